@@ -1,27 +1,49 @@
 package main
 
 import (
+	"context"
 	"event-service/configs"
+	middlewares "event-service/configs/middleware"
 	addressRepoQuery "event-service/internal/modules/address/repositories/queries"
 	eventHandler "event-service/internal/modules/event/handlers"
+	eventRepoCache "event-service/internal/modules/event/repositories/caches"
 	eventRepoCommand "event-service/internal/modules/event/repositories/commands"
 	eventRepoQuery "event-service/internal/modules/event/repositories/queries"
 	eventUsecase "event-service/internal/modules/event/usecases"
+	organizerHandler "event-service/internal/modules/organizer/handlers"
+	organizerRepoCommand "event-service/internal/modules/organizer/repositories/commands"
+	organizerRepoQuery "event-service/internal/modules/organizer/repositories/queries"
+	organizerUsecase "event-service/internal/modules/organizer/usecases"
+	outboxHandler "event-service/internal/modules/outbox/handlers"
+	outboxRepoCommand "event-service/internal/modules/outbox/repositories/commands"
+	outboxRepoQuery "event-service/internal/modules/outbox/repositories/queries"
+	outboxUsecase "event-service/internal/modules/outbox/usecases"
 	ticketRepoCommand "event-service/internal/modules/ticket/repositories/commands"
+	ticketRepoQuery "event-service/internal/modules/ticket/repositories/queries"
+	ticketRepoStock "event-service/internal/modules/ticket/repositories/stocks"
+	userHandler "event-service/internal/modules/user/handlers"
+	userRepoCache "event-service/internal/modules/user/repositories/caches"
+	userRepoQuery "event-service/internal/modules/user/repositories/queries"
+	userUsecase "event-service/internal/modules/user/usecases"
 	"event-service/internal/pkg/apm"
+	"event-service/internal/pkg/constants"
 	"event-service/internal/pkg/databases/mongodb"
 	graceful "event-service/internal/pkg/gs"
 	"event-service/internal/pkg/helpers"
 	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
 	"event-service/internal/pkg/log"
+	"event-service/internal/pkg/migrations"
 	"event-service/internal/pkg/redis"
+	"event-service/internal/pkg/scheduler"
 	"fmt"
 	logGo "log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/expvar"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
@@ -55,6 +77,15 @@ func main() {
 	logZap := log.SetupLogger(configs.GetConfig().ServiceName)
 	log.Init(logZap)
 
+	// Init Permission Matrix, it must be loaded before the routes are registered
+	if err := middlewares.InitPermissionMatrix(configs.GetConfig().PermissionMatrixFile); err != nil {
+		panic(err)
+	}
+	// Init Internal Auth, the machine credentials of the internal routes
+	if err := middlewares.InitInternalAuth(configs.GetConfig().InternalAuth); err != nil {
+		panic(err)
+	}
+
 	// Init BlacklistedEmail
 	helpers.InitReadBlackListEmail()
 
@@ -69,6 +100,7 @@ func main() {
 	app.Use(recover.New())
 	app.Use(cors.New())
 	app.Use(pprof.New())
+	app.Use(expvar.New())
 	if configs.GetConfig().AppsLimiter {
 		app.Use(limiter.New(limiter.Config{
 			Max:               100,
@@ -99,6 +131,8 @@ func main() {
 	if err := app.Listen(fmt.Sprintf(":%s", configs.GetConfig().ServicePort)); err != nil {
 		logGo.Fatal(err)
 	}
+	// the server is down, close the consumers, schedulers and connections registered by setHttp
+	gs.Cleanup()
 }
 
 func setHttp(app *fiber.App, gs *graceful.GracefulShutdown) {
@@ -109,31 +143,142 @@ func setHttp(app *fiber.App, gs *graceful.GracefulShutdown) {
 	helperImpl := &helpers.JwtImpl{}
 	helperImpl.InitConfig(configs.GetConfig().Jwt.JwtPrivateKey, configs.GetConfig().Jwt.JwtPublicKey,
 		configs.GetConfig().Jwt.JwtRefreshPrivateKey, configs.GetConfig().Jwt.JwtRefreshPublicKey)
+	// with a key set the access tokens are verified with the key named by their kid, the keys rotate without a deploy
+	var jwks *helpers.Jwks
+	if configs.GetConfig().Jwt.JwksSource != "" {
+		jwks = helpers.NewJwks(configs.GetConfig().Jwt.JwksSource, log.GetLogger())
+		if err := jwks.Refresh(context.Background()); err != nil {
+			panic(err)
+		}
+		jwksRefreshInterval, err := strconv.Atoi(configs.GetConfig().Jwt.JwksRefreshInterval)
+		if err != nil || jwksRefreshInterval <= 0 {
+			jwksRefreshInterval = 300
+		}
+		jwks.Start(time.Duration(jwksRefreshInterval) * time.Second)
+		gs.Register(jwks)
+	}
+	jwtAlgorithms := strings.FieldsFunc(configs.GetConfig().Jwt.JwtAlgorithms, func(r rune) bool { return r == ',' || r == ' ' })
+	if err := helperImpl.InitVerification(helpers.JwtVerifyConfig{
+		Issuer:     configs.GetConfig().Jwt.JwtIssuer,
+		Audience:   configs.GetConfig().Jwt.JwtAudience,
+		Algorithms: jwtAlgorithms,
+	}, jwks); err != nil {
+		panic(err)
+	}
 
 	logger := log.GetLogger()
 	mongoMasterClient := mongodb.NewMongoDBLogger(mongodb.GetMasterConn(), mongodb.GetMasterDBName(), logger)
 	mongoSlaveClient := mongodb.NewMongoDBLogger(mongodb.GetSlaveConn(), mongodb.GetMasterDBName(), logger)
+	// replicas starting together may run the same migration, they are idempotent
+	if configs.GetConfig().MigrateOnStartup {
+		if err := migrations.NewRunner(mongoMasterClient, logger, migrations.All()...).Run(context.Background()); err != nil {
+			panic(err)
+		}
+	}
 	kafkaProducer, err := kafkaConfluent.NewProducer(kafkaConfluent.GetConfig().GetKafkaConfig(configs.GetConfig().ServiceName, true), logger)
 	if err != nil {
 		panic(err)
 	}
-	gs.Register(
-		mongoMasterClient,
-		mongoSlaveClient,
-		graceful.FnWithError(redisClient.Close),
-		kafkaProducer,
-	)
 
 	addressQueryMongodbRepo := addressRepoQuery.NewQueryMongodbRepository(mongoSlaveClient, logger)
+	ticketQueryMongodbRepo := ticketRepoQuery.NewQueryMongodbRepository(mongoSlaveClient, logger)
 	ticketCommandMongodbRepo := ticketRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	ticketStockRedisRepo := ticketRepoStock.NewStockRedisRepository(redisClient, logger)
+	userQueryMongodbRepo := userRepoQuery.NewQueryMongodbRepository(mongoSlaveClient, logger)
+	userCacheRedisRepo := userRepoCache.NewCacheRedisRepository(redisClient, logger)
+	userUsecaseCommand := userUsecase.NewCommandUsecase(userCacheRedisRepo, logger)
+
+	organizerQueryMongodbRepo := organizerRepoQuery.NewQueryMongodbRepository(mongoSlaveClient, logger)
+	organizerCommandMongodbRepo := organizerRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	organizerUsecaseCommand := organizerUsecase.NewCommandUsecase(organizerQueryMongodbRepo, organizerCommandMongodbRepo,
+		userQueryMongodbRepo, logger)
+	organizerUsecaseQuery := organizerUsecase.NewQueryUsecase(organizerQueryMongodbRepo, logger)
+
+	// the relay reads from master so a message is never picked up before it is visible
+	outboxQueryMongodbRepo := outboxRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	outboxCommandMongodbRepo := outboxRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	outboxUsecaseCommand := outboxUsecase.NewCommandUsecase(outboxQueryMongodbRepo, outboxCommandMongodbRepo, kafkaProducer, logger)
+	outboxUsecaseQuery := outboxUsecase.NewQueryUsecase(outboxQueryMongodbRepo, logger)
 
 	eventQueryMongodbRepo := eventRepoQuery.NewQueryMongodbRepository(mongoSlaveClient, logger)
 	eventCommandMongodbRepo := eventRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
-	eventUsecaseCommand := eventUsecase.NewCommandUsecase(eventQueryMongodbRepo, eventCommandMongodbRepo,
-		ticketCommandMongodbRepo, addressQueryMongodbRepo, kafkaProducer, logger)
-	eventUsecaseQuery := eventUsecase.NewQueryUsecase(eventQueryMongodbRepo, logger)
+	eventCacheTTL, err := strconv.Atoi(configs.GetConfig().EventCacheTTL)
+	if err != nil || eventCacheTTL <= 0 {
+		eventCacheTTL = 30
+	}
+	// the command usecase keeps reading mongodb, it must never decide on cached data
+	eventCacheRedisRepo := eventRepoCache.NewCacheRedisRepository(eventQueryMongodbRepo, redisClient,
+		time.Duration(eventCacheTTL)*time.Second, logger)
+	eventUsecaseCommand := eventUsecase.NewCommandUsecase(eventQueryMongodbRepo, eventCommandMongodbRepo, eventCacheRedisRepo,
+		ticketQueryMongodbRepo, ticketCommandMongodbRepo, ticketStockRedisRepo, addressQueryMongodbRepo, outboxCommandMongodbRepo,
+		organizerQueryMongodbRepo, kafkaProducer, logger)
+	eventUsecaseQuery := eventUsecase.NewQueryUsecase(eventCacheRedisRepo, ticketQueryMongodbRepo, ticketStockRedisRepo,
+		organizerQueryMongodbRepo, logger)
+
+	// Init event scheduler
+	schedulerInterval, err := strconv.Atoi(configs.GetConfig().SchedulerInterval)
+	if err != nil || schedulerInterval <= 0 {
+		schedulerInterval = 30
+	}
+	eventScheduler := scheduler.NewScheduler(redisClient, logger, constants.RedisKeyEventSchedulerLock,
+		time.Duration(schedulerInterval)*time.Second, eventUsecaseCommand.ProcessScheduledEvents)
+	eventScheduler.Start()
+
+	outboxRelayInterval, err := strconv.Atoi(configs.GetConfig().OutboxRelayInterval)
+	if err != nil || outboxRelayInterval <= 0 {
+		outboxRelayInterval = 5
+	}
+	outboxRelay := scheduler.NewScheduler(redisClient, logger, constants.RedisKeyOutboxRelayLock,
+		time.Duration(outboxRelayInterval)*time.Second, outboxUsecaseCommand.RelayMessages)
+	outboxRelay.Start()
+
+	stockReconcileInterval, err := strconv.Atoi(configs.GetConfig().StockReconcileInterval)
+	if err != nil || stockReconcileInterval <= 0 {
+		stockReconcileInterval = 30
+	}
+	stockReconciler := scheduler.NewScheduler(redisClient, logger, constants.RedisKeyStockReconcileLock,
+		time.Duration(stockReconcileInterval)*time.Second, eventUsecaseCommand.ReconcileTicketStock)
+	stockReconciler.Start()
+
+	// Init kafka consumer
+	consumerMaxRetries, err := strconv.Atoi(configs.GetConfig().Kafka.KafkaConsumerMaxRetries)
+	if err != nil || consumerMaxRetries < 0 {
+		consumerMaxRetries = 3
+	}
+	consumerRetryBackoff, err := strconv.Atoi(configs.GetConfig().Kafka.KafkaConsumerRetryBackoff)
+	if err != nil || consumerRetryBackoff <= 0 {
+		consumerRetryBackoff = 500
+	}
+	kafkaRouter := kafkaConfluent.NewRouter(kafkaConfluent.RouterConfig{
+		MaxRetries:       consumerMaxRetries,
+		RetryBackoff:     time.Duration(consumerRetryBackoff) * time.Millisecond,
+		DeadLetterSuffix: configs.GetConfig().Kafka.KafkaDeadLetterSuffix,
+	}, kafkaProducer, logger)
+	eventHandler.InitEventKafkaHandler(kafkaRouter, eventUsecaseCommand, logger)
+	userHandler.InitUserKafkaHandler(kafkaRouter, userUsecaseCommand, logger)
+	kafkaConsumer, err := kafkaConfluent.NewConsumer(kafkaConfluent.GetConfig().GetKafkaConfig(configs.GetConfig().ServiceName, false), kafkaRouter, logger)
+	if err != nil {
+		panic(err)
+	}
+	if err := kafkaConsumer.Start(); err != nil {
+		panic(err)
+	}
+
+	// the schedulers and the consumer are stopped first so a running job never sees closed clients
+	gs.Register(
+		eventScheduler,
+		outboxRelay,
+		stockReconciler,
+		kafkaConsumer,
+		mongoMasterClient,
+		mongoSlaveClient,
+		graceful.FnWithError(redisClient.Close),
+		kafkaProducer,
+	)
 
 	// set module
 	eventHandler.InitEventHttpHandler(app, eventUsecaseCommand, eventUsecaseQuery, logger, redisClient)
+	outboxHandler.InitOutboxHttpHandler(app, outboxUsecaseQuery, logger, redisClient)
+	organizerHandler.InitOrganizerHttpHandler(app, organizerUsecaseCommand, organizerUsecaseQuery, logger, redisClient)
 
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"event-service/configs"
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/log"
+	"event-service/internal/pkg/migrations"
+	"flag"
+	"fmt"
+	logGo "log"
+)
+
+// migrate applies the pending database migrations, with -status it only lists them.
+func main() {
+	status := flag.Bool("status", false, "list the pending migrations without applying them")
+	flag.Parse()
+
+	// Init Config
+	configs.InitConfig()
+
+	// Init MongoDB Connection
+	mongo := mongodb.MongoImpl{}
+	mongo.SetCollections(&mongo)
+	mongo.InitConnection(configs.GetConfig().MongoDB.MongoMasterDBUrl, configs.GetConfig().MongoDB.MongoSlaveDBUrl)
+	// Init Logger
+	logZap := log.SetupLogger(configs.GetConfig().ServiceName)
+	log.Init(logZap)
+
+	ctx := context.Background()
+	mongoMasterClient := mongodb.NewMongoDBLogger(mongodb.GetMasterConn(), mongodb.GetMasterDBName(), log.GetLogger())
+	defer mongoMasterClient.Close(ctx)
+
+	runner := migrations.NewRunner(mongoMasterClient, log.GetLogger(), migrations.All()...)
+	if *status {
+		pending, err := runner.Pending(ctx)
+		if err != nil {
+			logGo.Fatal(err)
+		}
+		for _, migration := range pending {
+			fmt.Printf("pending %d: %s\n", migration.Version, migration.Description)
+		}
+		fmt.Printf("%d pending migrations\n", len(pending))
+		return
+	}
+
+	if err := runner.Run(ctx); err != nil {
+		logGo.Fatal(err)
+	}
+}
@@ -10,25 +10,32 @@ import (
 var Cfg Config
 
 type Config struct {
-	ServiceName       string           `envconfig:"service_name"`
-	ServiceVersion    string           `envconfig:"service_version"`
-	ServicePort       string           `envconfig:"service_port"`
-	ServiceEnv        string           `envconfig:"service_env"`
-	HttpServer        HttpServerConfig `envconfig:"http_server"`
-	Logger            LoggerConfig     `envconfig:"logger"`
-	Database          DatabaseConfig   `envconfig:"database"`
-	Redis             RedisConfig      `envconfig:"redis"`
-	MongoDB           MongoDBConfig    `envconfig:"mongo"`
-	APMElastic        APMElasticConfig `envconfig:"apm"`
-	Datadog           DatadogConfig    `envconfig:"datadog"`
-	Kafka             KafkaConfig      `envconfig:"kafka"`
-	Jwt               JwtConfig        `envconfig:"jwt"`
-	UsernameBasicAuth string           `envconfig:"username_basic_auth"`
-	PasswordBasicAuth string           `envconfig:"password_basic_auth"`
-	ShutDownDelay     string           `envconfig:"shutdown_delay"`
-	SecretHashPass    string           `envconfig:"secret_hash_pass"`
-	IdHash            string           `envconfig:"id_hash"`
-	AppsLimiter       bool             `envconfig:"apps_limiter"`
+	ServiceName            string             `envconfig:"service_name"`
+	ServiceVersion         string             `envconfig:"service_version"`
+	ServicePort            string             `envconfig:"service_port"`
+	ServiceEnv             string             `envconfig:"service_env"`
+	HttpServer             HttpServerConfig   `envconfig:"http_server"`
+	Logger                 LoggerConfig       `envconfig:"logger"`
+	Database               DatabaseConfig     `envconfig:"database"`
+	Redis                  RedisConfig        `envconfig:"redis"`
+	MongoDB                MongoDBConfig      `envconfig:"mongo"`
+	APMElastic             APMElasticConfig   `envconfig:"apm"`
+	Datadog                DatadogConfig      `envconfig:"datadog"`
+	Kafka                  KafkaConfig        `envconfig:"kafka"`
+	Jwt                    JwtConfig          `envconfig:"jwt"`
+	UsernameBasicAuth      string             `envconfig:"username_basic_auth"`
+	PasswordBasicAuth      string             `envconfig:"password_basic_auth"`
+	ShutDownDelay          string             `envconfig:"shutdown_delay"`
+	SecretHashPass         string             `envconfig:"secret_hash_pass"`
+	IdHash                 string             `envconfig:"id_hash"`
+	AppsLimiter            bool               `envconfig:"apps_limiter"`
+	SchedulerInterval      string             `envconfig:"scheduler_interval"`
+	OutboxRelayInterval    string             `envconfig:"outbox_relay_interval"`
+	StockReconcileInterval string             `envconfig:"stock_reconcile_interval"`
+	EventCacheTTL          string             `envconfig:"event_cache_ttl"`
+	MigrateOnStartup       bool               `envconfig:"migrate_on_startup"`
+	PermissionMatrixFile   string             `envconfig:"permission_matrix_file"`
+	InternalAuth           InternalAuthConfig `envconfig:"internal_auth"`
 }
 
 type HttpServerConfig struct {
@@ -82,9 +89,12 @@ type DatadogConfig struct {
 }
 
 type KafkaConfig struct {
-	KafkaUrl      string `envconfig:"kafka_url"`
-	KafkaUsername string `envconfig:"kafka_username"`
-	KafkaPassword string `envconfig:"kafka_password"`
+	KafkaUrl                  string `envconfig:"kafka_url"`
+	KafkaUsername             string `envconfig:"kafka_username"`
+	KafkaPassword             string `envconfig:"kafka_password"`
+	KafkaConsumerMaxRetries   string `envconfig:"kafka_consumer_max_retries"`
+	KafkaConsumerRetryBackoff string `envconfig:"kafka_consumer_retry_backoff"`
+	KafkaDeadLetterSuffix     string `envconfig:"kafka_dead_letter_suffix"`
 }
 
 type JwtConfig struct {
@@ -92,6 +102,19 @@ type JwtConfig struct {
 	JwtPublicKey         string `envconfig:"public_key"`
 	JwtRefreshPrivateKey string `envconfig:"private_key_refresh"`
 	JwtRefreshPublicKey  string `envconfig:"public_key_refresh"`
+	JwksSource           string `envconfig:"jwt_jwks_source"`
+	JwksRefreshInterval  string `envconfig:"jwt_jwks_refresh_interval"`
+	JwtIssuer            string `envconfig:"jwt_issuer"`
+	JwtAudience          string `envconfig:"jwt_audience"`
+	JwtAlgorithms        string `envconfig:"jwt_algorithms"`
+}
+
+type InternalAuthConfig struct {
+	HmacKeys     string `envconfig:"internal_auth_hmac_keys"`
+	JwtKeys      string `envconfig:"internal_auth_jwt_keys"`
+	JwtIssuer    string `envconfig:"internal_auth_jwt_issuer"`
+	JwtAudience  string `envconfig:"internal_auth_jwt_audience"`
+	MaxClockSkew string `envconfig:"internal_auth_max_clock_skew"`
 }
 
 func InitConfig() *Config {
@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+
+	config "event-service/configs"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+)
+
+// Headers of a request signed with an internal hmac key.
+const (
+	HeaderInternalKeyId     = "X-Internal-Key-Id"
+	HeaderInternalTimestamp = "X-Internal-Timestamp"
+	HeaderInternalSignature = "X-Internal-Signature"
+)
+
+const defaultInternalClockSkew = 5 * time.Minute
+
+// InternalAuth holds the machine credentials accepted on the internal routes. Every key is looked up by its id, so a
+// new key can be added next to the old one and the old one removed once no caller uses it anymore.
+type InternalAuth struct {
+	HmacKeys     map[string]string
+	JwtKeys      map[string]string
+	JwtIssuer    string
+	JwtAudience  string
+	MaxClockSkew time.Duration
+}
+
+var internalAuth = InternalAuth{MaxClockSkew: defaultInternalClockSkew}
+
+// ParseInternalKeys reads keys shaped like "keyId:secret,keyId:secret".
+func ParseInternalKeys(raw string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		keyId, secret, found := strings.Cut(pair, ":")
+		if !found || keyId == "" || secret == "" {
+			return nil, fmt.Errorf("invalid internal key '%s', expected keyId:secret", keyId)
+		}
+		if _, exist := keys[keyId]; exist {
+			return nil, fmt.Errorf("duplicate internal key '%s'", keyId)
+		}
+		keys[keyId] = secret
+	}
+	return keys, nil
+}
+
+// NewInternalAuth reads the internal credentials of the config. The jwt keys need an issuer and an audience, a token
+// signed for another service must not be accepted here.
+func NewInternalAuth(conf config.InternalAuthConfig) (InternalAuth, error) {
+	hmacKeys, err := ParseInternalKeys(conf.HmacKeys)
+	if err != nil {
+		return InternalAuth{}, err
+	}
+	jwtKeys, err := ParseInternalKeys(conf.JwtKeys)
+	if err != nil {
+		return InternalAuth{}, err
+	}
+	if len(jwtKeys) > 0 && (conf.JwtIssuer == "" || conf.JwtAudience == "") {
+		return InternalAuth{}, fmt.Errorf("internal jwt keys need an issuer and an audience")
+	}
+
+	maxClockSkew := defaultInternalClockSkew
+	if conf.MaxClockSkew != "" {
+		seconds, err := strconv.Atoi(conf.MaxClockSkew)
+		if err != nil || seconds <= 0 {
+			return InternalAuth{}, fmt.Errorf("invalid internal max clock skew '%s'", conf.MaxClockSkew)
+		}
+		maxClockSkew = time.Duration(seconds) * time.Second
+	}
+
+	return InternalAuth{
+		HmacKeys:     hmacKeys,
+		JwtKeys:      jwtKeys,
+		JwtIssuer:    conf.JwtIssuer,
+		JwtAudience:  conf.JwtAudience,
+		MaxClockSkew: maxClockSkew,
+	}, nil
+}
+
+// InitInternalAuth must run before the routes are registered, VerifyInternal reads the credentials once.
+func InitInternalAuth(conf config.InternalAuthConfig) error {
+	auth, err := NewInternalAuth(conf)
+	if err != nil {
+		return err
+	}
+	internalAuth = auth
+	return nil
+}
+
+// SignInternalRequest returns the hex hmac-sha256 of a request, callers send it in HeaderInternalSignature. The uri is
+// the path with its query string and timestamp is in unix seconds.
+func SignInternalRequest(secret string, method string, uri string, timestamp string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join([]string{method, uri, timestamp, hex.EncodeToString(bodyHash[:])}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyInternal lets through the requests signed with an internal hmac key or bearing a client-credential jwt of the
+// current credentials, and sets the caller in the clientId local.
+func VerifyInternal() fiber.Handler {
+	return internalAuth.Verify()
+}
+
+func (a InternalAuth) Verify() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		logger := log.GetLogger()
+		var clientId string
+		var err error
+		if c.Get(HeaderInternalSignature) != "" {
+			clientId, err = a.verifyHmac(c, time.Now())
+		} else {
+			clientId, err = a.verifyJwt(c)
+		}
+		if err != nil {
+			logger.Error(c.Context(), "Invalid internal credentials", err.Error())
+			return helpers.RespError(c, logger, err)
+		}
+
+		c.Locals("clientId", clientId)
+		return c.Next()
+	}
+}
+
+func (a InternalAuth) verifyHmac(c *fiber.Ctx, now time.Time) (string, error) {
+	keyId := c.Get(HeaderInternalKeyId)
+	secret, ok := a.HmacKeys[keyId]
+	if !ok {
+		return "", errors.UnauthorizedError("Unknown internal key")
+	}
+
+	timestamp := c.Get(HeaderInternalTimestamp)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", errors.UnauthorizedError("Invalid internal timestamp")
+	}
+	// a captured request can only be replayed within the skew
+	signedAt := time.Unix(seconds, 0)
+	if signedAt.Before(now.Add(-a.MaxClockSkew)) || signedAt.After(now.Add(a.MaxClockSkew)) {
+		return "", errors.UnauthorizedError("Internal signature expired")
+	}
+
+	expected := SignInternalRequest(secret, c.Method(), c.OriginalURL(), timestamp, c.Body())
+	if !hmac.Equal([]byte(expected), []byte(c.Get(HeaderInternalSignature))) {
+		return "", errors.UnauthorizedError("Invalid internal signature")
+	}
+	return keyId, nil
+}
+
+func (a InternalAuth) verifyJwt(c *fiber.Ctx) (string, error) {
+	token := strings.Split(c.Get(fiber.HeaderAuthorization), " ")
+	if len(token) != 2 || (token[0] != "Bearer" && token[0] != "bearer") || token[1] == "" {
+		return "", errors.UnauthorizedError("Missing internal credentials")
+	}
+
+	claims := new(jwt.RegisteredClaims)
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	_, err := parser.ParseWithClaims(token[1], claims, func(parsed *jwt.Token) (interface{}, error) {
+		kid, _ := parsed.Header["kid"].(string)
+		secret, ok := a.JwtKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown internal key '%s'", kid)
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return "", errors.UnauthorizedError("Invalid internal token")
+	}
+
+	if claims.ExpiresAt == nil || !claims.VerifyIssuer(a.JwtIssuer, true) || !claims.VerifyAudience(a.JwtAudience, true) {
+		return "", errors.UnauthorizedError("Invalid internal token")
+	}
+	if claims.Subject == "" {
+		return "", errors.UnauthorizedError("Invalid internal token subject")
+	}
+	return claims.Subject, nil
+}
@@ -0,0 +1,188 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	config "event-service/configs"
+	"event-service/configs/middleware"
+	"event-service/internal/pkg/log"
+)
+
+const internalPath = "/internal?tag=tag"
+
+func internalApp(t *testing.T) *fiber.App {
+	// the middleware logs through the global logger
+	log.Init((&log.LoggerConf{}).Clone(zap.NewNop()))
+	auth, err := middleware.NewInternalAuth(config.InternalAuthConfig{
+		HmacKeys:    "order-old:old-secret,order-new:new-secret",
+		JwtKeys:     "ticket-old:old-jwt-secret,ticket-new:new-jwt-secret",
+		JwtIssuer:   "auth-service",
+		JwtAudience: "event-service",
+	})
+	assert.NoError(t, err)
+
+	app := fiber.New()
+	app.Post("/internal", auth.Verify(), func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals("clientId").(string))
+	})
+	return app
+}
+
+func testInternal(t *testing.T, app *fiber.App, req *http.Request) (int, string) {
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func signedRequest(keyId string, secret string, signedAt time.Time, signedBody string) *http.Request {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	req := httptest.NewRequest(fiber.MethodPost, internalPath, strings.NewReader(`{"quantity":1}`))
+	req.Header.Set(middleware.HeaderInternalKeyId, keyId)
+	req.Header.Set(middleware.HeaderInternalTimestamp, timestamp)
+	req.Header.Set(middleware.HeaderInternalSignature,
+		middleware.SignInternalRequest(secret, fiber.MethodPost, internalPath, timestamp, []byte(signedBody)))
+	return req
+}
+
+func bearerRequest(t *testing.T, kid string, secret string, claims jwt.RegisteredClaims) *http.Request {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString([]byte(secret))
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(fiber.MethodPost, internalPath, nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+signed)
+	return req
+}
+
+func clientClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   "ticket-service",
+		Issuer:    "auth-service",
+		Audience:  jwt.ClaimStrings{"event-service"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}
+}
+
+func TestParseInternalKeys(t *testing.T) {
+	keys, err := middleware.ParseInternalKeys(" a:secret-a , b:secret:b,")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "secret-a", "b": "secret:b"}, keys)
+
+	_, err = middleware.ParseInternalKeys("a:secret,a:other")
+	assert.EqualError(t, err, "duplicate internal key 'a'")
+	_, err = middleware.ParseInternalKeys("a")
+	assert.Error(t, err)
+}
+
+func TestNewInternalAuthErr(t *testing.T) {
+	_, err := middleware.NewInternalAuth(config.InternalAuthConfig{JwtKeys: "a:secret"})
+	assert.EqualError(t, err, "internal jwt keys need an issuer and an audience")
+
+	_, err = middleware.NewInternalAuth(config.InternalAuthConfig{MaxClockSkew: "-1"})
+	assert.Error(t, err)
+
+	auth, err := middleware.NewInternalAuth(config.InternalAuthConfig{MaxClockSkew: "60"})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, auth.MaxClockSkew)
+}
+
+func TestVerifyInternalHmac(t *testing.T) {
+	app := internalApp(t)
+
+	// both keys are accepted while the callers move to the new one
+	for keyId, secret := range map[string]string{"order-old": "old-secret", "order-new": "new-secret"} {
+		status, body := testInternal(t, app, signedRequest(keyId, secret, time.Now(), `{"quantity":1}`))
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, keyId, body)
+	}
+}
+
+func TestVerifyInternalHmacErr(t *testing.T) {
+	app := internalApp(t)
+
+	requests := map[string]*http.Request{
+		"unknown key":    signedRequest("order-removed", "old-secret", time.Now(), `{"quantity":1}`),
+		"wrong secret":   signedRequest("order-new", "old-secret", time.Now(), `{"quantity":1}`),
+		"tampered body":  signedRequest("order-new", "new-secret", time.Now(), `{"quantity":100}`),
+		"expired":        signedRequest("order-new", "new-secret", time.Now().Add(-10*time.Minute), `{"quantity":1}`),
+		"in the future":  signedRequest("order-new", "new-secret", time.Now().Add(10*time.Minute), `{"quantity":1}`),
+		"no credentials": httptest.NewRequest(fiber.MethodPost, internalPath, nil),
+	}
+	for name, req := range requests {
+		status, _ := testInternal(t, app, req)
+		assert.Equal(t, fiber.StatusUnauthorized, status, name)
+	}
+}
+
+func TestVerifyInternalJwt(t *testing.T) {
+	app := internalApp(t)
+
+	for kid, secret := range map[string]string{"ticket-old": "old-jwt-secret", "ticket-new": "new-jwt-secret"} {
+		status, body := testInternal(t, app, bearerRequest(t, kid, secret, clientClaims()))
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "ticket-service", body)
+	}
+}
+
+func TestVerifyInternalJwtErr(t *testing.T) {
+	app := internalApp(t)
+
+	otherIssuer := clientClaims()
+	otherIssuer.Issuer = "user-service"
+	otherAudience := clientClaims()
+	otherAudience.Audience = jwt.ClaimStrings{"order-service"}
+	expired := clientClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	noExpiry := clientClaims()
+	noExpiry.ExpiresAt = nil
+	noSubject := clientClaims()
+	noSubject.Subject = ""
+
+	requests := map[string]*http.Request{
+		"unknown kid":    bearerRequest(t, "ticket-removed", "old-jwt-secret", clientClaims()),
+		"wrong secret":   bearerRequest(t, "ticket-new", "old-jwt-secret", clientClaims()),
+		"other issuer":   bearerRequest(t, "ticket-new", "new-jwt-secret", otherIssuer),
+		"other audience": bearerRequest(t, "ticket-new", "new-jwt-secret", otherAudience),
+		"expired":        bearerRequest(t, "ticket-new", "new-jwt-secret", expired),
+		"no expiry":      bearerRequest(t, "ticket-new", "new-jwt-secret", noExpiry),
+		"no subject":     bearerRequest(t, "ticket-new", "new-jwt-secret", noSubject),
+	}
+	for name, req := range requests {
+		status, _ := testInternal(t, app, req)
+		assert.Equal(t, fiber.StatusUnauthorized, status, name)
+	}
+}
+
+func TestVerifyInternalJwtErrAlgorithm(t *testing.T) {
+	app := internalApp(t)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS512, clientClaims())
+	token.Header["kid"] = "ticket-new"
+	signed, err := token.SignedString([]byte("new-jwt-secret"))
+	assert.NoError(t, err)
+	req := httptest.NewRequest(fiber.MethodPost, internalPath, nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+signed)
+
+	status, _ := testInternal(t, app, req)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+}
+
+func TestInitInternalAuth(t *testing.T) {
+	assert.NoError(t, middleware.InitInternalAuth(config.InternalAuthConfig{HmacKeys: "a:secret"}))
+	assert.Error(t, middleware.InitInternalAuth(config.InternalAuthConfig{HmacKeys: "a"}))
+	assert.NoError(t, middleware.InitInternalAuth(config.InternalAuthConfig{}))
+}
@@ -1,10 +1,10 @@
 package middleware
 
 import (
-	"encoding/json"
 	config "event-service/configs"
 	userDto "event-service/internal/modules/user/models/dto"
 	userEntity "event-service/internal/modules/user/models/entity"
+	userRepoCaches "event-service/internal/modules/user/repositories/caches"
 	userRepoQueries "event-service/internal/modules/user/repositories/queries"
 	"event-service/internal/pkg/constants"
 	"event-service/internal/pkg/databases/mongodb"
@@ -14,7 +14,6 @@ import (
 	"event-service/internal/pkg/redis"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/basicauth"
@@ -38,6 +37,23 @@ func (m Middlewares) VerifyBasicAuth() fiber.Handler {
 	})
 }
 
+// UserContextKey is the local holding the UserContext of a request.
+const UserContextKey = "userContext"
+
+// UserContext is the user of a request, its profile comes from the user collection and not from the token.
+type UserContext struct {
+	userDto.UserResp
+	Token string
+}
+
+// GetUserContext returns the user set by VerifyBearer.
+func GetUserContext(c *fiber.Ctx) (UserContext, bool) {
+	userContext, ok := c.Locals(UserContextKey).(UserContext)
+	return userContext, ok
+}
+
+// VerifyBearer sets the UserContext of the request, and the userId and userRole locals read by the handlers. The role
+// is the one of the profile, so a changed role or a blocked user applies without waiting for the token to expire.
 func (m Middlewares) VerifyBearer() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		logger := log.GetLogger()
@@ -58,35 +74,58 @@ func (m Middlewares) VerifyBearer() fiber.Handler {
 			logger.Error(c.Context(), "Access token expired!", "Token blocklist")
 			return helpers.RespError(c, logger, errors.UnauthorizedError("Access token expired!"))
 		}
-		result, _ := redisClient.Get(c.Context(), fmt.Sprintf("%s:%s", constants.RedisKeyGetProfileUser, parseToken.UserId)).Result()
-		if result == "" {
-			userQueryMongodbRepo := userRepoQueries.NewQueryMongodbRepository(mongodb.NewMongoDBLogger(mongodb.GetSlaveConn(), mongodb.GetSlaveDBName(), logger), logger)
-			resp := <-userQueryMongodbRepo.FindOneUserId(c.Context(), parseToken.UserId)
-			if resp.Error != nil {
-				return helpers.RespError(c, logger, resp.Error)
-			}
-			if resp.Data == nil {
-				return helpers.RespError(c, logger, errors.ForbiddenError("Invalid token!"))
-			}
-			convert, ok := resp.Data.(*userEntity.User)
-			if !ok {
-				return helpers.RespError(c, logger, errors.UnauthorizedError("Access token expired!"))
-			}
-			dataUser, _ := json.Marshal(userDto.UserData{
-				Data: userDto.UserResp{
-					FullName:  convert.FullName,
-					Email:     convert.Email,
-					Role:      convert.Role,
-					UserId:    convert.UserId,
-					CreatedAt: convert.CreatedAt,
-					UpdatedAt: convert.UpdatedAt,
-				},
-			})
-			redisClient.Set(c.Context(), fmt.Sprintf("%s:%s", constants.RedisKeyGetProfileUser, parseToken.UserId), dataUser, 10*time.Minute)
+
+		profile, err := m.findProfile(c, logger, parseToken.UserId)
+		if err != nil {
+			return helpers.RespError(c, logger, err)
 		}
-		c.Locals("userId", parseToken.UserId)
-		c.Locals("userRole", parseToken.Role)
+		if profile.Status != constants.UserStatusActive {
+			logger.Error(c.Context(), "User is not active", fmt.Sprintf("%s: %s", profile.UserId, profile.Status))
+			return helpers.RespError(c, logger, errors.ForbiddenError("User is not active!"))
+		}
+
+		c.Locals(UserContextKey, UserContext{UserResp: *profile, Token: parseToken.Token})
+		c.Locals("userId", profile.UserId)
+		c.Locals("userRole", profile.Role)
 		return c.Next()
 	}
 
 }
+
+// findProfile reads the profile from the cache, then from the mongodb master: the slave may not have the change the
+// consumer just dropped from the cache yet. A cached profile without status was written before the status was
+// cached, it is read again.
+func (m Middlewares) findProfile(c *fiber.Ctx, logger log.Logger, userId string) (*userDto.UserResp, error) {
+	userCacheRedisRepo := userRepoCaches.NewCacheRedisRepository(m.redisClient, logger)
+	cached := <-userCacheRedisRepo.FindProfile(c.Context(), userId)
+	if profile, ok := cached.Data.(*userDto.UserResp); ok && profile.Status != "" {
+		return profile, nil
+	}
+
+	userQueryMongodbRepo := userRepoQueries.NewQueryMongodbRepository(mongodb.NewMongoDBLogger(mongodb.GetMasterConn(), mongodb.GetMasterDBName(), logger), logger)
+	resp := <-userQueryMongodbRepo.FindOneUserId(c.Context(), userId)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	if resp.Data == nil {
+		return nil, errors.ForbiddenError("Invalid token!")
+	}
+	convert, ok := resp.Data.(*userEntity.User)
+	if !ok {
+		return nil, errors.UnauthorizedError("Access token expired!")
+	}
+
+	profile := userDto.UserResp{
+		FullName:    convert.FullName,
+		Email:       convert.Email,
+		Role:        convert.Role,
+		Status:      convert.Status,
+		UserId:      convert.UserId,
+		CountryCode: convert.Country.Code,
+		CreatedAt:   convert.CreatedAt,
+		UpdatedAt:   convert.UpdatedAt,
+	}
+	// the inactive users are cached too, the consumer drops them once they are active again
+	<-userCacheRedisRepo.SetProfile(c.Context(), profile)
+	return &profile, nil
+}
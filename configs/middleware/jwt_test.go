@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"event-service/configs/middleware"
+	userDto "event-service/internal/modules/user/models/dto"
+	"event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+	pkgRedis "event-service/internal/pkg/redis"
+)
+
+func initJwtKeys(t *testing.T) *helpers.JwtImpl {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	private := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	public := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+	jwtImpl := &helpers.JwtImpl{}
+	jwtImpl.InitConfig(private, public, private, public)
+	return jwtImpl
+}
+
+func bearerApp(t *testing.T, profile userDto.UserResp) (*fiber.App, string) {
+	log.Init((&log.LoggerConf{}).Clone(zap.NewNop()))
+	jwtImpl := initJwtKeys(t)
+	token, _, err := jwtImpl.GenerateToken(time.Hour, map[string]interface{}{"userId": "userId", "role": "admin"})
+	assert.NoError(t, err)
+
+	miniRedis := miniredis.RunT(t)
+	cached, _ := json.Marshal(userDto.UserData{Data: profile})
+	assert.NoError(t, miniRedis.Set("GET-PROFILE-USER:userId", string(cached)))
+
+	app := fiber.New()
+	redisClient := &pkgRedis.RedisClient{Client: redis.NewClient(&redis.Options{Addr: miniRedis.Addr()})}
+	app.Get("/", middleware.NewMiddlewares(redisClient).VerifyBearer(), func(c *fiber.Ctx) error {
+		userContext, ok := middleware.GetUserContext(c)
+		assert.True(t, ok)
+		assert.Equal(t, userContext.UserId, c.Locals("userId"))
+		assert.Equal(t, userContext.Role, c.Locals("userRole"))
+		return c.JSON(userContext)
+	})
+	return app, token
+}
+
+func TestVerifyBearer(t *testing.T) {
+	app, token := bearerApp(t, userDto.UserResp{UserId: "userId", FullName: "name", Role: "fan", Status: "active"})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	status, body := testInternal(t, app, req)
+
+	// the role of the profile wins over the one of the token
+	assert.Equal(t, fiber.StatusOK, status)
+	var userContext middleware.UserContext
+	assert.NoError(t, json.Unmarshal([]byte(body), &userContext))
+	assert.Equal(t, "fan", userContext.Role)
+	assert.Equal(t, "name", userContext.FullName)
+	assert.Equal(t, token, userContext.Token)
+}
+
+func TestVerifyBearerErrInactive(t *testing.T) {
+	app, token := bearerApp(t, userDto.UserResp{UserId: "userId", Role: "fan", Status: "blocked"})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	status, _ := testInternal(t, app, req)
+
+	assert.Equal(t, fiber.StatusForbidden, status)
+}
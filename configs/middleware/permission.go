@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+
+	"event-service/internal/pkg/constants"
+)
+
+// PermissionMatrix lists the permissions held by each role.
+type PermissionMatrix map[string][]string
+
+var permissions = []string{
+	constants.PermissionEventCreate,
+	constants.PermissionEventRead,
+	constants.PermissionEventUpdate,
+	constants.PermissionEventPublish,
+	constants.PermissionEventCancel,
+	constants.PermissionTicketManage,
+	constants.PermissionTicketHold,
+	constants.PermissionOnlineTicketConfigManage,
+	constants.PermissionOnlineTicketConfigRead,
+	constants.PermissionOutboxRead,
+	constants.PermissionOrganizerManage,
+	constants.PermissionOrganizerRead,
+}
+
+var permissionMatrix = DefaultPermissionMatrix()
+
+// DefaultPermissionMatrix is used when no matrix file is configured. Organizers manage events, the usecases limit them
+// to the ones of their teams.
+func DefaultPermissionMatrix() PermissionMatrix {
+	return PermissionMatrix{
+		constants.RoleAdmin: append([]string{}, permissions...),
+		constants.RoleOrganizer: {
+			constants.PermissionEventCreate,
+			constants.PermissionEventRead,
+			constants.PermissionEventUpdate,
+			constants.PermissionEventPublish,
+			constants.PermissionEventCancel,
+			constants.PermissionTicketManage,
+			constants.PermissionOnlineTicketConfigManage,
+			constants.PermissionOnlineTicketConfigRead,
+			constants.PermissionOrganizerManage,
+			constants.PermissionOrganizerRead,
+		},
+		constants.RoleFan: {
+			constants.PermissionEventRead,
+			constants.PermissionTicketHold,
+		},
+		constants.RoleSupport: {
+			constants.PermissionEventRead,
+			constants.PermissionOnlineTicketConfigRead,
+			constants.PermissionOrganizerRead,
+		},
+	}
+}
+
+// LoadPermissionMatrix reads a matrix from a json file shaped like {"role": ["permission", ...]}.
+func LoadPermissionMatrix(path string) (PermissionMatrix, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var matrix PermissionMatrix
+	if err := json.Unmarshal(content, &matrix); err != nil {
+		return nil, fmt.Errorf("invalid permission matrix %s: %w", path, err)
+	}
+
+	known := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		known[permission] = true
+	}
+	for role, rolePermissions := range matrix {
+		for _, permission := range rolePermissions {
+			if !known[permission] {
+				return nil, fmt.Errorf("unknown permission '%s' of role '%s'", permission, role)
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// InitPermissionMatrix must run before the routes are registered, RequirePermission resolves the roles once.
+// An empty path keeps the default matrix.
+func InitPermissionMatrix(path string) error {
+	if path == "" {
+		permissionMatrix = DefaultPermissionMatrix()
+		return nil
+	}
+
+	matrix, err := LoadPermissionMatrix(path)
+	if err != nil {
+		return err
+	}
+	permissionMatrix = matrix
+	return nil
+}
+
+// Roles returns the roles holding permission.
+func (m PermissionMatrix) Roles(permission string) []string {
+	roles := make([]string, 0, len(m))
+	for role, rolePermissions := range m {
+		for _, rolePermission := range rolePermissions {
+			if rolePermission == permission {
+				roles = append(roles, role)
+				break
+			}
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// RequirePermission lets through the roles holding permission in the current matrix.
+func RequirePermission(permission string) fiber.Handler {
+	return AllowedRoles(permissionMatrix.Roles(permission)...)
+}
@@ -0,0 +1,66 @@
+package middleware_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"event-service/configs/middleware"
+	"event-service/internal/pkg/constants"
+)
+
+func writeMatrix(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "permissions.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestDefaultPermissionMatrix(t *testing.T) {
+	matrix := middleware.DefaultPermissionMatrix()
+
+	assert.Equal(t, []string{constants.RoleAdmin, constants.RoleOrganizer}, matrix.Roles(constants.PermissionEventCreate))
+	assert.Equal(t, []string{constants.RoleAdmin, constants.RoleFan, constants.RoleOrganizer, constants.RoleSupport},
+		matrix.Roles(constants.PermissionEventRead))
+	assert.Equal(t, []string{constants.RoleAdmin}, matrix.Roles(constants.PermissionOutboxRead))
+}
+
+func TestLoadPermissionMatrix(t *testing.T) {
+	path := writeMatrix(t, `{"admin": ["event:create", "event:read"], "fan": ["event:read"]}`)
+
+	matrix, err := middleware.LoadPermissionMatrix(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{constants.RoleAdmin}, matrix.Roles(constants.PermissionEventCreate))
+	assert.Equal(t, []string{constants.RoleAdmin, constants.RoleFan}, matrix.Roles(constants.PermissionEventRead))
+	assert.Empty(t, matrix.Roles(constants.PermissionTicketHold))
+}
+
+func TestLoadPermissionMatrixErrUnknownPermission(t *testing.T) {
+	path := writeMatrix(t, `{"admin": ["event:delete"]}`)
+
+	_, err := middleware.LoadPermissionMatrix(path)
+
+	assert.EqualError(t, err, "unknown permission 'event:delete' of role 'admin'")
+}
+
+func TestLoadPermissionMatrixErrInvalid(t *testing.T) {
+	path := writeMatrix(t, `["admin"]`)
+
+	_, err := middleware.LoadPermissionMatrix(path)
+
+	assert.Error(t, err)
+}
+
+func TestLoadPermissionMatrixErrFile(t *testing.T) {
+	_, err := middleware.LoadPermissionMatrix(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.Error(t, err)
+}
+
+func TestInitPermissionMatrix(t *testing.T) {
+	assert.NoError(t, middleware.InitPermissionMatrix(writeMatrix(t, `{"fan": ["event:read"]}`)))
+	assert.Error(t, middleware.InitPermissionMatrix(writeMatrix(t, `{"fan": ["event:delete"]}`)))
+	assert.NoError(t, middleware.InitPermissionMatrix(""))
+}
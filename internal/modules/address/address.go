@@ -7,5 +7,6 @@ import (
 
 type MongodbRepositoryQuery interface {
 	FindOneCountry(ctx context.Context, id int) <-chan wrapper.Result
+	FindOneCountryByNumber(ctx context.Context, number int) <-chan wrapper.Result
 	FindOneContinentByCode(ctx context.Context, code string) <-chan wrapper.Result
 }
@@ -42,6 +42,25 @@ func (q queryMongodbRepository) FindOneCountry(ctx context.Context, id int) <-ch
 	return output
 }
 
+func (q queryMongodbRepository) FindOneCountryByNumber(ctx context.Context, number int) <-chan wrapper.Result {
+	var country entity.Country
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &country,
+			CollectionName: "country",
+			Filter: bson.M{
+				"number": number,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
 func (q queryMongodbRepository) FindOneContinentByCode(ctx context.Context, code string) <-chan wrapper.Result {
 	var continent entity.Continent
 	output := make(chan wrapper.Result)
@@ -2,6 +2,8 @@ package event
 
 import (
 	"context"
+	"time"
+
 	"event-service/internal/modules/event/models/entity"
 	"event-service/internal/modules/event/models/request"
 	"event-service/internal/modules/event/models/response"
@@ -10,19 +12,51 @@ import (
 
 type UsecaseQuery interface {
 	FindEvents(origCtx context.Context, payload request.AllEventReq) (*response.EventResp, error)
+	FindEventDetail(origCtx context.Context, payload request.EventDetailReq) (*response.EventDetail, error)
+	FindOnlineTicketAllocation(origCtx context.Context, tag string) (*response.OnlineTicketAllocation, error)
+	FindTicketAvailability(origCtx context.Context, ticketId string) (*response.TicketAvailability, error)
+	FindOnlineTicketConfig(origCtx context.Context, payload request.OnlineTicketConfigReq) (*response.OnlineTicketConfig, error)
+	FindOnlineTicketConfigs(origCtx context.Context, payload request.AllOnlineTicketConfigReq) (*response.OnlineTicketConfigResp, error)
+	FindOnlineTicketConfigHistory(origCtx context.Context, payload request.OnlineTicketConfigReq) ([]response.OnlineTicketConfigHistory, error)
 }
 
 type UsecaseCommand interface {
 	CreateEvent(origCtx context.Context, payload request.EventReq) (*string, error)
+	UpdateEvent(origCtx context.Context, payload request.UpdateEventReq) (*string, error)
+	PublishEvent(origCtx context.Context, payload request.PublishEventReq) (*string, error)
+	CancelEvent(origCtx context.Context, payload request.CancelEventReq) (*string, error)
+	AddTicketType(origCtx context.Context, payload request.AddTicketReq) (*string, error)
+	UpdateTicket(origCtx context.Context, payload request.UpdateTicketReq) (*string, error)
+	HoldTicket(origCtx context.Context, payload request.HoldTicketReq) (*response.TicketHold, error)
+	ConfirmTicketHold(origCtx context.Context, payload request.TicketHoldReq) (*string, error)
+	ReleaseTicketHold(origCtx context.Context, payload request.TicketHoldReq) (*string, error)
+	ReconcileTicketStock(origCtx context.Context) error
+	ReprovisionTickets(origCtx context.Context, payload request.ReprovisionTicketReq) (*string, error)
+	ProcessScheduledEvents(origCtx context.Context) error
+	AcknowledgeBankTicket(origCtx context.Context, payload request.BankTicketCreatedReq) error
+	AcknowledgeOrder(origCtx context.Context, payload request.OrderAckReq) error
 	CreateOnlineTicketConfig(origCtx context.Context, payload request.OnlineTicketReq) (*string, error)
+	DeleteOnlineTicketConfig(origCtx context.Context, payload request.OnlineTicketConfigReq) (*string, error)
 }
 
 type MongodbRepositoryQuery interface {
 	FindEventByName(ctx context.Context, name string) <-chan wrapper.Result
+	FindEventById(ctx context.Context, eventId string) <-chan wrapper.Result
 	FindEventByTag(ctx context.Context, tag string) <-chan wrapper.Result
-	FindAllEvent(ctx context.Context, payload request.AllEventReq) <-chan wrapper.Result
+	FindAllEvent(ctx context.Context, filter entity.EventFilter) <-chan wrapper.Result
+	FindEventsByStatusBefore(ctx context.Context, status string, field string, before time.Time) <-chan wrapper.Result
+}
+
+// RedisRepositoryCache reads through a redis cache in front of MongodbRepositoryQuery.
+type RedisRepositoryCache interface {
+	MongodbRepositoryQuery
+	RefreshEvent(ctx context.Context, event entity.Event) <-chan wrapper.Result
 }
 
 type MongodbRepositoryCommand interface {
 	InsertOneEventCollection(ctx context.Context, event entity.Event) <-chan wrapper.Result
+	UpdateOneEventDetail(ctx context.Context, event entity.Event, expected entity.EventVersion) <-chan wrapper.Result
+	UpdateOneEventStatus(ctx context.Context, event entity.Event, expected entity.EventVersion) <-chan wrapper.Result
+	AddEventTicketId(ctx context.Context, eventId string, ticketId string, updatedBy string) <-chan wrapper.Result
+	WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error
 }
@@ -3,6 +3,7 @@ package handlers
 import (
 	"event-service/internal/modules/event"
 	"event-service/internal/modules/event/models/request"
+	"event-service/internal/pkg/constants"
 	"event-service/internal/pkg/errors"
 	"event-service/internal/pkg/helpers"
 	"event-service/internal/pkg/log"
@@ -29,12 +30,38 @@ func InitEventHttpHandler(app *fiber.App, euc event.UsecaseCommand, euq event.Us
 		Logger:              log,
 		Validator:           validator.New(),
 	}
+	handler.RegisterInternalRoutes(app.Group("/internal/event"), middlewares.VerifyInternal())
 	middlewares := middlewares.NewMiddlewares(redisClient)
-	route := app.Group("/api/event")
+	handler.RegisterRoutes(app.Group("/api/event"), middlewares.VerifyBearer())
+}
+
+// RegisterInternalRoutes declares the lookups of the other services, auth must check their machine credentials.
+func (e EventHttpHandler) RegisterInternalRoutes(route fiber.Router, auth fiber.Handler) {
+	route.Get("/v1/tickets/:ticketId/availability", auth, e.GetTicketAvailability)
+	route.Get("/v1/online-ticket-config/:tag/quota", auth, e.GetOnlineTicketAllocation)
+	route.Post("/v1/tickets/:ticketId/hold/:holdId/confirm", auth, e.ConfirmTicketHold)
+}
 
-	route.Post("/v1/create-event", middlewares.VerifyBearer(), handler.CreateEvent)
-	route.Post("/v1/create-online-ticket-config", middlewares.VerifyBearer(), handler.CreateOnlineTicketConfig)
-	route.Get("/v1/list", middlewares.VerifyBearer(), handler.GetEvents)
+// RegisterRoutes declares the permission each route needs, auth must set the userId and userRole locals.
+func (e EventHttpHandler) RegisterRoutes(route fiber.Router, auth fiber.Handler) {
+	route.Post("/v1/create-event", auth, middlewares.RequirePermission(constants.PermissionEventCreate), e.CreateEvent)
+	route.Post("/v1/create-online-ticket-config", auth, middlewares.RequirePermission(constants.PermissionOnlineTicketConfigManage), e.CreateOnlineTicketConfig)
+	route.Get("/v1/online-ticket-config", auth, middlewares.RequirePermission(constants.PermissionOnlineTicketConfigRead), e.GetOnlineTicketConfigs)
+	route.Get("/v1/online-ticket-config/:tag", auth, middlewares.RequirePermission(constants.PermissionOnlineTicketConfigRead), e.GetOnlineTicketConfig)
+	route.Delete("/v1/online-ticket-config/:tag", auth, middlewares.RequirePermission(constants.PermissionOnlineTicketConfigManage), e.DeleteOnlineTicketConfig)
+	route.Get("/v1/online-ticket-config/:tag/history", auth, middlewares.RequirePermission(constants.PermissionOnlineTicketConfigRead), e.GetOnlineTicketConfigHistory)
+	route.Get("/v1/online-ticket-config/:tag/allocation", auth, middlewares.RequirePermission(constants.PermissionOnlineTicketConfigRead), e.GetOnlineTicketAllocation)
+	route.Get("/v1/list", auth, middlewares.RequirePermission(constants.PermissionEventRead), e.GetEvents)
+	route.Get("/v1/:eventId", auth, middlewares.RequirePermission(constants.PermissionEventRead), e.GetEventDetail)
+	route.Put("/v1/:eventId", auth, middlewares.RequirePermission(constants.PermissionEventUpdate), e.UpdateEvent)
+	route.Patch("/v1/:eventId", auth, middlewares.RequirePermission(constants.PermissionEventUpdate), e.UpdateEvent)
+	route.Post("/v1/:eventId/publish", auth, middlewares.RequirePermission(constants.PermissionEventPublish), e.PublishEvent)
+	route.Post("/v1/:eventId/cancel", auth, middlewares.RequirePermission(constants.PermissionEventCancel), e.CancelEvent)
+	route.Post("/v1/:eventId/reprovision", auth, middlewares.RequirePermission(constants.PermissionTicketManage), e.ReprovisionTickets)
+	route.Post("/v1/:eventId/tickets", auth, middlewares.RequirePermission(constants.PermissionTicketManage), e.AddTicketType)
+	route.Post("/v1/tickets/:ticketId/hold", auth, middlewares.RequirePermission(constants.PermissionTicketHold), e.HoldTicket)
+	route.Post("/v1/tickets/:ticketId/hold/:holdId/release", auth, middlewares.RequirePermission(constants.PermissionTicketHold), e.ReleaseTicketHold)
+	route.Patch("/v1/:eventId/tickets/:ticketId", auth, middlewares.RequirePermission(constants.PermissionTicketManage), e.UpdateTicket)
 }
 
 func (e EventHttpHandler) CreateEvent(c *fiber.Ctx) error {
@@ -45,6 +72,7 @@ func (e EventHttpHandler) CreateEvent(c *fiber.Ctx) error {
 
 	userId := c.Locals("userId").(string)
 	req.UserId = userId
+	req.UserRole, _ = c.Locals("userRole").(string)
 
 	if err := e.Validator.Struct(req); err != nil {
 		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
@@ -56,6 +84,177 @@ func (e EventHttpHandler) CreateEvent(c *fiber.Ctx) error {
 	return helpers.RespSuccess(c, e.Logger, resp, "Create event success")
 }
 
+func (e EventHttpHandler) UpdateEvent(c *fiber.Ctx) error {
+	req := new(request.UpdateEventReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.EventId = c.Params("eventId")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.UpdateEvent(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Update event success")
+}
+
+func (e EventHttpHandler) PublishEvent(c *fiber.Ctx) error {
+	req := new(request.PublishEventReq)
+	req.EventId = c.Params("eventId")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.PublishEvent(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Publish event success")
+}
+
+func (e EventHttpHandler) CancelEvent(c *fiber.Ctx) error {
+	req := new(request.CancelEventReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.EventId = c.Params("eventId")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.CancelEvent(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Cancel event success")
+}
+
+func (e EventHttpHandler) AddTicketType(c *fiber.Ctx) error {
+	req := new(request.AddTicketReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.EventId = c.Params("eventId")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.AddTicketType(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Add ticket type success")
+}
+
+func (e EventHttpHandler) UpdateTicket(c *fiber.Ctx) error {
+	req := new(request.UpdateTicketReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.EventId = c.Params("eventId")
+	req.TicketId = c.Params("ticketId")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.UpdateTicket(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Update ticket success")
+}
+
+func (e EventHttpHandler) HoldTicket(c *fiber.Ctx) error {
+	req := new(request.HoldTicketReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.TicketId = c.Params("ticketId")
+	req.UserId = c.Locals("userId").(string)
+	if user, ok := middlewares.GetUserContext(c); ok {
+		req.CountryCode = user.CountryCode
+	}
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.HoldTicket(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Hold ticket success")
+}
+
+// ConfirmTicketHold is called by the order service once the order of the hold is paid, the buyer comes in the body.
+func (e EventHttpHandler) ConfirmTicketHold(c *fiber.Ctx) error {
+	req := new(request.TicketHoldReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.TicketId = c.Params("ticketId")
+	req.HoldId = c.Params("holdId")
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.ConfirmTicketHold(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Confirm ticket hold success")
+}
+
+func (e EventHttpHandler) ReleaseTicketHold(c *fiber.Ctx) error {
+	req := new(request.TicketHoldReq)
+	req.TicketId = c.Params("ticketId")
+	req.HoldId = c.Params("holdId")
+	req.UserId = c.Locals("userId").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.ReleaseTicketHold(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Release ticket hold success")
+}
+
+func (e EventHttpHandler) ReprovisionTickets(c *fiber.Ctx) error {
+	req := new(request.ReprovisionTicketReq)
+	req.EventId = c.Params("eventId")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.ReprovisionTickets(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Re-provision tickets success")
+}
+
 func (e EventHttpHandler) GetEvents(c *fiber.Ctx) error {
 	req := new(request.AllEventReq)
 	if err := c.QueryParser(req); err != nil {
@@ -72,6 +271,105 @@ func (e EventHttpHandler) GetEvents(c *fiber.Ctx) error {
 	return helpers.RespPagination(c, e.Logger, resp.CollectionData, resp.MetaData, "Get country success")
 }
 
+func (e EventHttpHandler) GetEventDetail(c *fiber.Ctx) error {
+	req := new(request.EventDetailReq)
+	req.EventId = c.Params("eventId")
+	req.UserId, _ = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseQuery.FindEventDetail(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Get event detail success")
+}
+
+func (e EventHttpHandler) GetTicketAvailability(c *fiber.Ctx) error {
+	resp, err := e.EventUsecaseQuery.FindTicketAvailability(c.Context(), c.Params("ticketId"))
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Get ticket availability success")
+}
+
+func (e EventHttpHandler) GetOnlineTicketAllocation(c *fiber.Ctx) error {
+	resp, err := e.EventUsecaseQuery.FindOnlineTicketAllocation(c.Context(), c.Params("tag"))
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Get online ticket allocation success")
+}
+
+func (e EventHttpHandler) GetOnlineTicketConfigs(c *fiber.Ctx) error {
+	req := new(request.AllOnlineTicketConfigReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseQuery.FindOnlineTicketConfigs(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespPagination(c, e.Logger, resp.CollectionData, resp.MetaData, "Get online ticket configs success")
+}
+
+func (e EventHttpHandler) GetOnlineTicketConfig(c *fiber.Ctx) error {
+	req := new(request.OnlineTicketConfigReq)
+	req.Tag = c.Params("tag")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseQuery.FindOnlineTicketConfig(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Get online ticket config success")
+}
+
+func (e EventHttpHandler) GetOnlineTicketConfigHistory(c *fiber.Ctx) error {
+	req := new(request.OnlineTicketConfigReq)
+	req.Tag = c.Params("tag")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseQuery.FindOnlineTicketConfigHistory(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Get online ticket config history success")
+}
+
+func (e EventHttpHandler) DeleteOnlineTicketConfig(c *fiber.Ctx) error {
+	req := new(request.OnlineTicketConfigReq)
+	req.Tag = c.Params("tag")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EventUsecaseCommand.DeleteOnlineTicketConfig(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Delete online ticket config success")
+}
+
 func (e EventHttpHandler) CreateOnlineTicketConfig(c *fiber.Ctx) error {
 	req := new(request.OnlineTicketReq)
 	if err := c.BodyParser(req); err != nil {
@@ -80,6 +378,7 @@ func (e EventHttpHandler) CreateOnlineTicketConfig(c *fiber.Ctx) error {
 
 	userId := c.Locals("userId").(string)
 	req.UserId = userId
+	req.UserRole, _ = c.Locals("userRole").(string)
 
 	if err := e.Validator.Struct(req); err != nil {
 		fmt.Println(err)
@@ -5,23 +5,32 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	middlewares "event-service/configs/middleware"
 	"event-service/internal/modules/event/handlers"
 	"event-service/internal/modules/event/models/request"
 	"event-service/internal/modules/event/models/response"
+	userDto "event-service/internal/modules/user/models/dto"
 	"event-service/internal/pkg/constants"
 	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/log"
 	mockcert "event-service/mocks/modules/event"
 	mocklog "event-service/mocks/pkg/log"
 	mockredis "event-service/mocks/pkg/redis"
+	"io"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
 )
 
 type EventHttpHandlerTestSuite struct {
@@ -265,6 +274,48 @@ func (suite *EventHttpHandlerTestSuite) TestGetEventsErrValidate() {
 	assert.Nil(suite.T(), err)
 }
 
+func (suite *EventHttpHandlerTestSuite) TestGetEventsErrValidateFilter() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	for _, query := range []string{
+		"page=1&size=1&sortBy=price",
+		"page=1&size=1&from=01-06-2024",
+		"page=1&size=1&minPrice=500&maxPrice=100",
+		"page=1&size=1&limit=10",
+		"cursor=abc",
+		"limit=1000",
+		"page=1",
+	} {
+		ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+		ctx.Request().SetRequestURI("/v1/list?" + query)
+		ctx.Request().Header.SetMethod(fiber.MethodGet)
+
+		err := suite.handler.GetEvents(ctx)
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), fiber.StatusBadRequest, ctx.Response().StatusCode())
+		suite.app.ReleaseCtx(ctx)
+	}
+	suite.cUQ.AssertNotCalled(suite.T(), "FindEvents", mock.Anything, mock.Anything)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetEventsCursor() {
+	response := &response.EventResp{
+		CollectionData: []response.Event{{EventId: "id"}},
+		MetaData:       constants.MetaData{Count: 1, NextCursor: "next"},
+	}
+	suite.cUQ.On("FindEvents", mock.Anything, request.AllEventReq{Limit: 1, Cursor: "abc"}).Return(response, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().SetRequestURI("/v1/list?limit=1&cursor=abc")
+	ctx.Request().Header.SetMethod(fiber.MethodGet)
+
+	err := suite.handler.GetEvents(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, ctx.Response().StatusCode())
+	assert.Contains(suite.T(), string(ctx.Response().Body()), `"nextCursor":"next"`)
+}
+
 func (suite *EventHttpHandlerTestSuite) TestGetEventsErr() {
 	suite.cUQ.On("FindEvents", mock.Anything, mock.Anything).Return(nil, errors.BadRequest("error"))
 	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -280,6 +331,664 @@ func (suite *EventHttpHandlerTestSuite) TestGetEventsErr() {
 	assert.Nil(suite.T(), err)
 }
 
+func (suite *EventHttpHandlerTestSuite) TestGetEventDetail() {
+	response := &response.EventDetail{
+		EventId: "id",
+	}
+	suite.cUQ.On("FindEventDetail", mock.Anything, request.EventDetailReq{EventId: "id"}).Return(response, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/:eventId", suite.handler.GetEventDetail)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/id", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetEventDetailErr() {
+	suite.cUQ.On("FindEventDetail", mock.Anything, request.EventDetailReq{EventId: "id"}).Return(nil, errors.NotFound("event not found"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/:eventId", suite.handler.GetEventDetail)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/id", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetOnlineTicketAllocation() {
+	response := &response.OnlineTicketAllocation{
+		Tag: "tag",
+	}
+	suite.cUQ.On("FindOnlineTicketAllocation", mock.Anything, "tag").Return(response, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/online-ticket-config/:tag/allocation", suite.handler.GetOnlineTicketAllocation)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/online-ticket-config/tag/allocation", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetOnlineTicketAllocationErr() {
+	suite.cUQ.On("FindOnlineTicketAllocation", mock.Anything, "tag").Return(nil, errors.NotFound("online ticket config not found"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/online-ticket-config/:tag/allocation", suite.handler.GetOnlineTicketAllocation)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/online-ticket-config/tag/allocation", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetOnlineTicketConfigs() {
+	response := &response.OnlineTicketConfigResp{
+		CollectionData: []response.OnlineTicketConfig{{Tag: "tag"}},
+	}
+	suite.cUQ.On("FindOnlineTicketConfigs", mock.Anything, mock.MatchedBy(func(req request.AllOnlineTicketConfigReq) bool {
+		return req.Page == 1 && req.Size == 10 && req.UserId == "12345" && req.UserRole == "admin" && req.CreatedBy == "other"
+	})).Return(response, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/online-ticket-config", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		c.Locals("userRole", "admin")
+		return c.Next()
+	}, suite.handler.GetOnlineTicketConfigs)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/online-ticket-config?page=1&size=10&createdBy=other&userId=spoofed", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetOnlineTicketConfigsErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/online-ticket-config", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.GetOnlineTicketConfigs)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/online-ticket-config", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+	suite.cUQ.AssertNotCalled(suite.T(), "FindOnlineTicketConfigs", mock.Anything, mock.Anything)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetOnlineTicketConfig() {
+	suite.cUQ.On("FindOnlineTicketConfig", mock.Anything, request.OnlineTicketConfigReq{Tag: "tag", UserId: "12345"}).
+		Return(&response.OnlineTicketConfig{Tag: "tag"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/online-ticket-config/:tag", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.GetOnlineTicketConfig)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/online-ticket-config/tag", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetOnlineTicketConfigErr() {
+	suite.cUQ.On("FindOnlineTicketConfig", mock.Anything, mock.Anything).
+		Return(nil, errors.ForbiddenError("you are not allowed to manage this online ticket config"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/online-ticket-config/:tag", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.GetOnlineTicketConfig)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/online-ticket-config/tag", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusForbidden, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetOnlineTicketConfigHistory() {
+	suite.cUQ.On("FindOnlineTicketConfigHistory", mock.Anything, request.OnlineTicketConfigReq{Tag: "tag", UserId: "12345"}).
+		Return([]response.OnlineTicketConfigHistory{{Tag: "tag", Version: 1}}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/online-ticket-config/:tag/history", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.GetOnlineTicketConfigHistory)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/online-ticket-config/tag/history", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetOnlineTicketConfigHistoryErr() {
+	suite.cUQ.On("FindOnlineTicketConfigHistory", mock.Anything, mock.Anything).
+		Return(nil, errors.NotFound("online ticket config history not found"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/online-ticket-config/:tag/history", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.GetOnlineTicketConfigHistory)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/online-ticket-config/tag/history", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestDeleteOnlineTicketConfig() {
+	var res string
+	suite.cUC.On("DeleteOnlineTicketConfig", mock.Anything, request.OnlineTicketConfigReq{Tag: "tag", UserId: "12345", UserRole: "admin"}).
+		Return(&res, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Delete("/v1/online-ticket-config/:tag", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		c.Locals("userRole", "admin")
+		return c.Next()
+	}, suite.handler.DeleteOnlineTicketConfig)
+	req := httptest.NewRequest(fiber.MethodDelete, "/v1/online-ticket-config/tag", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestDeleteOnlineTicketConfigErr() {
+	suite.cUC.On("DeleteOnlineTicketConfig", mock.Anything, mock.Anything).
+		Return(nil, errors.Conflict("online ticket config already has sold tickets"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Delete("/v1/online-ticket-config/:tag", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.DeleteOnlineTicketConfig)
+	req := httptest.NewRequest(fiber.MethodDelete, "/v1/online-ticket-config/tag", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusConflict, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestUpdateEvent() {
+	var res string
+	suite.cUC.On("UpdateEvent", mock.Anything, mock.MatchedBy(func(req request.UpdateEventReq) bool {
+		return req.EventId == "id" && req.UserId == "12345" && req.UserRole == "admin"
+	})).Return(&res, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Put("/v1/:eventId", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		c.Locals("userRole", "admin")
+		return c.Next()
+	}, suite.handler.UpdateEvent)
+
+	requestBody, _ := json.Marshal(request.UpdateEventReq{Description: "desc"})
+	req := httptest.NewRequest(fiber.MethodPut, "/v1/id", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestUpdateEventErrParser() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Patch("/v1/:eventId", suite.handler.UpdateEvent)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/v1/id", bytes.NewBufferString("{"))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestUpdateEventErr() {
+	suite.cUC.On("UpdateEvent", mock.Anything, mock.Anything).Return(nil, errors.ForbiddenError("error"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Patch("/v1/:eventId", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.UpdateEvent)
+
+	requestBody, _ := json.Marshal(request.UpdateEventReq{Description: "desc"})
+	req := httptest.NewRequest(fiber.MethodPatch, "/v1/id", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusForbidden, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestPublishEvent() {
+	var res string
+	suite.cUC.On("PublishEvent", mock.Anything, mock.MatchedBy(func(req request.PublishEventReq) bool {
+		return req.EventId == "id" && req.UserId == "12345"
+	})).Return(&res, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/:eventId/publish", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.PublishEvent)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/id/publish", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestPublishEventErr() {
+	suite.cUC.On("PublishEvent", mock.Anything, mock.Anything).Return(nil, errors.InvalidStateTransition("cancelled", "published"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/:eventId/publish", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.PublishEvent)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/id/publish", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusConflict, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestAddTicketType() {
+	var res string
+	suite.cUC.On("AddTicketType", mock.Anything, mock.MatchedBy(func(req request.AddTicketReq) bool {
+		return req.EventId == "id" && req.TicketType == "Gold" && req.UserId == "12345"
+	})).Return(&res, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/:eventId/tickets", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.AddTicketType)
+
+	requestBody, _ := json.Marshal(request.AddTicketReq{TicketType: "Gold", TicketPrice: 100, TotalQuota: 10})
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/id/tickets", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestAddTicketTypeErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/:eventId/tickets", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.AddTicketType)
+
+	requestBody, _ := json.Marshal(request.AddTicketReq{TicketType: "Gold"})
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/id/tickets", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+	suite.cUC.AssertNotCalled(suite.T(), "AddTicketType", mock.Anything, mock.Anything)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestUpdateTicket() {
+	var res string
+	suite.cUC.On("UpdateTicket", mock.Anything, mock.MatchedBy(func(req request.UpdateTicketReq) bool {
+		return req.EventId == "id" && req.TicketId == "ticketId" && req.TotalQuota != nil && *req.TotalQuota == 20 &&
+			req.TicketPrice == nil
+	})).Return(&res, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Patch("/v1/:eventId/tickets/:ticketId", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.UpdateTicket)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/v1/id/tickets/ticketId", bytes.NewBufferString(`{"totalQuota":20}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestUpdateTicketErr() {
+	suite.cUC.On("UpdateTicket", mock.Anything, mock.Anything).Return(nil, errors.Conflict("ticket quota changed while updating, please retry"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Patch("/v1/:eventId/tickets/:ticketId", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.UpdateTicket)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/v1/id/tickets/ticketId", bytes.NewBufferString(`{"totalQuota":20}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusConflict, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestUpdateTicketErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Patch("/v1/:eventId/tickets/:ticketId", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.UpdateTicket)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/v1/id/tickets/ticketId", bytes.NewBufferString(`{"totalQuota":-1}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestHoldTicket() {
+	suite.cUC.On("HoldTicket", mock.Anything, request.HoldTicketReq{TicketId: "ticketId", Quantity: 2, UserId: "12345"}).
+		Return(&response.TicketHold{HoldId: "holdId"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/tickets/:ticketId/hold", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.HoldTicket)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold", bytes.NewBufferString(`{"quantity":2}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestHoldTicketBuyerCountry() {
+	suite.cUC.On("HoldTicket", mock.Anything, request.HoldTicketReq{TicketId: "ticketId", Quantity: 2, UserId: "12345", CountryCode: "ID"}).
+		Return(&response.TicketHold{HoldId: "holdId"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/tickets/:ticketId/hold", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		c.Locals(middlewares.UserContextKey, middlewares.UserContext{UserResp: userDto.UserResp{UserId: "12345", CountryCode: "ID"}})
+		return c.Next()
+	}, suite.handler.HoldTicket)
+
+	// the country comes from the profile of the buyer, not from the body
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold", bytes.NewBufferString(`{"quantity":2,"countryCode":"SG"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestHoldTicketErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/tickets/:ticketId/hold", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.HoldTicket)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold", bytes.NewBufferString(`{"quantity":0}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestHoldTicketErrQuantityAboveMax() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/tickets/:ticketId/hold", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.HoldTicket)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold", bytes.NewBufferString(`{"quantity":11}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+	suite.cUC.AssertNotCalled(suite.T(), "HoldTicket", mock.Anything, mock.Anything)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestHoldTicketErrSoldOut() {
+	suite.cUC.On("HoldTicket", mock.Anything, mock.Anything).Return(nil, errors.Conflict("not enough tickets left"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/tickets/:ticketId/hold", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.HoldTicket)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold", bytes.NewBufferString(`{"quantity":2}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusConflict, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestConfirmTicketHold() {
+	var res string
+	suite.cUC.On("ConfirmTicketHold", mock.Anything, request.TicketHoldReq{TicketId: "ticketId", HoldId: "holdId", UserId: "12345"}).
+		Return(&res, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/tickets/:ticketId/hold/:holdId/confirm", suite.handler.ConfirmTicketHold)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold/holdId/confirm", bytes.NewBufferString(`{"userId":"12345"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestConfirmTicketHoldErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/tickets/:ticketId/hold/:holdId/confirm", suite.handler.ConfirmTicketHold)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold/holdId/confirm", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+	suite.cUC.AssertNotCalled(suite.T(), "ConfirmTicketHold", mock.Anything, mock.Anything)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestReleaseTicketHold() {
+	var res string
+	suite.cUC.On("ReleaseTicketHold", mock.Anything, request.TicketHoldReq{TicketId: "ticketId", HoldId: "holdId", UserId: "12345"}).
+		Return(&res, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/tickets/:ticketId/hold/:holdId/release", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.ReleaseTicketHold)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold/holdId/release", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestReleaseTicketHoldErr() {
+	suite.cUC.On("ReleaseTicketHold", mock.Anything, mock.Anything).Return(nil, errors.NotFound("hold not found or expired"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/tickets/:ticketId/hold/:holdId/release", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.ReleaseTicketHold)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold/holdId/release", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestReprovisionTickets() {
+	var res string
+	suite.cUC.On("ReprovisionTickets", mock.Anything, mock.MatchedBy(func(req request.ReprovisionTicketReq) bool {
+		return req.EventId == "id" && req.UserId == "12345"
+	})).Return(&res, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/:eventId/reprovision", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.ReprovisionTickets)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/id/reprovision", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestReprovisionTicketsErr() {
+	suite.cUC.On("ReprovisionTickets", mock.Anything, mock.Anything).Return(nil, errors.BadRequest("no failed tickets to re-provision"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/:eventId/reprovision", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.ReprovisionTickets)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/id/reprovision", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestCancelEvent() {
+	var res string
+	suite.cUC.On("CancelEvent", mock.Anything, mock.MatchedBy(func(req request.CancelEventReq) bool {
+		return req.EventId == "id" && req.Reason == "reason"
+	})).Return(&res, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/:eventId/cancel", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.CancelEvent)
+
+	requestBody, _ := json.Marshal(request.CancelEventReq{Reason: "reason"})
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/id/cancel", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestCancelEventErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/:eventId/cancel", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.CancelEvent)
+
+	requestBody, _ := json.Marshal(request.CancelEventReq{})
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/id/cancel", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestCancelEventErr() {
+	suite.cUC.On("CancelEvent", mock.Anything, mock.Anything).Return(nil, errors.Conflict("error"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/:eventId/cancel", func(c *fiber.Ctx) error {
+		c.Locals("userId", "12345")
+		return c.Next()
+	}, suite.handler.CancelEvent)
+
+	requestBody, _ := json.Marshal(request.CancelEventReq{Reason: "reason"})
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/id/cancel", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusConflict, resp.StatusCode)
+}
+
 func (suite *EventHttpHandlerTestSuite) TestCreateOnlineTicketConfig() {
 	var res string
 	suite.cUC.On("CreateOnlineTicketConfig", mock.Anything, mock.Anything).Return(&res, nil)
@@ -392,3 +1101,139 @@ func (suite *EventHttpHandlerTestSuite) TestCreateOnlineTicketConfigErr() {
 	err := suite.handler.CreateOnlineTicketConfig(ctx)
 	assert.Nil(suite.T(), err)
 }
+
+func (suite *EventHttpHandlerTestSuite) TestRoutePermissions() {
+	// the role middleware logs through the global logger
+	log.Init((&log.LoggerConf{}).Clone(zap.NewNop()))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	admin, organizer, fan, support := constants.RoleAdmin, constants.RoleOrganizer, constants.RoleFan, constants.RoleSupport
+	routes := []struct {
+		method string
+		route  string
+		path   string
+		roles  []string
+	}{
+		{fiber.MethodPost, "/v1/create-event", "/v1/create-event", []string{admin, organizer}},
+		{fiber.MethodPost, "/v1/create-online-ticket-config", "/v1/create-online-ticket-config", []string{admin, organizer}},
+		{fiber.MethodGet, "/v1/online-ticket-config", "/v1/online-ticket-config", []string{admin, organizer, support}},
+		{fiber.MethodGet, "/v1/online-ticket-config/:tag", "/v1/online-ticket-config/tag", []string{admin, organizer, support}},
+		{fiber.MethodDelete, "/v1/online-ticket-config/:tag", "/v1/online-ticket-config/tag", []string{admin, organizer}},
+		{fiber.MethodGet, "/v1/online-ticket-config/:tag/history", "/v1/online-ticket-config/tag/history", []string{admin, organizer, support}},
+		{fiber.MethodGet, "/v1/online-ticket-config/:tag/allocation", "/v1/online-ticket-config/tag/allocation", []string{admin, organizer, support}},
+		{fiber.MethodGet, "/v1/list", "/v1/list", []string{admin, organizer, fan, support}},
+		{fiber.MethodGet, "/v1/:eventId", "/v1/id", []string{admin, organizer, fan, support}},
+		{fiber.MethodPut, "/v1/:eventId", "/v1/id", []string{admin, organizer}},
+		{fiber.MethodPatch, "/v1/:eventId", "/v1/id", []string{admin, organizer}},
+		{fiber.MethodPost, "/v1/:eventId/publish", "/v1/id/publish", []string{admin, organizer}},
+		{fiber.MethodPost, "/v1/:eventId/cancel", "/v1/id/cancel", []string{admin, organizer}},
+		{fiber.MethodPost, "/v1/:eventId/reprovision", "/v1/id/reprovision", []string{admin, organizer}},
+		{fiber.MethodPost, "/v1/:eventId/tickets", "/v1/id/tickets", []string{admin, organizer}},
+		{fiber.MethodPost, "/v1/tickets/:ticketId/hold", "/v1/tickets/ticketId/hold", []string{admin, fan}},
+		{fiber.MethodPost, "/v1/tickets/:ticketId/hold/:holdId/release", "/v1/tickets/ticketId/hold/holdId/release", []string{admin, fan}},
+		{fiber.MethodPatch, "/v1/:eventId/tickets/:ticketId", "/v1/id/tickets/ticketId", []string{admin, organizer}},
+	}
+
+	for _, role := range []string{admin, organizer, fan, support} {
+		role := role
+		app := fiber.New()
+		// an allowed request reaches the handler, the usecase mocks are not set so it fails with a 500
+		app.Use(recover.New())
+		suite.handler.RegisterRoutes(app, func(c *fiber.Ctx) error {
+			c.Locals("userId", "userId")
+			c.Locals("userRole", role)
+			return c.Next()
+		})
+
+		registered := make([]string, 0)
+		for _, route := range app.GetRoutes(true) {
+			if route.Method != fiber.MethodHead {
+				registered = append(registered, route.Method+" "+route.Path)
+			}
+		}
+		covered := make([]string, 0)
+		for _, route := range routes {
+			covered = append(covered, route.method+" "+route.route)
+		}
+		assert.ElementsMatch(suite.T(), registered, covered, "every route must be covered")
+
+		for _, route := range routes {
+			resp, err := app.Test(httptest.NewRequest(route.method, route.path, nil))
+			assert.Nil(suite.T(), err)
+
+			allowed := false
+			for _, allowedRole := range route.roles {
+				allowed = allowed || allowedRole == role
+			}
+			body, _ := io.ReadAll(resp.Body)
+			denied := resp.StatusCode == fiber.StatusForbidden && strings.Contains(string(body), "Unauthorized role!")
+			assert.Equal(suite.T(), !allowed, denied, "%s %s as %s", route.method, route.route, role)
+		}
+	}
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetTicketAvailability() {
+	response := &response.TicketAvailability{
+		TicketId:  "ticketId",
+		Available: 5,
+	}
+	suite.cUQ.On("FindTicketAvailability", mock.Anything, "ticketId").Return(response, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/tickets/:ticketId/availability", suite.handler.GetTicketAvailability)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/tickets/ticketId/availability", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestGetTicketAvailabilityErr() {
+	suite.cUQ.On("FindTicketAvailability", mock.Anything, "ticketId").Return(nil, errors.NotFound("ticket not found"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/tickets/:ticketId/availability", suite.handler.GetTicketAvailability)
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/tickets/ticketId/availability", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *EventHttpHandlerTestSuite) TestInternalRoutes() {
+	log.Init((&log.LoggerConf{}).Clone(zap.NewNop()))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	suite.cUQ.On("FindTicketAvailability", mock.Anything, "ticketId").Return(&response.TicketAvailability{}, nil)
+	suite.cUQ.On("FindOnlineTicketAllocation", mock.Anything, "tag").Return(&response.OnlineTicketAllocation{}, nil)
+
+	app := fiber.New()
+	suite.handler.RegisterInternalRoutes(app, middlewares.InternalAuth{
+		HmacKeys:     map[string]string{"order-service": "secret"},
+		MaxClockSkew: time.Minute,
+	}.Verify())
+
+	for _, path := range []string{"/v1/tickets/ticketId/availability", "/v1/online-ticket-config/tag/quota"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), fiber.StatusUnauthorized, resp.StatusCode, path)
+
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req := httptest.NewRequest(fiber.MethodGet, path, nil)
+		req.Header.Set(middlewares.HeaderInternalKeyId, "order-service")
+		req.Header.Set(middlewares.HeaderInternalTimestamp, timestamp)
+		req.Header.Set(middlewares.HeaderInternalSignature,
+			middlewares.SignInternalRequest("secret", fiber.MethodGet, path, timestamp, nil))
+		resp, err = app.Test(req)
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode, path)
+	}
+
+	// a fan cannot confirm a hold without paying, only the order service can
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/v1/tickets/ticketId/hold/holdId/confirm", nil))
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, resp.StatusCode)
+	suite.cUC.AssertNotCalled(suite.T(), "ConfirmTicketHold", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"event-service/internal/modules/event"
+	"event-service/internal/modules/event/models/request"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/log"
+
+	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type EventKafkaHandler struct {
+	EventUsecaseCommand event.UsecaseCommand
+	Logger              log.Logger
+	Validator           *validator.Validate
+}
+
+func InitEventKafkaHandler(router *kafkaConfluent.Router, euc event.UsecaseCommand, log log.Logger) {
+	handler := &EventKafkaHandler{
+		EventUsecaseCommand: euc,
+		Logger:              log,
+		Validator:           validator.New(),
+	}
+
+	router.Handle(constants.TopicBankTicketCreated, handler.BankTicketCreated)
+	router.Handle(constants.TopicOrderAck, handler.OrderAck)
+}
+
+func (e EventKafkaHandler) BankTicketCreated(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+	req := new(request.BankTicketCreatedReq)
+	if err := json.Unmarshal(message.Value, req); err != nil {
+		return kafkaConfluent.Permanent(errors.BadRequest("bad request"))
+	}
+
+	if err := e.Validator.Struct(req); err != nil {
+		return kafkaConfluent.Permanent(errors.BadRequest(err.Error()))
+	}
+	return e.EventUsecaseCommand.AcknowledgeBankTicket(ctx, *req)
+}
+
+func (e EventKafkaHandler) OrderAck(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+	req := new(request.OrderAckReq)
+	if err := json.Unmarshal(message.Value, req); err != nil {
+		return kafkaConfluent.Permanent(errors.BadRequest("bad request"))
+	}
+
+	if err := e.Validator.Struct(req); err != nil {
+		return kafkaConfluent.Permanent(errors.BadRequest(err.Error()))
+	}
+	return e.EventUsecaseCommand.AcknowledgeOrder(ctx, *req)
+}
@@ -0,0 +1,117 @@
+package handlers_test
+
+import (
+	"context"
+	"event-service/internal/modules/event/handlers"
+	"event-service/internal/modules/event/models/request"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	mockcert "event-service/mocks/modules/event"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+
+	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
+	mockkafka "event-service/mocks/pkg/kafka"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventKafkaHandlerTestSuite struct {
+	suite.Suite
+
+	cUC     *mockcert.UsecaseCommand
+	cLog    *mocklog.Logger
+	handler *handlers.EventKafkaHandler
+	ctx     context.Context
+}
+
+func (suite *EventKafkaHandlerTestSuite) SetupTest() {
+	suite.cUC = new(mockcert.UsecaseCommand)
+	suite.cLog = new(mocklog.Logger)
+	suite.handler = &handlers.EventKafkaHandler{
+		EventUsecaseCommand: suite.cUC,
+		Logger:              suite.cLog,
+		Validator:           validator.New(),
+	}
+	suite.ctx = context.Background()
+}
+
+func TestEventKafkaHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(EventKafkaHandlerTestSuite))
+}
+
+func (suite *EventKafkaHandlerTestSuite) TestInitEventKafkaHandler() {
+	router := kafkaConfluent.NewRouter(kafkaConfluent.RouterConfig{}, new(mockkafka.Producer), suite.cLog)
+
+	handlers.InitEventKafkaHandler(router, suite.cUC, suite.cLog)
+
+	assert.ElementsMatch(suite.T(), []string{constants.TopicBankTicketCreated, constants.TopicOrderAck}, router.Topics())
+}
+
+func (suite *EventKafkaHandlerTestSuite) TestBankTicketCreated() {
+	suite.cUC.On("AcknowledgeBankTicket", mock.Anything, request.BankTicketCreatedReq{TicketId: "ticketId", EventId: "eventId"}).Return(nil)
+
+	err := suite.handler.BankTicketCreated(suite.ctx, kafkaConfluent.ConsumedMessage{
+		Topic: constants.TopicBankTicketCreated,
+		Value: []byte(`{"ticketId":"ticketId","eventId":"eventId"}`),
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *EventKafkaHandlerTestSuite) TestBankTicketCreatedErrParse() {
+	err := suite.handler.BankTicketCreated(suite.ctx, kafkaConfluent.ConsumedMessage{Value: []byte(`not json`)})
+	assert.True(suite.T(), kafkaConfluent.IsPermanent(err))
+	suite.cUC.AssertNotCalled(suite.T(), "AcknowledgeBankTicket", mock.Anything, mock.Anything)
+}
+
+func (suite *EventKafkaHandlerTestSuite) TestBankTicketCreatedErrValidation() {
+	err := suite.handler.BankTicketCreated(suite.ctx, kafkaConfluent.ConsumedMessage{Value: []byte(`{"ticketId":"ticketId"}`)})
+	assert.True(suite.T(), kafkaConfluent.IsPermanent(err))
+}
+
+func (suite *EventKafkaHandlerTestSuite) TestOrderAck() {
+	suite.cUC.On("AcknowledgeOrder", mock.Anything, mock.MatchedBy(func(req request.OrderAckReq) bool {
+		return req.OrderId == "orderId" && req.HoldId == "holdId" && req.UserId == "userId" && req.Status == "paid"
+	})).Return(nil)
+
+	err := suite.handler.OrderAck(suite.ctx, kafkaConfluent.ConsumedMessage{
+		Topic: constants.TopicOrderAck,
+		Value: []byte(`{"orderId":"orderId","eventId":"eventId","ticketId":"ticketId","holdId":"holdId","userId":"userId","status":"paid"}`),
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *EventKafkaHandlerTestSuite) TestOrderAckErrUsecase() {
+	suite.cUC.On("AcknowledgeOrder", mock.Anything, mock.Anything).Return(errors.NotFound("event not found"))
+
+	err := suite.handler.OrderAck(suite.ctx, kafkaConfluent.ConsumedMessage{
+		Value: []byte(`{"orderId":"orderId","eventId":"eventId","ticketId":"ticketId","holdId":"holdId","userId":"userId","status":"paid"}`),
+	})
+	assert.Error(suite.T(), err)
+	// a failing usecase is retried by the router
+	assert.False(suite.T(), kafkaConfluent.IsPermanent(err))
+}
+
+func (suite *EventKafkaHandlerTestSuite) TestOrderAckErrValidation() {
+	err := suite.handler.OrderAck(suite.ctx, kafkaConfluent.ConsumedMessage{
+		Value: []byte(`{"orderId":"orderId","eventId":"eventId","ticketId":"ticketId","holdId":"holdId","userId":"userId","status":"refunded"}`),
+	})
+	assert.True(suite.T(), kafkaConfluent.IsPermanent(err))
+	suite.cUC.AssertNotCalled(suite.T(), "AcknowledgeOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *EventKafkaHandlerTestSuite) TestOrderAckErrParse() {
+	err := suite.handler.OrderAck(suite.ctx, kafkaConfluent.ConsumedMessage{Value: []byte(`not json`)})
+	assert.True(suite.T(), kafkaConfluent.IsPermanent(err))
+}
+
+func (suite *EventKafkaHandlerTestSuite) TestBankTicketCreatedErrStatus() {
+	err := suite.handler.BankTicketCreated(suite.ctx, kafkaConfluent.ConsumedMessage{
+		Value: []byte(`{"ticketId":"ticketId","eventId":"eventId","status":"unknown"}`),
+	})
+	assert.Error(suite.T(), err)
+	suite.cUC.AssertNotCalled(suite.T(), "AcknowledgeBankTicket", mock.Anything, mock.Anything)
+}
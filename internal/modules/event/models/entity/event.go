@@ -18,10 +18,48 @@ type Event struct {
 	Country       Country   `json:"country" bson:"country"`
 	Description   string    `json:"description" bson:"description"`
 	Tag           string    `json:"tag" bson:"tag"`
+	OrganizerId   string    `json:"organizerId" bson:"organizerId,omitempty"`
 	EventUrl      string    `json:"eventUrl" bson:"eventUrl"`
 	TicketIds     []string  `json:"ticketIds" bson:"ticketIds"`
+	Status        string    `json:"status" bson:"status"`
+	PublishAt     time.Time `json:"publishAt,omitempty" bson:"publishAt,omitempty"`
+	SalesStartAt  time.Time `json:"salesStartAt,omitempty" bson:"salesStartAt,omitempty"`
+	SalesEndAt    time.Time `json:"salesEndAt,omitempty" bson:"salesEndAt,omitempty"`
+	CancelReason  string    `json:"cancelReason,omitempty" bson:"cancelReason,omitempty"`
+	CancelledAt   time.Time `json:"cancelledAt,omitempty" bson:"cancelledAt,omitempty"`
+	CancelledBy   string    `json:"cancelledBy,omitempty" bson:"cancelledBy,omitempty"`
 	CreatedAt     time.Time `json:"createdAt" bson:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt" bson:"updatedAt"`
 	CreatedBy     string    `json:"createdBy" bson:"createdBy"`
 	UpdatedBy     string    `json:"updatedBy" bson:"updatedBy"`
+	// Score is the text search relevance, it is only projected by the list search and never stored.
+	Score float64 `json:"score,omitempty" bson:"score,omitempty"`
+}
+
+// EventVersion is the status and updatedAt an event was read with, a conditional write only applies while the stored
+// event still has both.
+type EventVersion struct {
+	Status    string
+	UpdatedAt time.Time
+}
+
+// EventFilter narrows the public event list. Empty fields do not filter, To is exclusive
+// and a nil EventIds does not restrict the list to specific events. Keyset pages by Cursor
+// instead of Page, the total is then only counted on WithCount.
+type EventFilter struct {
+	Search           string
+	ContinentCode    string
+	CountryCode      string
+	City             string
+	Tag              string
+	From             time.Time
+	To               time.Time
+	EventIds         []string
+	IncludeCancelled bool
+	SortBy           string
+	Page             int64
+	Size             int64
+	Keyset           bool
+	Cursor           string
+	WithCount        bool
 }
@@ -1,5 +1,7 @@
 package request
 
+import "time"
+
 type TicketReq struct {
 	// ContinentCode string `json:"continentCode"`
 	CountryCode string `json:"countryCode" validate:"required"`
@@ -22,35 +24,194 @@ type EventReq struct {
 	Tag           string   `json:"tag" validate:"required"`
 	EventUrl      string   `json:"eventUrl" validate:"required"`
 	Tickets       []Ticket `json:"tickets" validate:"required"`
+	PublishAt     string   `json:"publishAt"`
+	SalesStartAt  string   `json:"salesStartAt"`
+	SalesEndAt    string   `json:"salesEndAt"`
+	OrganizerId   string   `json:"organizerId" validate:"required"`
 	UserId        string   `json:"userId" validate:"required"`
+	UserRole      string   `json:"userRole"`
+}
+
+type UpdateEventReq struct {
+	EventId       string   `json:"eventId" validate:"required"`
+	Name          string   `json:"name"`
+	DateTime      string   `json:"dateTime"`
+	ContinentName string   `json:"continentName"`
+	ContinentCode string   `json:"continentCode"`
+	Country       *Country `json:"country"`
+	Description   string   `json:"description"`
+	Tag           string   `json:"tag"`
+	EventUrl      string   `json:"eventUrl"`
+	PublishAt     string   `json:"publishAt"`
+	SalesStartAt  string   `json:"salesStartAt"`
+	SalesEndAt    string   `json:"salesEndAt"`
+	UserId        string   `json:"userId" validate:"required"`
+	UserRole      string   `json:"userRole"`
 }
 
 type OnlineTicketReq struct {
+	OrganizerId string        `json:"organizerId" validate:"required"`
 	UserId      string        `json:"userId" validate:"required"`
+	UserRole    string        `json:"userRole"`
 	Tag         string        `json:"tag" validate:"required"`
-	TotalQuota  int           `json:"totalQuota" validate:"required"`
-	CountryList []CountryList `json:"countryList" validate:"required"`
+	TotalQuota  int           `json:"totalQuota" validate:"required,min=1"`
+	CountryList []CountryList `json:"countryList" validate:"required,min=1,dive"`
+}
+
+type OnlineTicketConfigReq struct {
+	Tag      string `json:"tag" validate:"required"`
+	UserId   string `json:"userId" validate:"required"`
+	UserRole string `json:"userRole"`
+}
+
+type AllOnlineTicketConfigReq struct {
+	Page        int64  `query:"page" validate:"required"`
+	Size        int64  `query:"size" validate:"required"`
+	CreatedBy   string `query:"createdBy"`
+	OrganizerId string `query:"organizerId"`
+	UserId      string `query:"-" validate:"required"`
+	UserRole    string `query:"-"`
 }
 
 type CountryList struct {
 	CountryNumber int `json:"countryNumber" validate:"required"`
-	Percentage    int `json:"percentage" validate:"required"`
+	Percentage    int `json:"percentage" validate:"required,min=1,max=100"`
 }
 
 type Ticket struct {
-	TicketType  string `json:"ticketType" validate:"required"`
-	TicketPrice int    `json:"ticketPrice" validate:"required"`
-	TotalQuota  int    `json:"totalQuota" validate:"required"`
-	Tag         string `json:"tag" validate:"required"`
+	TicketType   string `json:"ticketType" validate:"required"`
+	TicketPrice  int    `json:"ticketPrice" validate:"required"`
+	TotalQuota   int    `json:"totalQuota" validate:"required"`
+	Tag          string `json:"tag" validate:"required"`
+	SalesStartAt string `json:"salesStartAt"`
+	SalesEndAt   string `json:"salesEndAt"`
 }
 
 type AllEventReq struct {
-	Page   int64  `query:"page" validate:"required"`
-	Size   int64  `query:"size" validate:"required"`
-	Search string `query:"search"`
+	Page             int64  `query:"page" validate:"required_without=Limit,excluded_with=Limit"`
+	Size             int64  `query:"size" validate:"required_with=Page"`
+	Limit            int64  `query:"limit" validate:"required_with=Cursor,omitempty,min=1,max=100"`
+	Cursor           string `query:"cursor"`
+	WithCount        bool   `query:"withCount"`
+	Search           string `query:"search"`
+	Highlight        bool   `query:"highlight"`
+	IncludeCancelled bool   `query:"includeCancelled"`
+	ContinentCode    string `query:"continentCode"`
+	CountryCode      string `query:"countryCode"`
+	City             string `query:"city"`
+	Tag              string `query:"tag"`
+	From             string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To               string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	TicketType       string `query:"ticketType"`
+	MinPrice         int    `query:"minPrice" validate:"omitempty,min=0"`
+	MaxPrice         int    `query:"maxPrice" validate:"omitempty,min=0,gtefield=MinPrice"`
+	Available        bool   `query:"available"`
+	SortBy           string `query:"sortBy" validate:"omitempty,oneof=name date newest"`
+}
+
+type EventDetailReq struct {
+	EventId  string `json:"eventId" validate:"required"`
+	UserId   string `json:"userId"`
+	UserRole string `json:"userRole"`
+}
+
+type PublishEventReq struct {
+	EventId  string `json:"eventId" validate:"required"`
+	UserId   string `json:"userId" validate:"required"`
+	UserRole string `json:"userRole"`
+}
+
+type CancelEventReq struct {
+	EventId  string `json:"eventId" validate:"required"`
+	Reason   string `json:"reason" validate:"required"`
+	UserId   string `json:"userId" validate:"required"`
+	UserRole string `json:"userRole"`
 }
 
 type CreateTicketReq struct {
 	TicketId string `json:"ticketId" bson:"ticketId"`
 	EventId  string `json:"eventId" bson:"eventId"`
 }
+
+type UpdateBankTicketReq struct {
+	TicketId       string    `json:"ticketId" bson:"ticketId"`
+	EventId        string    `json:"eventId" bson:"eventId"`
+	TicketType     string    `json:"ticketType" bson:"ticketType"`
+	TicketPrice    int       `json:"ticketPrice" bson:"ticketPrice"`
+	TotalQuota     int       `json:"totalQuota" bson:"totalQuota"`
+	TotalRemaining int       `json:"totalRemaining" bson:"totalRemaining"`
+	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+type EventCancelledReq struct {
+	EventId     string    `json:"eventId" bson:"eventId"`
+	Tag         string    `json:"tag" bson:"tag"`
+	TicketIds   []string  `json:"ticketIds" bson:"ticketIds"`
+	Reason      string    `json:"reason" bson:"reason"`
+	CancelledBy string    `json:"cancelledBy" bson:"cancelledBy"`
+	CancelledAt time.Time `json:"cancelledAt" bson:"cancelledAt"`
+}
+
+type EventStateChangedReq struct {
+	EventId    string    `json:"eventId" bson:"eventId"`
+	Tag        string    `json:"tag" bson:"tag"`
+	FromStatus string    `json:"fromStatus" bson:"fromStatus"`
+	ToStatus   string    `json:"toStatus" bson:"toStatus"`
+	ChangedAt  time.Time `json:"changedAt" bson:"changedAt"`
+}
+
+type AddTicketReq struct {
+	EventId      string `json:"eventId" validate:"required"`
+	TicketType   string `json:"ticketType" validate:"required"`
+	TicketPrice  int    `json:"ticketPrice" validate:"required"`
+	TotalQuota   int    `json:"totalQuota" validate:"required"`
+	SalesStartAt string `json:"salesStartAt"`
+	SalesEndAt   string `json:"salesEndAt"`
+	UserId       string `json:"userId" validate:"required"`
+	UserRole     string `json:"userRole"`
+}
+
+type UpdateTicketReq struct {
+	EventId     string `json:"eventId" validate:"required"`
+	TicketId    string `json:"ticketId" validate:"required"`
+	TicketPrice *int   `json:"ticketPrice" validate:"omitempty,min=1"`
+	TotalQuota  *int   `json:"totalQuota" validate:"omitempty,min=0"`
+	UserId      string `json:"userId" validate:"required"`
+	UserRole    string `json:"userRole"`
+}
+
+type HoldTicketReq struct {
+	TicketId string `json:"ticketId" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,min=1,max=10"`
+	UserId   string `json:"userId" validate:"required"`
+	// CountryCode is the country of the buyer profile, it is not read from the body
+	CountryCode string `json:"-"`
+}
+
+type TicketHoldReq struct {
+	TicketId string `json:"ticketId" validate:"required"`
+	HoldId   string `json:"holdId" validate:"required"`
+	UserId   string `json:"userId" validate:"required"`
+}
+
+type ReprovisionTicketReq struct {
+	EventId  string `json:"eventId" validate:"required"`
+	UserId   string `json:"userId" validate:"required"`
+	UserRole string `json:"userRole"`
+}
+
+type BankTicketCreatedReq struct {
+	TicketId string `json:"ticketId" validate:"required"`
+	EventId  string `json:"eventId" validate:"required"`
+	Status   string `json:"status" validate:"omitempty,oneof=provisioned failed"`
+	Reason   string `json:"reason"`
+}
+
+type OrderAckReq struct {
+	OrderId  string `json:"orderId" validate:"required"`
+	EventId  string `json:"eventId" validate:"required"`
+	TicketId string `json:"ticketId" validate:"required"`
+	HoldId   string `json:"holdId" validate:"required"`
+	UserId   string `json:"userId" validate:"required"`
+	Status   string `json:"status" validate:"required,oneof=paid cancelled expired"`
+}
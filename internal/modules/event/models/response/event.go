@@ -13,18 +13,130 @@ type Country struct {
 }
 
 type Event struct {
-	EventId       string    `json:"eventId" bson:"eventId"`
-	Name          string    `json:"name" bson:"name"`
-	DateTime      time.Time `json:"dateTime" bson:"dateTime"`
-	ContinentName string    `json:"continentName" bson:"continentName"`
-	ContinentCode string    `json:"continentCode" bson:"continentCode"`
-	Country       Country   `json:"country" bson:"country"`
-	Description   string    `json:"description" bson:"description"`
-	Tag           string    `json:"tag" bson:"tag"`
-	TicketIds     []string  `json:"ticketIds" bson:"ticketIds"`
+	EventId       string            `json:"eventId" bson:"eventId"`
+	Name          string            `json:"name" bson:"name"`
+	DateTime      time.Time         `json:"dateTime" bson:"dateTime"`
+	ContinentName string            `json:"continentName" bson:"continentName"`
+	ContinentCode string            `json:"continentCode" bson:"continentCode"`
+	Country       Country           `json:"country" bson:"country"`
+	Description   string            `json:"description" bson:"description"`
+	Tag           string            `json:"tag" bson:"tag"`
+	OrganizerId   string            `json:"organizerId,omitempty" bson:"organizerId,omitempty"`
+	TicketIds     []string          `json:"ticketIds" bson:"ticketIds"`
+	Status        string            `json:"status" bson:"status"`
+	SalesStartAt  time.Time         `json:"salesStartAt" bson:"salesStartAt"`
+	SalesEndAt    time.Time         `json:"salesEndAt" bson:"salesEndAt"`
+	IsSalesOpen   bool              `json:"isSalesOpen" bson:"isSalesOpen"`
+	Score         float64           `json:"score,omitempty" bson:"score,omitempty"`
+	Highlights    map[string]string `json:"highlights,omitempty" bson:"highlights,omitempty"`
 }
 
 type EventResp struct {
 	CollectionData []Event
 	MetaData       constants.MetaData
 }
+
+type Ticket struct {
+	TicketId           string    `json:"ticketId" bson:"ticketId"`
+	TicketType         string    `json:"ticketType" bson:"ticketType"`
+	TicketPrice        int       `json:"ticketPrice" bson:"ticketPrice"`
+	TotalQuota         int       `json:"totalQuota" bson:"totalQuota"`
+	TotalRemaining     int       `json:"totalRemaining" bson:"totalRemaining"`
+	IsSellable         bool      `json:"isSellable" bson:"isSellable"`
+	SalesStartAt       time.Time `json:"salesStartAt" bson:"salesStartAt"`
+	SalesEndAt         time.Time `json:"salesEndAt" bson:"salesEndAt"`
+	IsSalesOpen        bool      `json:"isSalesOpen" bson:"isSalesOpen"`
+	ProvisioningStatus string    `json:"provisioningStatus" bson:"provisioningStatus"`
+	ProvisioningError  string    `json:"provisioningError,omitempty" bson:"provisioningError,omitempty"`
+}
+
+type TicketHold struct {
+	HoldId    string    `json:"holdId" bson:"holdId"`
+	TicketId  string    `json:"ticketId" bson:"ticketId"`
+	EventId   string    `json:"eventId" bson:"eventId"`
+	Quantity  int       `json:"quantity" bson:"quantity"`
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+// TicketAvailability is what the other services may sell of a ticket type right now. Available excludes the tickets
+// held by pending checkouts.
+type TicketAvailability struct {
+	TicketId    string `json:"ticketId" bson:"ticketId"`
+	EventId     string `json:"eventId" bson:"eventId"`
+	TicketType  string `json:"ticketType" bson:"ticketType"`
+	TotalQuota  int    `json:"totalQuota" bson:"totalQuota"`
+	Available   int    `json:"available" bson:"available"`
+	Held        int    `json:"held" bson:"held"`
+	IsSalesOpen bool   `json:"isSalesOpen" bson:"isSalesOpen"`
+}
+
+type CountryList struct {
+	CountryNumber int    `json:"countryNumber" bson:"countryNumber"`
+	Percentage    int    `json:"percentage" bson:"percentage"`
+	CountryCode   string `json:"countryCode" bson:"countryCode"`
+	CountryName   string `json:"countryName" bson:"countryName"`
+	Quota         int    `json:"quota" bson:"quota"`
+	Sold          int    `json:"sold" bson:"sold"`
+}
+
+type CountryAllocation struct {
+	CountryCode string `json:"countryCode" bson:"countryCode"`
+	CountryName string `json:"countryName" bson:"countryName"`
+	Allocated   int    `json:"allocated" bson:"allocated"`
+	Sold        int    `json:"sold" bson:"sold"`
+	Remaining   int    `json:"remaining" bson:"remaining"`
+}
+
+type OnlineTicketAllocation struct {
+	Tag         string              `json:"tag" bson:"tag"`
+	TotalQuota  int                 `json:"totalQuota" bson:"totalQuota"`
+	CountryList []CountryAllocation `json:"countryList" bson:"countryList"`
+}
+
+type OnlineTicketConfig struct {
+	Tag         string        `json:"tag" bson:"tag"`
+	OrganizerId string        `json:"organizerId,omitempty" bson:"organizerId,omitempty"`
+	Version     int           `json:"version" bson:"version"`
+	TotalQuota  int           `json:"totalQuota" bson:"totalQuota"`
+	CountryList []CountryList `json:"countryList" bson:"countryList"`
+	CreatedAt   time.Time     `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt" bson:"updatedAt"`
+	CreatedBy   string        `json:"createdBy" bson:"createdBy"`
+	UpdatedBy   string        `json:"updatedBy" bson:"updatedBy"`
+}
+
+type OnlineTicketConfigResp struct {
+	CollectionData []OnlineTicketConfig
+	MetaData       constants.MetaData
+}
+
+type OnlineTicketConfigHistory struct {
+	Tag         string        `json:"tag" bson:"tag"`
+	Version     int           `json:"version" bson:"version"`
+	Action      string        `json:"action" bson:"action"`
+	TotalQuota  int           `json:"totalQuota" bson:"totalQuota"`
+	CountryList []CountryList `json:"countryList" bson:"countryList"`
+	ChangedBy   string        `json:"changedBy" bson:"changedBy"`
+	ChangedAt   time.Time     `json:"changedAt" bson:"changedAt"`
+}
+
+type EventDetail struct {
+	EventId            string              `json:"eventId" bson:"eventId"`
+	Name               string              `json:"name" bson:"name"`
+	DateTime           time.Time           `json:"dateTime" bson:"dateTime"`
+	ContinentName      string              `json:"continentName" bson:"continentName"`
+	ContinentCode      string              `json:"continentCode" bson:"continentCode"`
+	Country            Country             `json:"country" bson:"country"`
+	Description        string              `json:"description" bson:"description"`
+	Tag                string              `json:"tag" bson:"tag"`
+	OrganizerId        string              `json:"organizerId,omitempty" bson:"organizerId,omitempty"`
+	EventUrl           string              `json:"eventUrl" bson:"eventUrl"`
+	Status             string              `json:"status" bson:"status"`
+	CancelReason       string              `json:"cancelReason,omitempty" bson:"cancelReason,omitempty"`
+	PublishAt          time.Time           `json:"publishAt" bson:"publishAt"`
+	SalesStartAt       time.Time           `json:"salesStartAt" bson:"salesStartAt"`
+	SalesEndAt         time.Time           `json:"salesEndAt" bson:"salesEndAt"`
+	IsSalesOpen        bool                `json:"isSalesOpen" bson:"isSalesOpen"`
+	Tickets            []Ticket            `json:"tickets" bson:"tickets"`
+	OnlineTicketConfig *OnlineTicketConfig `json:"onlineTicketConfig" bson:"onlineTicketConfig"`
+}
@@ -0,0 +1,258 @@
+package caches
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"event-service/internal/modules/event"
+	"event-service/internal/modules/event/models/entity"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	wrapper "event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+	"event-service/internal/pkg/redis"
+	"expvar"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	lockTTL      = 5 * time.Second
+	lockWait     = 50 * time.Millisecond
+	lockAttempts = 10
+	// listSettleTime bounds the replication lag of the slave, the lists read within it after a write are not cached
+	listSettleTime = 5 * time.Second
+)
+
+// stats counts the cache hits and misses per read, they are served on /debug/vars.
+var stats = expvar.NewMap("eventCache")
+
+type cachedList struct {
+	Events     []entity.Event `json:"events"`
+	Count      int64          `json:"count"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+type cacheRedisRepository struct {
+	event.MongodbRepositoryQuery
+	redisClient redis.Collections
+	ttl         time.Duration
+	logger      log.Logger
+}
+
+// NewCacheRedisRepository caches the event list and event detail of query for ttl, the other reads go to query directly.
+func NewCacheRedisRepository(query event.MongodbRepositoryQuery, redisClient redis.Collections, ttl time.Duration,
+	log log.Logger) event.RedisRepositoryCache {
+	return &cacheRedisRepository{
+		MongodbRepositoryQuery: query,
+		redisClient:            redisClient,
+		ttl:                    ttl,
+		logger:                 log,
+	}
+}
+
+// listKey is versioned, a write of an event bumps the version so every cached list is dropped at once. The filter is
+// normalized so equivalent queries share an entry.
+func listKey(version string, filter entity.EventFilter) string {
+	filter.Search = strings.ToLower(strings.TrimSpace(filter.Search))
+	filter.City = strings.ToLower(strings.TrimSpace(filter.City))
+	filter.From = filter.From.UTC()
+	filter.To = filter.To.UTC()
+	if filter.EventIds != nil {
+		eventIds := append([]string{}, filter.EventIds...)
+		sort.Strings(eventIds)
+		filter.EventIds = eventIds
+	}
+
+	marshaled, _ := json.Marshal(filter)
+	hash := sha256.Sum256(marshaled)
+	return fmt.Sprintf("%s:%s:%s", constants.RedisKeyEventListCache, version, hex.EncodeToString(hash[:]))
+}
+
+// detailKey is versioned per event, a write of the event bumps only its own version. A detail loaded before the write
+// is stored under the old version, so it is never served after the write.
+func detailKey(version string, eventId string) string {
+	return fmt.Sprintf("%s:%s:%s", constants.RedisKeyEventDetailCache, version, eventId)
+}
+
+func detailVersionKey(eventId string) string {
+	return fmt.Sprintf("%s:%s", constants.RedisKeyEventDetailVersion, eventId)
+}
+
+// version returns the current version of versionKey, it must be read before loading from mongodb.
+func (c cacheRedisRepository) version(ctx context.Context, versionKey string) (string, bool) {
+	version, err := c.redisClient.Get(ctx, versionKey).Result()
+	if err == redis.Nil {
+		return "0", true
+	}
+	if err != nil {
+		c.logger.Error(ctx, fmt.Sprintf("Error Redis: %s", err.Error()), versionKey)
+		return "", false
+	}
+	return version, true
+}
+
+// settling reports whether an event was written within listSettleTime, the slave may not have it yet.
+func (c cacheRedisRepository) settling(ctx context.Context) bool {
+	err := c.redisClient.Get(ctx, constants.RedisKeyEventListSettling).Err()
+	if err != nil && err != redis.Nil {
+		c.logger.Error(ctx, fmt.Sprintf("Error Redis: %s", err.Error()), constants.RedisKeyEventListSettling)
+	}
+	return err != redis.Nil
+}
+
+func (c cacheRedisRepository) FindAllEvent(ctx context.Context, filter entity.EventFilter) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		version, ok := c.version(ctx, constants.RedisKeyEventListVersion)
+		if !ok {
+			output <- <-c.MongodbRepositoryQuery.FindAllEvent(ctx, filter)
+			return
+		}
+
+		var list cachedList
+		output <- c.readThrough(ctx, "list", listKey(version, filter), &list, func() (wrapper.Result, interface{}) {
+			settling := c.settling(ctx)
+			resp := <-c.MongodbRepositoryQuery.FindAllEvent(ctx, filter)
+			if resp.Error != nil || resp.Data == nil || settling {
+				return resp, nil
+			}
+			events, ok := resp.Data.(*[]entity.Event)
+			if !ok {
+				return resp, nil
+			}
+			return resp, cachedList{Events: *events, Count: resp.Count, NextCursor: resp.NextCursor}
+		}, func() wrapper.Result {
+			return wrapper.Result{Data: &list.Events, Count: list.Count, NextCursor: list.NextCursor}
+		})
+	}()
+
+	return output
+}
+
+func (c cacheRedisRepository) FindEventById(ctx context.Context, eventId string) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		version, ok := c.version(ctx, detailVersionKey(eventId))
+		if !ok {
+			output <- <-c.MongodbRepositoryQuery.FindEventById(ctx, eventId)
+			return
+		}
+
+		var event entity.Event
+		output <- c.readThrough(ctx, "detail", detailKey(version, eventId), &event, func() (wrapper.Result, interface{}) {
+			resp := <-c.MongodbRepositoryQuery.FindEventById(ctx, eventId)
+			if resp.Error != nil || resp.Data == nil {
+				return resp, nil
+			}
+			return resp, resp.Data
+		}, func() wrapper.Result {
+			return wrapper.Result{Data: &event}
+		})
+	}()
+
+	return output
+}
+
+// RefreshEvent is called with the event as it was just written to the master. It bumps the detail version of the
+// event and caches the event under the new version, so the detail is never reloaded from the lagging slave. The list
+// version is bumped too, and the lists read from the slave during listSettleTime are not cached. The entries of the
+// old versions expire with their ttl.
+func (c cacheRedisRepository) RefreshEvent(ctx context.Context, event entity.Event) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		version, err := c.redisClient.Incr(ctx, detailVersionKey(event.EventId)).Result()
+		if err != nil {
+			output <- c.redisError(ctx, err, event.EventId)
+			return
+		}
+
+		// a reader that missed the new version only fills the key when it is empty, it never overwrites this one
+		marshaled, _ := json.Marshal(event)
+		if err := c.redisClient.Set(ctx, detailKey(strconv.FormatInt(version, 10), event.EventId), marshaled, c.ttl).Err(); err != nil {
+			output <- c.redisError(ctx, err, event.EventId)
+			return
+		}
+
+		if err := c.redisClient.Set(ctx, constants.RedisKeyEventListSettling, 1, listSettleTime).Err(); err != nil {
+			output <- c.redisError(ctx, err, event.EventId)
+			return
+		}
+
+		if err := c.redisClient.Incr(ctx, constants.RedisKeyEventListVersion).Err(); err != nil {
+			output <- c.redisError(ctx, err, event.EventId)
+			return
+		}
+		output <- wrapper.Result{Data: event.EventId}
+	}()
+
+	return output
+}
+
+// readThrough serves key from the cache into value. On a miss only the caller holding the lock loads from mongodb,
+// the others wait for it to fill the cache and load themselves when it takes too long. load returns nil as the
+// value to cache when the result must not be cached, hit builds the result from value. The value is only stored in an
+// empty key, so it never overwrites an event written by RefreshEvent.
+func (c cacheRedisRepository) readThrough(ctx context.Context, name string, key string, value interface{},
+	load func() (wrapper.Result, interface{}), hit func() wrapper.Result) wrapper.Result {
+	if c.get(ctx, key, value) {
+		stats.Add(name+"Hit", 1)
+		return hit()
+	}
+	stats.Add(name+"Miss", 1)
+
+	lockKey := fmt.Sprintf("%s:%s", constants.RedisKeyEventCacheLock, key)
+	locked, err := c.redisClient.SetNX(ctx, lockKey, 1, lockTTL).Result()
+	if err == nil && !locked {
+		for i := 0; i < lockAttempts; i++ {
+			time.Sleep(lockWait)
+			if c.get(ctx, key, value) {
+				return hit()
+			}
+		}
+	}
+
+	resp, cacheValue := load()
+	if cacheValue != nil {
+		if marshaled, err := json.Marshal(cacheValue); err == nil {
+			if err := c.redisClient.SetNX(ctx, key, marshaled, c.ttl).Err(); err != nil {
+				c.logger.Error(ctx, fmt.Sprintf("Error Redis: %s", err.Error()), key)
+			}
+		}
+	}
+	if locked {
+		c.redisClient.Del(ctx, lockKey)
+	}
+	return resp
+}
+
+func (c cacheRedisRepository) redisError(ctx context.Context, err error, payload interface{}) wrapper.Result {
+	c.logger.Error(ctx, fmt.Sprintf("Error Redis: %s", err.Error()), fmt.Sprintf("%+v", payload))
+	return wrapper.Result{
+		Error: errors.InternalServerError("Error redis"),
+	}
+}
+
+func (c cacheRedisRepository) get(ctx context.Context, key string, value interface{}) bool {
+	cached, err := c.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			c.logger.Error(ctx, fmt.Sprintf("Error Redis: %s", err.Error()), key)
+		}
+		return false
+	}
+	return json.Unmarshal(cached, value) == nil
+}
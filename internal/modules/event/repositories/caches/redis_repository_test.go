@@ -0,0 +1,296 @@
+package caches_test
+
+import (
+	"context"
+	"encoding/json"
+	"event-service/internal/modules/event"
+	"event-service/internal/modules/event/models/entity"
+	"event-service/internal/modules/event/repositories/caches"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	pkgRedis "event-service/internal/pkg/redis"
+	mockcert "event-service/mocks/modules/event"
+	mocklog "event-service/mocks/pkg/log"
+	"expvar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+	miniRedis      *miniredis.Miniredis
+	mockLogger     *mocklog.Logger
+	mockRepository *mockcert.MongodbRepositoryQuery
+	repository     event.RedisRepositoryCache
+	ctx            context.Context
+}
+
+func (suite *CacheTestSuite) SetupTest() {
+	suite.miniRedis = miniredis.RunT(suite.T())
+	suite.mockLogger = &mocklog.Logger{}
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockRepository = &mockcert.MongodbRepositoryQuery{}
+	suite.repository = caches.NewCacheRedisRepository(
+		suite.mockRepository,
+		&pkgRedis.RedisClient{Client: redis.NewClient(&redis.Options{Addr: suite.miniRedis.Addr()})},
+		time.Minute,
+		suite.mockLogger,
+	)
+	suite.ctx = context.Background()
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
+
+func (suite *CacheTestSuite) mockFindAllEvent(filter interface{}, result helpers.Result) {
+	suite.mockRepository.On("FindAllEvent", mock.Anything, filter).Return(func(ctx context.Context, filter entity.EventFilter) <-chan helpers.Result {
+		return mockChannel(result)
+	})
+}
+
+func (suite *CacheTestSuite) mockFindEventById(result helpers.Result) {
+	suite.mockRepository.On("FindEventById", mock.Anything, "id").Return(func(ctx context.Context, eventId string) <-chan helpers.Result {
+		return mockChannel(result)
+	})
+}
+
+func stat(name string) int64 {
+	value := expvar.Get("eventCache").(*expvar.Map).Get(name)
+	if value == nil {
+		return 0
+	}
+	return value.(*expvar.Int).Value()
+}
+
+func (suite *CacheTestSuite) TestFindAllEvent() {
+	filter := entity.EventFilter{Search: "rock", Page: 1, Size: 10}
+	suite.mockFindAllEvent(mock.Anything, helpers.Result{
+		Data:  &[]entity.Event{{EventId: "id", Name: "Rock", DateTime: time.Date(2030, 1, 1, 19, 0, 0, 0, time.UTC)}},
+		Count: 1,
+	})
+	hit, miss := stat("listHit"), stat("listMiss")
+
+	first := <-suite.repository.FindAllEvent(suite.ctx, filter)
+	second := <-suite.repository.FindAllEvent(suite.ctx, filter)
+
+	assert.NoError(suite.T(), second.Error)
+	assert.Equal(suite.T(), first.Data, second.Data)
+	assert.Equal(suite.T(), int64(1), second.Count)
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindAllEvent", 1)
+	assert.Equal(suite.T(), hit+1, stat("listHit"))
+	assert.Equal(suite.T(), miss+1, stat("listMiss"))
+	for _, key := range suite.miniRedis.Keys() {
+		assert.False(suite.T(), strings.HasPrefix(key, constants.RedisKeyEventCacheLock), "the lock is released")
+	}
+}
+
+func (suite *CacheTestSuite) TestFindAllEventNormalizedKey() {
+	suite.mockFindAllEvent(mock.Anything, helpers.Result{Data: &[]entity.Event{{EventId: "id1"}, {EventId: "id2"}}})
+
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Search: "Rock ", EventIds: []string{"id2", "id1"}, Page: 1, Size: 10})
+	resp := <-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Search: "rock", EventIds: []string{"id1", "id2"}, Page: 1, Size: 10})
+
+	assert.Len(suite.T(), *resp.Data.(*[]entity.Event), 2)
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindAllEvent", 1)
+}
+
+func (suite *CacheTestSuite) TestFindAllEventDifferentFilter() {
+	suite.mockFindAllEvent(mock.Anything, helpers.Result{Data: &[]entity.Event{}})
+
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 1, Size: 10})
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 2, Size: 10})
+
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindAllEvent", 2)
+}
+
+func (suite *CacheTestSuite) TestFindAllEventErrNotCached() {
+	suite.mockFindAllEvent(mock.Anything, helpers.Result{Error: errors.InternalServerError("error")})
+
+	first := <-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 1, Size: 10})
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 1, Size: 10})
+
+	assert.Error(suite.T(), first.Error)
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindAllEvent", 2)
+}
+
+func (suite *CacheTestSuite) TestFindAllEventKeepsCursor() {
+	suite.mockFindAllEvent(mock.Anything, helpers.Result{Data: &[]entity.Event{{EventId: "id"}}, NextCursor: "next"})
+
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Keyset: true, Size: 1})
+	resp := <-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Keyset: true, Size: 1})
+
+	assert.Equal(suite.T(), "next", resp.NextCursor)
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindAllEvent", 1)
+}
+
+func (suite *CacheTestSuite) TestFindAllEventRedisDown() {
+	suite.mockFindAllEvent(mock.Anything, helpers.Result{Data: &[]entity.Event{{EventId: "id"}}})
+	suite.miniRedis.Close()
+
+	resp := <-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 1, Size: 10})
+
+	assert.NoError(suite.T(), resp.Error)
+	assert.Len(suite.T(), *resp.Data.(*[]entity.Event), 1)
+}
+
+func (suite *CacheTestSuite) TestRefreshEvent() {
+	suite.mockFindAllEvent(mock.Anything, helpers.Result{Data: &[]entity.Event{{EventId: "id"}}})
+	suite.mockFindEventById(helpers.Result{Data: &entity.Event{EventId: "id", Name: "stale"}})
+
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 1, Size: 10})
+	<-suite.repository.FindEventById(suite.ctx, "id")
+	assert.True(suite.T(), suite.miniRedis.Exists(constants.RedisKeyEventDetailCache+":0:id"))
+
+	resp := <-suite.repository.RefreshEvent(suite.ctx, entity.Event{EventId: "id", Name: "fresh"})
+	assert.NoError(suite.T(), resp.Error)
+
+	// the detail is the event written by the command side, it is not read again from the slave
+	detail := <-suite.repository.FindEventById(suite.ctx, "id")
+	assert.Equal(suite.T(), "fresh", detail.Data.(*entity.Event).Name)
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindEventById", 1)
+
+	// the lists read while the slave may lag behind the write are not cached
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 1, Size: 10})
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 1, Size: 10})
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindAllEvent", 3)
+
+	suite.miniRedis.FastForward(6 * time.Second)
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 1, Size: 10})
+	<-suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Page: 1, Size: 10})
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindAllEvent", 4)
+}
+
+func (suite *CacheTestSuite) TestRefreshEventKeepsOtherDetails() {
+	suite.mockFindEventById(helpers.Result{Data: &entity.Event{EventId: "id"}})
+
+	<-suite.repository.FindEventById(suite.ctx, "id")
+	<-suite.repository.RefreshEvent(suite.ctx, entity.Event{EventId: "other"})
+	<-suite.repository.FindEventById(suite.ctx, "id")
+
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindEventById", 1)
+}
+
+func (suite *CacheTestSuite) TestRefreshEventDuringLoad() {
+	// the detail is read from the slave before a write and stored only once the write refreshed the cache
+	suite.mockRepository.On("FindEventById", mock.Anything, "id").Return(func(ctx context.Context, eventId string) <-chan helpers.Result {
+		<-suite.repository.RefreshEvent(suite.ctx, entity.Event{EventId: "id", Name: "fresh"})
+		return mockChannel(helpers.Result{Data: &entity.Event{EventId: "id", Name: "stale"}})
+	}).Once()
+
+	<-suite.repository.FindEventById(suite.ctx, "id")
+	resp := <-suite.repository.FindEventById(suite.ctx, "id")
+
+	assert.Equal(suite.T(), "fresh", resp.Data.(*entity.Event).Name)
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindEventById", 1)
+}
+
+func (suite *CacheTestSuite) TestRefreshEventNotOverwritten() {
+	// a reader missed the detail of the new version and loads the stale slave copy while the command side writes it
+	suite.miniRedis.Set(constants.RedisKeyEventDetailVersion+":id", "1")
+	fresh, _ := json.Marshal(entity.Event{EventId: "id", Name: "fresh"})
+	suite.mockRepository.On("FindEventById", mock.Anything, "id").Return(func(ctx context.Context, eventId string) <-chan helpers.Result {
+		suite.miniRedis.Set(constants.RedisKeyEventDetailCache+":1:id", string(fresh))
+		return mockChannel(helpers.Result{Data: &entity.Event{EventId: "id", Name: "stale"}})
+	}).Once()
+
+	<-suite.repository.FindEventById(suite.ctx, "id")
+	resp := <-suite.repository.FindEventById(suite.ctx, "id")
+
+	assert.Equal(suite.T(), "fresh", resp.Data.(*entity.Event).Name)
+}
+
+func (suite *CacheTestSuite) TestFindEventByIdRedisDown() {
+	suite.mockFindEventById(helpers.Result{Data: &entity.Event{EventId: "id"}})
+	suite.miniRedis.Close()
+
+	resp := <-suite.repository.FindEventById(suite.ctx, "id")
+
+	assert.NoError(suite.T(), resp.Error)
+	assert.Equal(suite.T(), "id", resp.Data.(*entity.Event).EventId)
+}
+
+func (suite *CacheTestSuite) TestRefreshEventErr() {
+	suite.miniRedis.Close()
+
+	resp := <-suite.repository.RefreshEvent(suite.ctx, entity.Event{EventId: "id"})
+	assert.Error(suite.T(), resp.Error)
+}
+
+func (suite *CacheTestSuite) TestFindEventById() {
+	suite.mockFindEventById(helpers.Result{Data: &entity.Event{EventId: "id", Name: "Rock"}})
+	hit := stat("detailHit")
+
+	<-suite.repository.FindEventById(suite.ctx, "id")
+	resp := <-suite.repository.FindEventById(suite.ctx, "id")
+
+	assert.Equal(suite.T(), &entity.Event{EventId: "id", Name: "Rock"}, resp.Data)
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindEventById", 1)
+	assert.Equal(suite.T(), hit+1, stat("detailHit"))
+}
+
+func (suite *CacheTestSuite) TestFindEventByIdNotFoundNotCached() {
+	suite.mockFindEventById(helpers.Result{})
+
+	<-suite.repository.FindEventById(suite.ctx, "id")
+	resp := <-suite.repository.FindEventById(suite.ctx, "id")
+
+	assert.Nil(suite.T(), resp.Data)
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindEventById", 2)
+}
+
+func (suite *CacheTestSuite) TestFindEventByIdWaitsForLock() {
+	suite.mockFindEventById(helpers.Result{Data: &entity.Event{EventId: "id"}})
+	lockKey := constants.RedisKeyEventCacheLock + ":" + constants.RedisKeyEventDetailCache + ":0:id"
+	suite.miniRedis.Set(lockKey, "1")
+
+	go func() {
+		time.Sleep(60 * time.Millisecond)
+		cached, _ := json.Marshal(entity.Event{EventId: "id", Name: "filled"})
+		suite.miniRedis.Set(constants.RedisKeyEventDetailCache+":0:id", string(cached))
+	}()
+
+	resp := <-suite.repository.FindEventById(suite.ctx, "id")
+
+	assert.Equal(suite.T(), "filled", resp.Data.(*entity.Event).Name)
+	suite.mockRepository.AssertNotCalled(suite.T(), "FindEventById", mock.Anything, mock.Anything)
+	assert.True(suite.T(), suite.miniRedis.Exists(lockKey), "the lock of another caller is kept")
+}
+
+func (suite *CacheTestSuite) TestFindEventByIdLockTimeout() {
+	suite.mockFindEventById(helpers.Result{Data: &entity.Event{EventId: "id"}})
+	suite.miniRedis.Set(constants.RedisKeyEventCacheLock+":"+constants.RedisKeyEventDetailCache+":0:id", "1")
+
+	resp := <-suite.repository.FindEventById(suite.ctx, "id")
+
+	assert.Equal(suite.T(), "id", resp.Data.(*entity.Event).EventId)
+	suite.mockRepository.AssertNumberOfCalls(suite.T(), "FindEventById", 1)
+}
+
+func (suite *CacheTestSuite) TestFindEventByTagPassThrough() {
+	suite.mockRepository.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{Data: &entity.Event{Tag: "tag"}}))
+
+	resp := <-suite.repository.FindEventByTag(suite.ctx, "tag")
+
+	assert.Equal(suite.T(), "tag", resp.Data.(*entity.Event).Tag)
+	assert.Empty(suite.T(), suite.miniRedis.Keys())
+}
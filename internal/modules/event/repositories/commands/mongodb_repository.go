@@ -6,8 +6,12 @@ import (
 	"event-service/internal/modules/event/models/entity"
 	"event-service/internal/pkg/databases/mongodb"
 	"event-service/internal/pkg/log"
+	"time"
 
 	wrapper "event-service/internal/pkg/helpers"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type commandMongodbRepository struct {
@@ -36,3 +40,104 @@ func (c commandMongodbRepository) InsertOneEventCollection(ctx context.Context,
 
 	return output
 }
+
+// UpdateOneEventDetail writes the editable details of the event. Count is 0 when the stored event no longer has the
+// expected version, it was changed since it was read.
+func (c commandMongodbRepository) UpdateOneEventDetail(ctx context.Context, event entity.Event, expected entity.EventVersion) <-chan wrapper.Result {
+	document := bson.M{
+		"name":          event.Name,
+		"dateTime":      event.DateTime,
+		"continentName": event.ContinentName,
+		"continentCode": event.ContinentCode,
+		"country":       event.Country,
+		"description":   event.Description,
+		"tag":           event.Tag,
+		"eventUrl":      event.EventUrl,
+		"updatedAt":     event.UpdatedAt,
+		"updatedBy":     event.UpdatedBy,
+	}
+	if !event.PublishAt.IsZero() {
+		document["publishAt"] = event.PublishAt
+	}
+	if !event.SalesStartAt.IsZero() {
+		document["salesStartAt"] = event.SalesStartAt
+	}
+	if !event.SalesEndAt.IsZero() {
+		document["salesEndAt"] = event.SalesEndAt
+	}
+
+	return c.updateOneEvent(ctx, event.EventId, expected, document)
+}
+
+// UpdateOneEventStatus writes the status of the event, with the cancellation details once it is cancelled. Count is 0
+// when the stored event no longer has the expected version.
+func (c commandMongodbRepository) UpdateOneEventStatus(ctx context.Context, event entity.Event, expected entity.EventVersion) <-chan wrapper.Result {
+	document := bson.M{
+		"status":    event.Status,
+		"updatedAt": event.UpdatedAt,
+		"updatedBy": event.UpdatedBy,
+	}
+	if !event.CancelledAt.IsZero() {
+		document["cancelReason"] = event.CancelReason
+		document["cancelledAt"] = event.CancelledAt
+		document["cancelledBy"] = event.CancelledBy
+	}
+
+	return c.updateOneEvent(ctx, event.EventId, expected, document)
+}
+
+func (c commandMongodbRepository) updateOneEvent(ctx context.Context, eventId string, expected entity.EventVersion, document bson.M) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	// events stored before the lifecycle existed have no status
+	var status interface{} = expected.Status
+	if expected.Status == "" {
+		status = bson.M{"$in": bson.A{nil, ""}}
+	}
+
+	go func() {
+		resp := <-c.mongoDb.UpdateOne(mongodb.UpdateOne{
+			CollectionName: "event",
+			Filter: bson.M{
+				"eventId":   eventId,
+				"status":    status,
+				"updatedAt": expected.UpdatedAt,
+			},
+			Document: document,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// AddEventTicketId adds ticketId to the tickets of the event and returns the updated event, Data is nil when the event
+// does not exist. Only the ticket ids are written, an update of the event made meanwhile is kept.
+func (c commandMongodbRepository) AddEventTicketId(ctx context.Context, eventId string, ticketId string, updatedBy string) <-chan wrapper.Result {
+	var event entity.Event
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			CollectionName: "event",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+			Update: bson.M{
+				"$addToSet": bson.M{"ticketIds": ticketId},
+				"$set":      bson.M{"updatedAt": time.Now(), "updatedBy": updatedBy},
+			},
+			Result: &event,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// WithTransaction runs fn in a mongodb transaction, repositories called with txCtx join it.
+func (c commandMongodbRepository) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	return c.mongoDb.WithTransaction(ctx, fn)
+}
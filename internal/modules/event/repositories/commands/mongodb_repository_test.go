@@ -2,17 +2,22 @@ package commands_test
 
 import (
 	"context"
+	"errors"
 	"event-service/internal/modules/event"
 	"event-service/internal/modules/event/models/entity"
 	mongoRC "event-service/internal/modules/event/repositories/commands"
+	"event-service/internal/pkg/databases/mongodb"
 	"event-service/internal/pkg/helpers"
 	mocks "event-service/mocks/pkg/databases/mongodb"
 	mocklog "event-service/mocks/pkg/log"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type CommandTestSuite struct {
@@ -64,3 +69,109 @@ func (suite *CommandTestSuite) TestInsertOneEventCollection() {
 	// Assert UpsertOne
 	suite.mockMongodb.AssertCalled(suite.T(), "InsertOne", mock.Anything, mock.Anything)
 }
+
+func (suite *CommandTestSuite) TestUpdateOneEventDetail() {
+	readAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	payload := entity.Event{
+		EventId:   "id",
+		Name:      "name",
+		Status:    "published",
+		TicketIds: []string{"ticketId"},
+	}
+
+	expectedResult := make(chan helpers.Result, 1)
+	expectedResult <- helpers.Result{Data: "Success update data", Count: 1}
+	close(expectedResult)
+	suite.mockMongodb.On("UpdateOne", mock.MatchedBy(func(payload mongodb.UpdateOne) bool {
+		filter := payload.Filter.(bson.M)
+		document := payload.Document.(bson.M)
+		_, hasStatus := document["status"]
+		_, hasTicketIds := document["ticketIds"]
+		return filter["eventId"] == "id" && filter["status"] == "published" && filter["updatedAt"] == readAt &&
+			document["name"] == "name" && !hasStatus && !hasTicketIds
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := <-suite.repository.UpdateOneEventDetail(suite.ctx, payload, entity.EventVersion{Status: "published", UpdatedAt: readAt})
+
+	assert.Equal(suite.T(), int64(1), result.Count)
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestUpdateOneEventStatus() {
+	readAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	payload := entity.Event{
+		EventId:   "id",
+		Name:      "name",
+		Status:    "sales-closed",
+		TicketIds: []string{"ticketId"},
+	}
+
+	expectedResult := make(chan helpers.Result, 1)
+	expectedResult <- helpers.Result{Data: "Success update data"}
+	close(expectedResult)
+	suite.mockMongodb.On("UpdateOne", mock.MatchedBy(func(payload mongodb.UpdateOne) bool {
+		filter := payload.Filter.(bson.M)
+		document := payload.Document.(bson.M)
+		_, hasName := document["name"]
+		_, hasTicketIds := document["ticketIds"]
+		_, hasCancelledAt := document["cancelledAt"]
+		// an event stored before the lifecycle has no status
+		return assert.ObjectsAreEqual(bson.M{"$in": bson.A{nil, ""}}, filter["status"]) && filter["updatedAt"] == readAt &&
+			document["status"] == "sales-closed" && !hasName && !hasTicketIds && !hasCancelledAt
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := <-suite.repository.UpdateOneEventStatus(suite.ctx, payload, entity.EventVersion{UpdatedAt: readAt})
+
+	assert.Equal(suite.T(), int64(0), result.Count)
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestAddEventTicketId() {
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOneAndUpdate", mock.MatchedBy(func(payload mongodb.FindOneAndUpdate) bool {
+		update, ok := payload.Update.(bson.M)
+		return ok && payload.CollectionName == "event" &&
+			assert.ObjectsAreEqual(bson.M{"ticketIds": "ticketId"}, update["$addToSet"]) &&
+			update["$set"].(bson.M)["updatedBy"] == "userId"
+	}), options.After, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := suite.repository.AddEventTicketId(suite.ctx, "id", "ticketId", "userId")
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	go func() {
+		expectedResult <- helpers.Result{Data: &entity.Event{EventId: "id"}}
+		close(expectedResult)
+	}()
+
+	<-result
+
+	suite.mockMongodb.AssertCalled(suite.T(), "FindOneAndUpdate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandTestSuite) TestWithTransaction() {
+	suite.mockMongodb.On("WithTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(txCtx context.Context) error) error {
+		return fn(ctx)
+	})
+
+	called := false
+	err := suite.repository.WithTransaction(suite.ctx, func(txCtx context.Context) error {
+		called = true
+		return nil
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), called)
+	suite.mockMongodb.AssertCalled(suite.T(), "WithTransaction", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandTestSuite) TestWithTransactionErr() {
+	suite.mockMongodb.On("WithTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(txCtx context.Context) error) error {
+		return fn(ctx)
+	})
+
+	err := suite.repository.WithTransaction(suite.ctx, func(txCtx context.Context) error {
+		return errors.New("error")
+	})
+
+	assert.Error(suite.T(), err)
+}
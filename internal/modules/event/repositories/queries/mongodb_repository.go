@@ -4,10 +4,12 @@ import (
 	"context"
 	"event-service/internal/modules/event"
 	"event-service/internal/modules/event/models/entity"
-	"event-service/internal/modules/event/models/request"
+	"event-service/internal/pkg/constants"
 	"event-service/internal/pkg/databases/mongodb"
 	wrapper "event-service/internal/pkg/helpers"
 	"event-service/internal/pkg/log"
+	"regexp"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -44,6 +46,25 @@ func (q queryMongodbRepository) FindEventByName(ctx context.Context, name string
 	return output
 }
 
+func (q queryMongodbRepository) FindEventById(ctx context.Context, eventId string) <-chan wrapper.Result {
+	var event entity.Event
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &event,
+			CollectionName: "event",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
 func (q queryMongodbRepository) FindEventByTag(ctx context.Context, tag string) <-chan wrapper.Result {
 	var event entity.Event
 	output := make(chan wrapper.Result)
@@ -63,23 +84,144 @@ func (q queryMongodbRepository) FindEventByTag(ctx context.Context, tag string)
 	return output
 }
 
-func (q queryMongodbRepository) FindAllEvent(ctx context.Context, payload request.AllEventReq) <-chan wrapper.Result {
+func (q queryMongodbRepository) FindAllEvent(ctx context.Context, filter entity.EventFilter) <-chan wrapper.Result {
 	var event []entity.Event
-	var countData int64
+	var countData *int64
 	output := make(chan wrapper.Result)
 
+	if !filter.Keyset || filter.WithCount {
+		countData = new(int64)
+	}
+
 	go func() {
 		resp := <-q.mongoDb.FindAllData(mongodb.FindAllData{
 			Result:         &event,
-			CountData:      &countData,
+			CountData:      countData,
 			CollectionName: "event",
-			Filter:         bson.M{"name": primitive.Regex{Pattern: ".*" + payload.Search + ".*", Options: "i"}},
+			Filter:         buildEventFilter(filter),
+			Projection:     buildEventProjection(filter),
+			Sort:           buildEventSort(filter),
+			Page:           filter.Page,
+			Size:           filter.Size,
+			Keyset:         filter.Keyset,
+			Cursor:         filter.Cursor,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// buildEventFilter translates the list filter into a mongo filter, the search runs on the event text index
+// and the city is escaped so it only matches literally.
+func buildEventFilter(filter entity.EventFilter) bson.M {
+	query := bson.M{}
+	if filter.Search != "" {
+		query["$text"] = bson.M{"$search": filter.Search}
+	}
+	if filter.ContinentCode != "" {
+		query["continentCode"] = filter.ContinentCode
+	}
+	if filter.CountryCode != "" {
+		query["country.code"] = filter.CountryCode
+	}
+	if filter.City != "" {
+		query["country.city"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.City) + "$", Options: "i"}
+	}
+	if filter.Tag != "" {
+		query["tag"] = filter.Tag
+	}
+
+	dateTime := bson.M{}
+	if !filter.From.IsZero() {
+		dateTime["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		dateTime["$lt"] = filter.To
+	}
+	if len(dateTime) > 0 {
+		query["dateTime"] = dateTime
+	}
+
+	if filter.EventIds != nil {
+		query["eventId"] = bson.M{"$in": filter.EventIds}
+	}
+
+	hiddenStatus := []string{constants.EventStatusDraft, constants.EventStatusScheduled}
+	if !filter.IncludeCancelled {
+		hiddenStatus = append(hiddenStatus, constants.EventStatusCancelled)
+	}
+	query["status"] = bson.M{"$nin": hiddenStatus}
+
+	return query
+}
+
+func buildEventProjection(filter entity.EventFilter) interface{} {
+	if filter.Search == "" {
+		return nil
+	}
+	return bson.M{"score": bson.M{"$meta": "textScore"}}
+}
+
+// buildEventSort always ends on a unique key so pages stay stable between requests,
+// a search without an explicit sort is ranked by relevance.
+func buildEventSort(filter entity.EventFilter) *mongodb.Sort {
+	if filter.Search != "" && filter.SortBy == "" {
+		return &mongodb.Sort{
+			FieldName: "score",
+			Meta:      "textScore",
+			ThenBy: []mongodb.Sort{
+				{FieldName: "eventId", By: mongodb.SortAscending},
+			},
+		}
+	}
+
+	switch filter.SortBy {
+	case constants.EventSortDate:
+		return &mongodb.Sort{
+			FieldName: "dateTime",
+			By:        mongodb.SortAscending,
+			ThenBy: []mongodb.Sort{
+				{FieldName: "name", By: mongodb.SortAscending},
+				{FieldName: "eventId", By: mongodb.SortAscending},
+			},
+		}
+	case constants.EventSortNewest:
+		return &mongodb.Sort{
+			FieldName: "createdAt",
+			By:        mongodb.SortDescending,
+			ThenBy: []mongodb.Sort{
+				{FieldName: "eventId", By: mongodb.SortAscending},
+			},
+		}
+	default:
+		return &mongodb.Sort{
+			FieldName: "name",
+			By:        mongodb.SortAscending,
+			ThenBy: []mongodb.Sort{
+				{FieldName: "eventId", By: mongodb.SortAscending},
+			},
+		}
+	}
+}
+
+func (q queryMongodbRepository) FindEventsByStatusBefore(ctx context.Context, status string, field string, before time.Time) <-chan wrapper.Result {
+	var event []entity.Event
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &event,
+			CollectionName: "event",
+			Filter: bson.M{
+				"status": status,
+				field:    bson.M{"$lte": before},
+			},
 			Sort: &mongodb.Sort{
-				FieldName: "name",
+				FieldName: field,
 				By:        mongodb.SortAscending,
 			},
-			Page: payload.Page,
-			Size: payload.Size,
 		}, ctx)
 		output <- resp
 		close(output)
@@ -3,8 +3,10 @@ package queries_test
 import (
 	"context"
 	"event-service/internal/modules/event"
-	"event-service/internal/modules/event/models/request"
+	"event-service/internal/modules/event/models/entity"
 	mongoRQ "event-service/internal/modules/event/repositories/queries"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/databases/mongodb"
 	"event-service/internal/pkg/helpers"
 	mocks "event-service/mocks/pkg/databases/mongodb"
 	mocklog "event-service/mocks/pkg/log"
@@ -13,6 +15,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 )
 
 type CommandTestSuite struct {
@@ -61,6 +66,30 @@ func (suite *CommandTestSuite) TestFindEventByName() {
 	suite.mockMongodb.AssertCalled(suite.T(), "FindOne", mock.Anything, mock.Anything)
 }
 
+func (suite *CommandTestSuite) TestFindEventById() {
+
+	// Mock FindOne
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOne", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindEventById(suite.ctx, "eventId")
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOne
+	suite.mockMongodb.AssertCalled(suite.T(), "FindOne", mock.Anything, mock.Anything)
+}
+
 func (suite *CommandTestSuite) TestFindEventByTag() {
 
 	// Mock FindOne
@@ -92,7 +121,7 @@ func (suite *CommandTestSuite) TestFindAllEvent() {
 	suite.mockMongodb.On("FindAllData", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
 
 	// Act
-	result := suite.repository.FindAllEvent(suite.ctx, request.AllEventReq{})
+	result := suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{})
 	// Asset
 	assert.NotNil(suite.T(), result, "Expected a result")
 
@@ -108,3 +137,301 @@ func (suite *CommandTestSuite) TestFindAllEvent() {
 	// Assert FindOne
 	suite.mockMongodb.AssertCalled(suite.T(), "FindAllData", mock.Anything, mock.Anything)
 }
+
+func (suite *CommandTestSuite) TestFindAllEventHideCancelled() {
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		filter := payload.Filter.(bson.M)
+		status, ok := filter["status"].(bson.M)
+		return ok && assert.ObjectsAreEqual([]string{constants.EventStatusDraft, constants.EventStatusScheduled, constants.EventStatusCancelled}, status["$nin"])
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{})
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestFindAllEventIncludeCancelled() {
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		status, ok := payload.Filter.(bson.M)["status"].(bson.M)
+		return ok && assert.ObjectsAreEqual([]string{constants.EventStatusDraft, constants.EventStatusScheduled}, status["$nin"])
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{IncludeCancelled: true})
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestFindEventsByStatusBefore() {
+
+	// Mock FindMany
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindMany", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindEventsByStatusBefore(suite.ctx, constants.EventStatusScheduled, "publishAt", time.Now())
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindMany
+	suite.mockMongodb.AssertCalled(suite.T(), "FindMany", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandTestSuite) TestFindAllEventFilters() {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		filter := payload.Filter.(bson.M)
+		return assert.ObjectsAreEqual(bson.M{"$search": "rock live"}, filter["$text"]) &&
+			filter["continentCode"] == "AS" &&
+			filter["country.code"] == "ID" &&
+			assert.ObjectsAreEqual(primitive.Regex{Pattern: `^St\. John's \(Old\)$`, Options: "i"}, filter["country.city"]) &&
+			filter["tag"] == "tag" &&
+			assert.ObjectsAreEqual(bson.M{"$gte": from, "$lt": to}, filter["dateTime"]) &&
+			assert.ObjectsAreEqual(bson.M{"$in": []string{"id"}}, filter["eventId"])
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{
+		Search:        "rock live",
+		ContinentCode: "AS",
+		CountryCode:   "ID",
+		City:          "St. John's (Old)",
+		Tag:           "tag",
+		From:          from,
+		To:            to,
+		EventIds:      []string{"id"},
+	})
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestFindAllEventNoFilters() {
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		filter := payload.Filter.(bson.M)
+		_, hasText := filter["$text"]
+		_, hasDateTime := filter["dateTime"]
+		_, hasEventId := filter["eventId"]
+		return len(filter) == 1 && !hasText && !hasDateTime && !hasEventId && payload.Projection == nil
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{})
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestFindAllEventSort() {
+	testCases := []struct {
+		sortBy string
+		sort   mongodb.Sort
+	}{
+		{
+			sortBy: "",
+			sort: mongodb.Sort{FieldName: "name", By: mongodb.SortAscending, ThenBy: []mongodb.Sort{
+				{FieldName: "eventId", By: mongodb.SortAscending},
+			}},
+		},
+		{
+			sortBy: constants.EventSortDate,
+			sort: mongodb.Sort{FieldName: "dateTime", By: mongodb.SortAscending, ThenBy: []mongodb.Sort{
+				{FieldName: "name", By: mongodb.SortAscending},
+				{FieldName: "eventId", By: mongodb.SortAscending},
+			}},
+		},
+		{
+			sortBy: constants.EventSortNewest,
+			sort: mongodb.Sort{FieldName: "createdAt", By: mongodb.SortDescending, ThenBy: []mongodb.Sort{
+				{FieldName: "eventId", By: mongodb.SortAscending},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		mockMongodb := new(mocks.Collections)
+		repository := mongoRQ.NewQueryMongodbRepository(mockMongodb, suite.mockLogger)
+
+		// Mock FindAllData
+		expectedResult := make(chan helpers.Result)
+		mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+			return assert.ObjectsAreEqual(&tc.sort, payload.Sort)
+		}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+		// Act
+		result := repository.FindAllEvent(suite.ctx, entity.EventFilter{SortBy: tc.sortBy})
+
+		// Simulate receiving a result from the channel
+		go func() {
+			expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+			close(expectedResult)
+		}()
+
+		// Wait for the goroutine to complete
+		<-result
+
+		// Assert FindAllData
+		mockMongodb.AssertExpectations(suite.T())
+	}
+}
+
+func (suite *CommandTestSuite) TestFindAllEventKeyset() {
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		return payload.Keyset && payload.Cursor == "cursor" && payload.Size == 10 && payload.CountData == nil
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Keyset: true, Cursor: "cursor", Size: 10})
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", NextCursor: "next"}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	resp := <-result
+	assert.Equal(suite.T(), "next", resp.NextCursor)
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestFindAllEventKeysetWithCount() {
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		return payload.Keyset && payload.CountData != nil
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Keyset: true, Size: 10, WithCount: true})
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Count: 1}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestFindAllEventSearchRanking() {
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		return assert.ObjectsAreEqual(bson.M{"score": bson.M{"$meta": "textScore"}}, payload.Projection) &&
+			assert.ObjectsAreEqual(&mongodb.Sort{FieldName: "score", Meta: "textScore", ThenBy: []mongodb.Sort{
+				{FieldName: "eventId", By: mongodb.SortAscending},
+			}}, payload.Sort)
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Search: "rock"})
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestFindAllEventSearchSortBy() {
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		return payload.Sort.FieldName == "dateTime" && payload.Projection != nil
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllEvent(suite.ctx, entity.EventFilter{Search: "rock", SortBy: constants.EventSortDate})
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"sort"
+
+	ticketEntity "event-service/internal/modules/ticket/models/entity"
+)
+
+// allocateCountryQuota splits totalQuota over the countries by percentage with the largest remainder method: every
+// country gets the floor of its share, then the tickets lost to rounding go one by one to the countries with the
+// largest fractional part, earlier countries first on a tie. The quotas always add up to totalQuota.
+func allocateCountryQuota(totalQuota int, countryList []ticketEntity.CountryList) {
+	remainders := make([]int, len(countryList))
+	order := make([]int, len(countryList))
+	allocated := 0
+	for i := range countryList {
+		share := totalQuota * countryList[i].Percentage
+		countryList[i].Quota = share / 100
+		remainders[i] = share % 100
+		order[i] = i
+		allocated += countryList[i].Quota
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for i := 0; allocated < totalQuota && i < len(order); i++ {
+		countryList[order[i]].Quota++
+		allocated++
+	}
+}
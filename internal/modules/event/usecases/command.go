@@ -13,34 +13,56 @@ import (
 	"event-service/internal/modules/event"
 	"event-service/internal/modules/event/models/entity"
 	"event-service/internal/modules/event/models/request"
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/outbox"
 	"event-service/internal/modules/ticket"
 	"event-service/internal/pkg/constants"
 	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
 	"event-service/internal/pkg/log"
 
 	addressEntity "event-service/internal/modules/address/models/entity"
+	outboxEntity "event-service/internal/modules/outbox/models/entity"
 	ticketEntity "event-service/internal/modules/ticket/models/entity"
 	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
 )
 
+const (
+	outboxDeliveryTimeout = 5 * time.Second
+	// outboxDeliveryLease keeps the relay away from a fresh message while it is delivered and marked sent inline.
+	outboxDeliveryLease = 2 * outboxDeliveryTimeout
+)
+
 type commandUsecase struct {
-	eventRepositoryQuery    event.MongodbRepositoryQuery
-	eventRepositoryCommand  event.MongodbRepositoryCommand
-	ticketRepositoryCommand ticket.MongodbRepositoryCommand
-	addressRepositoryQuery  address.MongodbRepositoryQuery
-	kafkaProducer           kafkaConfluent.Producer
-	logger                  log.Logger
+	eventRepositoryQuery     event.MongodbRepositoryQuery
+	eventRepositoryCommand   event.MongodbRepositoryCommand
+	eventRepositoryCache     event.RedisRepositoryCache
+	ticketRepositoryQuery    ticket.MongodbRepositoryQuery
+	ticketRepositoryCommand  ticket.MongodbRepositoryCommand
+	ticketRepositoryStock    ticket.RedisRepositoryStock
+	addressRepositoryQuery   address.MongodbRepositoryQuery
+	outboxRepositoryCommand  outbox.MongodbRepositoryCommand
+	organizerRepositoryQuery organizer.MongodbRepositoryQuery
+	kafkaProducer            kafkaConfluent.Producer
+	logger                   log.Logger
 }
 
-func NewCommandUsecase(erq event.MongodbRepositoryQuery, erc event.MongodbRepositoryCommand,
-	trc ticket.MongodbRepositoryCommand, arq address.MongodbRepositoryQuery, kp kafkaConfluent.Producer, log log.Logger) event.UsecaseCommand {
+func NewCommandUsecase(erq event.MongodbRepositoryQuery, erc event.MongodbRepositoryCommand, ercc event.RedisRepositoryCache,
+	trq ticket.MongodbRepositoryQuery, trc ticket.MongodbRepositoryCommand, trs ticket.RedisRepositoryStock,
+	arq address.MongodbRepositoryQuery, orc outbox.MongodbRepositoryCommand, orq organizer.MongodbRepositoryQuery,
+	kp kafkaConfluent.Producer, log log.Logger) event.UsecaseCommand {
 	return commandUsecase{
-		eventRepositoryQuery:    erq,
-		eventRepositoryCommand:  erc,
-		ticketRepositoryCommand: trc,
-		addressRepositoryQuery:  arq,
-		kafkaProducer:           kp,
-		logger:                  log,
+		eventRepositoryQuery:     erq,
+		eventRepositoryCommand:   erc,
+		eventRepositoryCache:     ercc,
+		ticketRepositoryQuery:    trq,
+		ticketRepositoryCommand:  trc,
+		ticketRepositoryStock:    trs,
+		addressRepositoryQuery:   arq,
+		outboxRepositoryCommand:  orc,
+		organizerRepositoryQuery: orq,
+		kafkaProducer:            kp,
+		logger:                   log,
 	}
 }
 
@@ -52,6 +74,15 @@ func (c commandUsecase) CreateEvent(origCtx context.Context, payload request.Eve
 	})
 	defer span.End()
 
+	allowed, err := checkTeamAccess(ctx, c.organizerRepositoryQuery, payload.OrganizerId, "", payload.UserId,
+		payload.UserRole, editorRoles...)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.ForbiddenError("only the owners and editors of the organizer can create its events")
+	}
+
 	currentEvent := <-c.eventRepositoryQuery.FindEventByName(ctx, payload.Name)
 	if currentEvent.Error != nil {
 		return nil, currentEvent.Error
@@ -72,51 +103,43 @@ func (c commandUsecase) CreateEvent(origCtx context.Context, payload request.Eve
 			return nil, errors.InternalServerError("failed marshal event")
 		}
 
-		if payload.UserId != eventTag.CreatedBy {
-			return nil, errors.BadRequest("tag already exist, please create event with the same user to use this tag")
+		if !sameOwner(payload.OrganizerId, payload.UserId, eventTag.OrganizerId, eventTag.CreatedBy) {
+			return nil, errors.BadRequest("tag already exist, please create event with the same organizer to use this tag")
 		}
 	}
 
-	dateTime, err := time.Parse("2006-01-02 15:04", payload.DateTime)
+	dateTime, err := time.Parse(dateTimeLayout, payload.DateTime)
 	if err != nil {
 		return nil, errors.BadRequest("Format dateTime must be 'YYYY-MM-DD HH:MM'")
 	}
 
-	continentData := <-c.addressRepositoryQuery.FindOneContinentByCode(ctx, payload.ContinentCode)
-	if continentData.Error != nil {
-		return nil, currentEvent.Error
-	}
-
-	if continentData.Data == nil {
-		return nil, errors.BadRequest("continent not found")
-	}
-
-	continent, ok := continentData.Data.(*addressEntity.Continent)
-	if !ok {
-		return nil, errors.InternalServerError("failed marshal continentData")
-	}
-
-	countryData := <-c.addressRepositoryQuery.FindOneCountry(ctx, payload.Country.Id)
-	if countryData.Error != nil {
-		return nil, currentEvent.Error
+	publishAt, salesStartAt, salesEndAt, err := parseSchedule(payload.PublishAt, payload.SalesStartAt, payload.SalesEndAt)
+	if err != nil {
+		return nil, err
 	}
 
-	if countryData.Data == nil {
-		return nil, errors.BadRequest("continent not found")
+	if err := validateSchedule(dateTime, publishAt, salesStartAt, salesEndAt); err != nil {
+		return nil, err
 	}
 
-	country, ok := countryData.Data.(*addressEntity.Country)
-	if !ok {
-		return nil, errors.InternalServerError("failed marshal countryData")
+	continent, country, err := c.validateLocation(ctx, payload.ContinentCode, payload.Country.Id)
+	if err != nil {
+		return nil, err
 	}
 
 	eventId := uuid.New().String()
 	tiketIds := make([]string, 0)
 	tickets := make([]ticketEntity.Ticket, 0)
 	for _, v := range payload.Tickets {
-		if v.TicketType != constants.Bronze && v.TicketType != constants.Gold && v.TicketType != constants.Silver &&
-			v.TicketType != constants.Wood && v.TicketType != constants.Online {
-			return nil, errors.BadRequest("ticketType must be 'Online' or 'Wood' or 'Gold' or 'Bronze' or 'Silver'")
+		if err := validateTicketType(v.TicketType); err != nil {
+			return nil, err
+		}
+		_, ticketSalesStartAt, ticketSalesEndAt, err := parseSchedule("", v.SalesStartAt, v.SalesEndAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateSchedule(dateTime, time.Time{}, ticketSalesStartAt, ticketSalesEndAt); err != nil {
+			return nil, err
 		}
 		ticketId := uuid.New().String()
 		ticket := ticketEntity.Ticket{
@@ -133,19 +156,18 @@ func (c commandUsecase) CreateEvent(origCtx context.Context, payload request.Eve
 				City:  payload.Country.City,
 				Place: payload.Country.Place,
 			},
-			ContinentName: payload.ContinentName,
-			ContinentCode: payload.ContinentCode,
-			CreatedAt:     time.Now(),
-			UpdatedAt:     time.Now(),
+			ContinentName:      payload.ContinentName,
+			ContinentCode:      payload.ContinentCode,
+			IsSellable:         false,
+			ProvisioningStatus: constants.TicketProvisioningPending,
+			SalesStartAt:       ticketSalesStartAt,
+			SalesEndAt:         ticketSalesEndAt,
+			CreatedAt:          time.Now(),
+			UpdatedAt:          time.Now(),
 		}
 		tickets = append(tickets, ticket)
 		tiketIds = append(tiketIds, ticketId)
 	}
-	respTicket := <-c.ticketRepositoryCommand.InsertManyTicketCollection(ctx, tickets)
-	if respTicket.Error != nil {
-		return nil, respTicket.Error
-	}
-
 	event := entity.Event{
 		EventId:  eventId,
 		Name:     payload.Name,
@@ -159,31 +181,41 @@ func (c commandUsecase) CreateEvent(origCtx context.Context, payload request.Eve
 			City:  payload.Country.City,
 			Place: payload.Country.Place,
 		},
-		Description: payload.Description,
-		Tag:         payload.Tag,
-		EventUrl:    payload.EventUrl,
-		TicketIds:   tiketIds,
-		CreatedBy:   payload.UserId,
-		UpdatedBy:   payload.UserId,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Description:  payload.Description,
+		Tag:          payload.Tag,
+		EventUrl:     payload.EventUrl,
+		OrganizerId:  payload.OrganizerId,
+		TicketIds:    tiketIds,
+		Status:       constants.EventStatusDraft,
+		PublishAt:    publishAt,
+		SalesStartAt: salesStartAt,
+		SalesEndAt:   salesEndAt,
+		CreatedBy:    payload.UserId,
+		UpdatedBy:    payload.UserId,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 
-	respEvent := <-c.eventRepositoryCommand.InsertOneEventCollection(ctx, event)
-	if respEvent.Error != nil {
-		return nil, errors.InternalServerError("failed save event")
-	}
+	err = c.eventRepositoryCommand.WithTransaction(ctx, func(txCtx context.Context) error {
+		respTicket := <-c.ticketRepositoryCommand.InsertManyTicketCollection(txCtx, tickets)
+		if respTicket.Error != nil {
+			return respTicket.Error
+		}
 
-	for _, ticketId := range tiketIds {
-		createTicketReq := request.CreateTicketReq{
-			TicketId: ticketId,
-			EventId:  eventId,
+		respEvent := <-c.eventRepositoryCommand.InsertOneEventCollection(txCtx, event)
+		// the unique name index catches a concurrent create that passed the name check
+		if errors.IsConflict(respEvent.Error) {
+			return errors.Conflict("event already exist")
 		}
-		marshaledKafkaData, _ := json.Marshal(createTicketReq)
-		topic := "concert-create-bank-ticket"
-		c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
-		c.logger.Info(ctx, fmt.Sprintf("Send kafka create bank ticket, ticketId : %s", ticketId), fmt.Sprintf("%+v", createTicketReq))
+		if respEvent.Error != nil {
+			return errors.InternalServerError("failed save event")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	c.refreshEventCache(ctx, event)
 
 	rs := "Success create event"
 	return &rs, nil
@@ -197,6 +229,15 @@ func (c commandUsecase) CreateOnlineTicketConfig(origCtx context.Context, payloa
 	})
 	defer span.End()
 
+	allowed, err := checkTeamAccess(ctx, c.organizerRepositoryQuery, payload.OrganizerId, "", payload.UserId,
+		payload.UserRole, editorRoles...)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.ForbiddenError("only the owners and editors of the organizer can configure its tags")
+	}
+
 	eventTagData := <-c.eventRepositoryQuery.FindEventByTag(ctx, payload.Tag)
 	if eventTagData.Error != nil {
 		return nil, eventTagData.Error
@@ -208,36 +249,1227 @@ func (c commandUsecase) CreateOnlineTicketConfig(origCtx context.Context, payloa
 			return nil, errors.InternalServerError("failed marshal event")
 		}
 
-		if payload.UserId != eventTag.CreatedBy {
-			return nil, errors.BadRequest("tag already exist, please create event with the same user to use this tag")
+		if !sameOwner(payload.OrganizerId, payload.UserId, eventTag.OrganizerId, eventTag.CreatedBy) {
+			return nil, errors.BadRequest("tag already exist, please create event with the same organizer to use this tag")
 		}
 	}
 
 	countryList := make([]ticketEntity.CountryList, 0)
+	countryIndex := make(map[int]bool)
 	totalPercentage := 0
 	for _, v := range payload.CountryList {
+		if countryIndex[v.CountryNumber] {
+			return nil, errors.BadRequest(fmt.Sprintf("country %d is listed more than once", v.CountryNumber))
+		}
+		countryIndex[v.CountryNumber] = true
 		totalPercentage = totalPercentage + v.Percentage
+
+		countryData := <-c.addressRepositoryQuery.FindOneCountryByNumber(ctx, v.CountryNumber)
+		if countryData.Error != nil {
+			return nil, countryData.Error
+		}
+
+		if countryData.Data == nil {
+			return nil, errors.BadRequest(fmt.Sprintf("country %d not found", v.CountryNumber))
+		}
+
+		country, ok := countryData.Data.(*addressEntity.Country)
+		if !ok {
+			return nil, errors.InternalServerError("failed marshal country")
+		}
+
 		countryList = append(countryList, ticketEntity.CountryList{
 			CountryNumber: v.CountryNumber,
 			Percentage:    v.Percentage,
+			CountryCode:   country.Code,
+			CountryName:   country.Name,
 		})
 	}
 	if totalPercentage != 100 {
 		return nil, errors.BadRequest("total percentage must be 100")
 	}
-	otConfig := ticketEntity.OnlineTicketConfig{
-		Tag:         payload.Tag,
-		TotalQuota:  payload.TotalQuota,
-		CountryList: countryList,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		CreatedBy:   payload.UserId,
-		UpdatedBy:   payload.UserId,
-	}
-	resp := <-c.ticketRepositoryCommand.UpsertOneOnlineTicketConfig(ctx, otConfig)
-	if resp.Error != nil {
-		return nil, eventTagData.Error
+	allocateCountryQuota(payload.TotalQuota, countryList)
+
+	// a new allocation keeps the tickets already sold per country and cannot go below them. The config is read from
+	// the master in the transaction snapshot, a sale counted after that makes the upsert fail with a write conflict, so
+	// the request is refused with a conflict and can be sent again.
+	err = c.eventRepositoryCommand.WithTransaction(ctx, func(txCtx context.Context) error {
+		configData := <-c.ticketRepositoryCommand.FindOnlineTicketConfigByTag(txCtx, payload.Tag)
+		if configData.Error != nil {
+			return configData.Error
+		}
+
+		createdAt, createdBy, version := time.Now(), payload.UserId, 1
+		if configData.Data != nil {
+			currentConfig, ok := configData.Data.(*ticketEntity.OnlineTicketConfig)
+			if !ok {
+				return errors.InternalServerError("failed marshal online ticket config")
+			}
+
+			if !sameOwner(payload.OrganizerId, payload.UserId, currentConfig.OrganizerId, currentConfig.CreatedBy) {
+				return errors.BadRequest("tag already exist, please create event with the same organizer to use this tag")
+			}
+			createdAt, createdBy, version = currentConfig.CreatedAt, currentConfig.CreatedBy, currentConfig.Version+1
+
+			sold := make(map[string]int)
+			for _, v := range currentConfig.CountryList {
+				sold[v.CountryCode] += v.Sold
+			}
+			for i, v := range countryList {
+				if v.Quota < sold[v.CountryCode] {
+					return errors.BadRequest(fmt.Sprintf("quota of country %s is below the %d tickets already sold",
+						v.CountryCode, sold[v.CountryCode]))
+				}
+				countryList[i].Sold = sold[v.CountryCode]
+				delete(sold, v.CountryCode)
+			}
+			for countryCode, v := range sold {
+				if v > 0 {
+					return errors.BadRequest(fmt.Sprintf("country %s already sold %d tickets and cannot be removed",
+						countryCode, v))
+				}
+			}
+		}
+
+		otConfig := ticketEntity.OnlineTicketConfig{
+			Tag:         payload.Tag,
+			OrganizerId: payload.OrganizerId,
+			Version:     version,
+			TotalQuota:  payload.TotalQuota,
+			CountryList: countryList,
+			CreatedAt:   createdAt,
+			UpdatedAt:   time.Now(),
+			CreatedBy:   createdBy,
+			UpdatedBy:   payload.UserId,
+		}
+		resp := <-c.ticketRepositoryCommand.UpsertOneOnlineTicketConfig(txCtx, otConfig)
+		if resp.Error != nil {
+			return resp.Error
+		}
+
+		respHistory := <-c.ticketRepositoryCommand.InsertOneOnlineTicketConfigHistory(txCtx,
+			newOnlineTicketConfigHistory(otConfig, constants.OnlineTicketConfigUpserted))
+		return respHistory.Error
+	})
+	if err != nil {
+		return nil, err
 	}
+
 	result := "Success create online ticket config"
 	return &result, nil
 }
+
+func (c commandUsecase) DeleteOnlineTicketConfig(origCtx context.Context, payload request.OnlineTicketConfigReq) (*string, error) {
+	domain := "eventUsecase-DeleteOnlineTicketConfig"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	config, err := findOnlineTicketConfig(ctx, c.ticketRepositoryQuery, payload.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkOnlineTicketConfigOwner(ctx, c.eventRepositoryQuery, c.organizerRepositoryQuery, config,
+		payload.UserId, payload.UserRole, editorRoles...); err != nil {
+		return nil, err
+	}
+
+	for _, v := range config.CountryList {
+		if v.Sold > 0 {
+			return nil, errors.Conflict("online ticket config already has sold tickets")
+		}
+	}
+
+	deleted := *config
+	deleted.Version++
+	deleted.UpdatedAt = time.Now()
+	deleted.UpdatedBy = payload.UserId
+	err = c.eventRepositoryCommand.WithTransaction(ctx, func(txCtx context.Context) error {
+		resp := <-c.ticketRepositoryCommand.DeleteOneOnlineTicketConfig(txCtx, payload.Tag)
+		if resp.Error != nil {
+			return resp.Error
+		}
+
+		// nothing deleted when a sale was counted since the config was read
+		if resp.Count == 0 {
+			return errors.Conflict("online ticket config already has sold tickets")
+		}
+
+		respHistory := <-c.ticketRepositoryCommand.InsertOneOnlineTicketConfigHistory(txCtx,
+			newOnlineTicketConfigHistory(deleted, constants.OnlineTicketConfigDeleted))
+		return respHistory.Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := "Success delete online ticket config"
+	return &result, nil
+}
+
+func (c commandUsecase) UpdateEvent(origCtx context.Context, payload request.UpdateEventReq) (*string, error) {
+	domain := "eventUsecase-UpdateEvent"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	eventData := <-c.eventRepositoryQuery.FindEventById(ctx, payload.EventId)
+	if eventData.Error != nil {
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal event")
+	}
+
+	allowed, err := canManageEvent(ctx, c.organizerRepositoryQuery, event, payload.UserId, payload.UserRole)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.ForbiddenError("only the event organizer or an admin can update this event")
+	}
+
+	if !isEventEditable(event) {
+		return nil, errors.Conflict(fmt.Sprintf("event with status '%s' cannot be updated", eventStatus(event)))
+	}
+	expected := eventVersion(event)
+
+	if payload.Name != "" && payload.Name != event.Name {
+		currentEvent := <-c.eventRepositoryQuery.FindEventByName(ctx, payload.Name)
+		if currentEvent.Error != nil {
+			return nil, currentEvent.Error
+		}
+
+		if currentEvent.Data != nil {
+			return nil, errors.BadRequest("event already exist")
+		}
+		event.Name = payload.Name
+	}
+
+	tagChanged := payload.Tag != "" && payload.Tag != event.Tag
+	var dateTime time.Time
+	if payload.DateTime != "" {
+		parsed, err := time.Parse(dateTimeLayout, payload.DateTime)
+		if err != nil {
+			return nil, errors.BadRequest("Format dateTime must be 'YYYY-MM-DD HH:MM'")
+		}
+		dateTime = parsed
+	}
+	dateTimeChanged := payload.DateTime != "" && !dateTime.Equal(event.DateTime)
+
+	if tagChanged || dateTimeChanged {
+		ticketData := <-c.ticketRepositoryQuery.FindTicketsByEventId(ctx, event.EventId)
+		if ticketData.Error != nil {
+			return nil, ticketData.Error
+		}
+
+		if ticketData.Data != nil {
+			tickets, ok := ticketData.Data.(*[]ticketEntity.Ticket)
+			if !ok {
+				return nil, errors.InternalServerError("failed marshal tickets")
+			}
+
+			for _, v := range *tickets {
+				if v.TotalRemaining < v.TotalQuota {
+					return nil, errors.Conflict("tag and dateTime cannot be changed after ticket sales have started")
+				}
+			}
+		}
+	}
+
+	if tagChanged {
+		eventTagData := <-c.eventRepositoryQuery.FindEventByTag(ctx, payload.Tag)
+		if eventTagData.Error != nil {
+			return nil, eventTagData.Error
+		}
+
+		if eventTagData.Data != nil {
+			eventTag, ok := eventTagData.Data.(*entity.Event)
+			if !ok {
+				return nil, errors.InternalServerError("failed marshal event")
+			}
+
+			if !sameOwner(event.OrganizerId, event.CreatedBy, eventTag.OrganizerId, eventTag.CreatedBy) {
+				return nil, errors.BadRequest("tag already exist, please create event with the same organizer to use this tag")
+			}
+		}
+		event.Tag = payload.Tag
+	}
+
+	if dateTimeChanged {
+		event.DateTime = dateTime
+	}
+
+	publishAt, salesStartAt, salesEndAt, err := parseSchedule(payload.PublishAt, payload.SalesStartAt, payload.SalesEndAt)
+	if err != nil {
+		return nil, err
+	}
+	if !publishAt.IsZero() {
+		event.PublishAt = publishAt
+	}
+	if !salesStartAt.IsZero() {
+		event.SalesStartAt = salesStartAt
+	}
+	if !salesEndAt.IsZero() {
+		event.SalesEndAt = salesEndAt
+	}
+
+	if err := validateSchedule(event.DateTime, event.PublishAt, event.SalesStartAt, event.SalesEndAt); err != nil {
+		return nil, err
+	}
+
+	locationChanged := payload.ContinentCode != "" || payload.Country != nil
+	if locationChanged {
+		if payload.Country == nil {
+			return nil, errors.BadRequest("country is required when continentCode is changed")
+		}
+
+		continentCode := helpers.CustomIfEmpty(payload.ContinentCode, event.ContinentCode)
+		continent, country, err := c.validateLocation(ctx, continentCode, payload.Country.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		event.ContinentName = continent.Name
+		event.ContinentCode = continent.Code
+		event.Country = entity.Country{
+			Name:  country.Name,
+			Code:  country.Code,
+			City:  payload.Country.City,
+			Place: payload.Country.Place,
+		}
+	}
+
+	event.Description = helpers.CustomIfEmpty(payload.Description, event.Description)
+	event.EventUrl = helpers.CustomIfEmpty(payload.EventUrl, event.EventUrl)
+	event.UpdatedBy = payload.UserId
+	event.UpdatedAt = time.Now()
+
+	// the tickets keep a copy of the tag and location of their event, both are written together
+	err = c.eventRepositoryCommand.WithTransaction(ctx, func(txCtx context.Context) error {
+		respEvent := <-c.eventRepositoryCommand.UpdateOneEventDetail(txCtx, *event, expected)
+		if errors.IsConflict(respEvent.Error) {
+			return errors.Conflict("event already exist")
+		}
+		if err := checkEventWrite(respEvent, "failed update event"); err != nil {
+			return err
+		}
+
+		if !tagChanged && !locationChanged {
+			return nil
+		}
+		respTicket := <-c.ticketRepositoryCommand.UpdateManyTicketEventInfo(txCtx, event.EventId, event.Tag, event.ContinentName,
+			event.ContinentCode, ticketEntity.Country{
+				Name:  event.Country.Name,
+				Code:  event.Country.Code,
+				City:  event.Country.City,
+				Place: event.Country.Place,
+			})
+		if respTicket.Error != nil {
+			return errors.InternalServerError("failed update tickets")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.refreshEventCache(ctx, *event)
+
+	rs := "Success update event"
+	return &rs, nil
+}
+
+func (c commandUsecase) PublishEvent(origCtx context.Context, payload request.PublishEventReq) (*string, error) {
+	domain := "eventUsecase-PublishEvent"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	eventData := <-c.eventRepositoryQuery.FindEventById(ctx, payload.EventId)
+	if eventData.Error != nil {
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal event")
+	}
+
+	allowed, err := canManageEvent(ctx, c.organizerRepositoryQuery, event, payload.UserId, payload.UserRole)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.ForbiddenError("only the event organizer or an admin can publish this event")
+	}
+
+	scheduled := event.PublishAt.After(time.Now())
+	target := constants.EventStatusPublished
+	if scheduled {
+		target = constants.EventStatusScheduled
+	}
+
+	if err := checkTransition(event, target); err != nil {
+		return nil, err
+	}
+
+	if err := validateEventCompleteness(event); err != nil {
+		return nil, err
+	}
+
+	if scheduled {
+		fromStatus := eventStatus(event)
+		expected := eventVersion(event)
+		event.Status = constants.EventStatusScheduled
+
+		event.UpdatedBy = payload.UserId
+		event.UpdatedAt = time.Now()
+		message := stateChangedMessage(event, fromStatus)
+		if err := c.updateEventStatus(ctx, event, expected, "failed schedule event", message); err != nil {
+			return nil, err
+		}
+		c.deliverOutbox(ctx, []outboxEntity.Outbox{message})
+
+		rs := fmt.Sprintf("Success schedule event, will be published at %s", event.PublishAt.Format(dateTimeLayout))
+		return &rs, nil
+	}
+
+	event.UpdatedBy = payload.UserId
+	undelivered, err := c.publishEvent(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if undelivered > 0 {
+		rs := fmt.Sprintf("Event published, %d of %d bank ticket messages failed to deliver and will be retried",
+			undelivered, len(event.TicketIds))
+		return &rs, nil
+	}
+
+	rs := "Success publish event"
+	return &rs, nil
+}
+
+func (c commandUsecase) CancelEvent(origCtx context.Context, payload request.CancelEventReq) (*string, error) {
+	domain := "eventUsecase-CancelEvent"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	eventData := <-c.eventRepositoryQuery.FindEventById(ctx, payload.EventId)
+	if eventData.Error != nil {
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal event")
+	}
+
+	allowed, err := canManageEvent(ctx, c.organizerRepositoryQuery, event, payload.UserId, payload.UserRole)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.ForbiddenError("only the event organizer or an admin can cancel this event")
+	}
+
+	expected := eventVersion(event)
+	if err := transitionEvent(event, constants.EventStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	cancelledAt := time.Now()
+	event.CancelReason = payload.Reason
+	event.CancelledAt = cancelledAt
+	event.CancelledBy = payload.UserId
+	event.UpdatedBy = payload.UserId
+	event.UpdatedAt = cancelledAt
+
+	eventCancelledReq := request.EventCancelledReq{
+		EventId:     event.EventId,
+		Tag:         event.Tag,
+		TicketIds:   event.TicketIds,
+		Reason:      payload.Reason,
+		CancelledBy: payload.UserId,
+		CancelledAt: cancelledAt,
+	}
+	marshaledKafkaData, _ := json.Marshal(eventCancelledReq)
+	// queued with the cancellation, the refunds downstream must not be lost when kafka is down
+	message := newOutboxMessage("event-cancelled", event.EventId, marshaledKafkaData, cancelledAt)
+	err = c.updateEventAndTickets(ctx, event, expected, false, "failed cancel event", message)
+	if err != nil {
+		return nil, err
+	}
+	c.deliverOutbox(ctx, []outboxEntity.Outbox{message})
+
+	rs := "Success cancel event"
+	return &rs, nil
+}
+
+func (c commandUsecase) AddTicketType(origCtx context.Context, payload request.AddTicketReq) (*string, error) {
+	domain := "eventUsecase-AddTicketType"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	eventData := <-c.eventRepositoryQuery.FindEventById(ctx, payload.EventId)
+	if eventData.Error != nil {
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal event")
+	}
+
+	allowed, err := canManageEvent(ctx, c.organizerRepositoryQuery, event, payload.UserId, payload.UserRole)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.ForbiddenError("only the event organizer or an admin can add tickets to this event")
+	}
+
+	if !isEventEditable(event) {
+		return nil, errors.Conflict(fmt.Sprintf("cannot add tickets to a %s event", eventStatus(event)))
+	}
+
+	if err := validateTicketType(payload.TicketType); err != nil {
+		return nil, err
+	}
+
+	_, salesStartAt, salesEndAt, err := parseSchedule("", payload.SalesStartAt, payload.SalesEndAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSchedule(event.DateTime, time.Time{}, salesStartAt, salesEndAt); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ticket := ticketEntity.Ticket{
+		TicketId:       uuid.New().String(),
+		EventId:        event.EventId,
+		TicketType:     payload.TicketType,
+		TicketPrice:    payload.TicketPrice,
+		TotalQuota:     payload.TotalQuota,
+		TotalRemaining: payload.TotalQuota,
+		Tag:            event.Tag,
+		Country: ticketEntity.Country{
+			Name:  event.Country.Name,
+			Code:  event.Country.Code,
+			City:  event.Country.City,
+			Place: event.Country.Place,
+		},
+		ContinentName:      event.ContinentName,
+		ContinentCode:      event.ContinentCode,
+		IsSellable:         eventStatus(event) == constants.EventStatusPublished,
+		ProvisioningStatus: constants.TicketProvisioningPending,
+		SalesStartAt:       salesStartAt,
+		SalesEndAt:         salesEndAt,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	// tickets of a draft event are sent to the bank on publish
+	messages := make([]outboxEntity.Outbox, 0)
+	if isBankRequested(event) {
+		messages = bankTicketMessages(event.EventId, []string{ticket.TicketId}, now)
+	}
+
+	var updatedEvent *entity.Event
+	err = c.eventRepositoryCommand.WithTransaction(ctx, func(txCtx context.Context) error {
+		respTicket := <-c.ticketRepositoryCommand.InsertOneTicketIfAbsent(txCtx, ticket)
+		if respTicket.Error != nil {
+			return respTicket.Error
+		}
+
+		if respTicket.Data != nil {
+			return errors.Conflict(fmt.Sprintf("ticket type '%s' already exist in this event", payload.TicketType))
+		}
+
+		respEvent := <-c.eventRepositoryCommand.AddEventTicketId(txCtx, event.EventId, ticket.TicketId, payload.UserId)
+		if respEvent.Error != nil {
+			return errors.InternalServerError("failed add ticket to event")
+		}
+
+		if respEvent.Data == nil {
+			return errors.NotFound("event not found")
+		}
+
+		updatedEvent, ok = respEvent.Data.(*entity.Event)
+		if !ok {
+			return errors.InternalServerError("failed marshal event")
+		}
+
+		if len(messages) > 0 {
+			respOutbox := <-c.outboxRepositoryCommand.InsertManyOutbox(txCtx, messages)
+			if respOutbox.Error != nil {
+				return errors.InternalServerError("failed save outbox message")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.refreshEventCache(ctx, *updatedEvent)
+
+	if undelivered := c.deliverOutbox(ctx, messages); undelivered > 0 {
+		rs := "Ticket type added, the bank ticket message failed to deliver and will be retried"
+		return &rs, nil
+	}
+
+	rs := "Success add ticket type"
+	return &rs, nil
+}
+
+func (c commandUsecase) UpdateTicket(origCtx context.Context, payload request.UpdateTicketReq) (*string, error) {
+	domain := "eventUsecase-UpdateTicket"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if payload.TicketPrice == nil && payload.TotalQuota == nil {
+		return nil, errors.BadRequest("ticketPrice or totalQuota is required")
+	}
+
+	eventData := <-c.eventRepositoryQuery.FindEventById(ctx, payload.EventId)
+	if eventData.Error != nil {
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal event")
+	}
+
+	allowed, err := canManageEvent(ctx, c.organizerRepositoryQuery, event, payload.UserId, payload.UserRole)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.ForbiddenError("only the event organizer or an admin can update tickets of this event")
+	}
+
+	if !isEventEditable(event) {
+		return nil, errors.Conflict(fmt.Sprintf("cannot update tickets of a %s event", eventStatus(event)))
+	}
+
+	ticketData := <-c.ticketRepositoryQuery.FindTicketsByEventId(ctx, event.EventId)
+	if ticketData.Error != nil {
+		return nil, ticketData.Error
+	}
+
+	var ticket *ticketEntity.Ticket
+	if ticketData.Data != nil {
+		tickets, ok := ticketData.Data.(*[]ticketEntity.Ticket)
+		if !ok {
+			return nil, errors.InternalServerError("failed marshal tickets")
+		}
+
+		for i := range *tickets {
+			if (*tickets)[i].TicketId == payload.TicketId {
+				ticket = &(*tickets)[i]
+				break
+			}
+		}
+	}
+
+	if ticket == nil {
+		return nil, errors.NotFound("ticket not found in event")
+	}
+
+	currentQuota, delta := ticket.TotalQuota, 0
+	if payload.TotalQuota != nil {
+		sold := ticket.TotalQuota - ticket.TotalRemaining
+		if *payload.TotalQuota < sold {
+			return nil, errors.BadRequest(fmt.Sprintf("totalQuota cannot be lower than the %d tickets already sold", sold))
+		}
+		delta = *payload.TotalQuota - ticket.TotalQuota
+	}
+
+	// redis holds the live stock once holds started and is not part of the transaction, which may also run its
+	// callback more than once. A lower quota is taken from redis first, it refuses to drop below the units held or
+	// sold there, and is given back when the transaction fails. A higher quota only reaches redis once committed.
+	stockLowered := false
+	if delta < 0 {
+		respStock := <-c.ticketRepositoryStock.AdjustStock(ctx, ticket.TicketId, delta)
+		if respStock.Error != nil {
+			return nil, respStock.Error
+		}
+		// nil when there is no counter yet, the next hold seeds it from mongodb
+		stockLowered = respStock.Data != nil
+	}
+
+	messages := make([]outboxEntity.Outbox, 0)
+	err = c.eventRepositoryCommand.WithTransaction(ctx, func(txCtx context.Context) error {
+		if payload.TicketPrice != nil {
+			respTicket := <-c.ticketRepositoryCommand.UpdateOneTicketPrice(txCtx, ticket.TicketId, *payload.TicketPrice)
+			if respTicket.Error != nil {
+				return respTicket.Error
+			}
+
+			if respTicket.Data == nil {
+				return errors.NotFound("ticket not found")
+			}
+			ticket.TicketPrice = *payload.TicketPrice
+		}
+
+		if delta != 0 {
+			respTicket := <-c.ticketRepositoryCommand.UpdateOneTicketQuota(txCtx, ticket.TicketId, currentQuota, delta)
+			if respTicket.Error != nil {
+				return respTicket.Error
+			}
+
+			// nil when the quota moved or tickets were sold since it was read
+			updated, ok := respTicket.Data.(*ticketEntity.Ticket)
+			if !ok || updated == nil {
+				return errors.Conflict("ticket quota changed while updating, please retry")
+			}
+			ticket.TotalQuota = updated.TotalQuota
+			ticket.TotalRemaining = updated.TotalRemaining
+		}
+
+		// the bank only needs resizing once it has been asked to create the tickets
+		if !isBankRequested(event) {
+			return nil
+		}
+
+		updateBankTicketReq := request.UpdateBankTicketReq{
+			TicketId:       ticket.TicketId,
+			EventId:        event.EventId,
+			TicketType:     ticket.TicketType,
+			TicketPrice:    ticket.TicketPrice,
+			TotalQuota:     ticket.TotalQuota,
+			TotalRemaining: ticket.TotalRemaining,
+			UpdatedAt:      time.Now(),
+		}
+		marshaledKafkaData, _ := json.Marshal(updateBankTicketReq)
+		messages = []outboxEntity.Outbox{
+			newOutboxMessage("concert-update-bank-ticket", event.EventId, marshaledKafkaData, updateBankTicketReq.UpdatedAt),
+		}
+		respOutbox := <-c.outboxRepositoryCommand.InsertManyOutbox(txCtx, messages)
+		if respOutbox.Error != nil {
+			return errors.InternalServerError("failed save outbox message")
+		}
+		return nil
+	})
+	if err != nil {
+		if stockLowered {
+			c.adjustTicketStock(ctx, ticket.TicketId, -delta)
+		}
+		return nil, err
+	}
+	if delta > 0 {
+		c.adjustTicketStock(ctx, ticket.TicketId, delta)
+	}
+
+	if undelivered := c.deliverOutbox(ctx, messages); undelivered > 0 {
+		rs := "Ticket updated, the bank ticket message failed to deliver and will be retried"
+		return &rs, nil
+	}
+
+	rs := "Success update ticket"
+	return &rs, nil
+}
+
+func (c commandUsecase) ReprovisionTickets(origCtx context.Context, payload request.ReprovisionTicketReq) (*string, error) {
+	domain := "eventUsecase-ReprovisionTickets"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	eventData := <-c.eventRepositoryQuery.FindEventById(ctx, payload.EventId)
+	if eventData.Error != nil {
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal event")
+	}
+
+	allowed, err := canManageEvent(ctx, c.organizerRepositoryQuery, event, payload.UserId, payload.UserRole)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.ForbiddenError("only the event organizer or an admin can re-provision tickets of this event")
+	}
+
+	if !isBankRequested(event) {
+		return nil, errors.Conflict(fmt.Sprintf("cannot re-provision tickets of a %s event", eventStatus(event)))
+	}
+
+	ticketData := <-c.ticketRepositoryQuery.FindTicketsByEventId(ctx, event.EventId)
+	if ticketData.Error != nil {
+		return nil, ticketData.Error
+	}
+
+	failedIds := make([]string, 0)
+	if ticketData.Data != nil {
+		tickets, ok := ticketData.Data.(*[]ticketEntity.Ticket)
+		if !ok {
+			return nil, errors.InternalServerError("failed marshal tickets")
+		}
+
+		for _, v := range *tickets {
+			if v.ProvisioningStatus == constants.TicketProvisioningFailed {
+				failedIds = append(failedIds, v.TicketId)
+			}
+		}
+	}
+
+	if len(failedIds) == 0 {
+		return nil, errors.BadRequest("no failed tickets to re-provision")
+	}
+
+	messages := bankTicketMessages(event.EventId, failedIds, time.Now())
+	err = c.eventRepositoryCommand.WithTransaction(ctx, func(txCtx context.Context) error {
+		for _, ticketId := range failedIds {
+			respTicket := <-c.ticketRepositoryCommand.UpdateOneTicketProvisioning(txCtx, ticketId, constants.TicketProvisioningPending, "")
+			if respTicket.Error != nil {
+				return respTicket.Error
+			}
+		}
+
+		respOutbox := <-c.outboxRepositoryCommand.InsertManyOutbox(txCtx, messages)
+		if respOutbox.Error != nil {
+			return errors.InternalServerError("failed save outbox message")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	undelivered := c.deliverOutbox(ctx, messages)
+	if undelivered > 0 {
+		rs := fmt.Sprintf("Tickets re-provisioned, %d of %d bank ticket messages failed to deliver and will be retried",
+			undelivered, len(messages))
+		return &rs, nil
+	}
+
+	rs := fmt.Sprintf("Success re-provision %d tickets", len(failedIds))
+	return &rs, nil
+}
+
+func (c commandUsecase) ProcessScheduledEvents(origCtx context.Context) error {
+	domain := "eventUsecase-ProcessScheduledEvents"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	now := time.Now()
+	steps := []struct {
+		status string
+		field  string
+		apply  func(ctx context.Context, event *entity.Event) error
+	}{
+		{constants.EventStatusScheduled, "publishAt", func(ctx context.Context, event *entity.Event) error {
+			_, err := c.publishEvent(ctx, event)
+			return err
+		}},
+		{constants.EventStatusPublished, "salesEndAt", c.closeEventSales},
+		{constants.EventStatusPublished, "dateTime", c.closeEventSales},
+		{constants.EventStatusSalesClosed, "dateTime", c.finishEvent},
+	}
+
+	for _, step := range steps {
+		eventData := <-c.eventRepositoryQuery.FindEventsByStatusBefore(ctx, step.status, step.field, now)
+		if eventData.Error != nil {
+			return eventData.Error
+		}
+
+		if eventData.Data == nil {
+			continue
+		}
+
+		events, ok := eventData.Data.(*[]entity.Event)
+		if !ok {
+			return errors.InternalServerError("failed marshal events")
+		}
+
+		for i := range *events {
+			event := &(*events)[i]
+			if err := step.apply(ctx, event); err != nil {
+				c.logger.Error(ctx, fmt.Sprintf("Failed process scheduled event, eventId : %s", event.EventId), err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c commandUsecase) AcknowledgeBankTicket(origCtx context.Context, payload request.BankTicketCreatedReq) error {
+	domain := "eventUsecase-AcknowledgeBankTicket"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if _, err := c.findEventForAck(ctx, payload.EventId, payload.TicketId); err != nil {
+		return err
+	}
+
+	status := constants.TicketProvisioningProvisioned
+	reason := ""
+	if payload.Status == constants.TicketProvisioningFailed {
+		status = constants.TicketProvisioningFailed
+		reason = payload.Reason
+	}
+
+	respTicket := <-c.ticketRepositoryCommand.UpdateOneTicketProvisioning(ctx, payload.TicketId, status, reason)
+	if respTicket.Error != nil {
+		return respTicket.Error
+	}
+
+	c.logger.Info(ctx, fmt.Sprintf("Receive kafka bank ticket created, ticketId : %s", payload.TicketId), fmt.Sprintf("%+v", payload))
+	return nil
+}
+
+// AcknowledgeOrder settles the hold an order was placed on: a paid order confirms it, a cancelled or expired one gives
+// its units back. A hold already settled or expired is skipped, the same acknowledgement may be read twice.
+func (c commandUsecase) AcknowledgeOrder(origCtx context.Context, payload request.OrderAckReq) error {
+	domain := "eventUsecase-AcknowledgeOrder"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if _, err := c.findEventForAck(ctx, payload.EventId, payload.TicketId); err != nil {
+		return err
+	}
+
+	var err error
+	if payload.Status == constants.OrderStatusPaid {
+		err = c.confirmHold(ctx, payload.TicketId, payload.HoldId, payload.UserId)
+	} else {
+		respHold := <-c.ticketRepositoryStock.ReleaseHold(ctx, payload.TicketId, payload.HoldId, payload.UserId, time.Now())
+		err = respHold.Error
+	}
+	if errors.IsNotFound(err) {
+		c.logger.Info(ctx, fmt.Sprintf("Skip kafka order ack, orderId : %s", payload.OrderId), err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info(ctx, fmt.Sprintf("Receive kafka order ack, orderId : %s", payload.OrderId), fmt.Sprintf("%+v", payload))
+	return nil
+}
+
+// findEventForAck loads the event an acknowledgement refers to and checks the ticket belongs to it.
+func (c commandUsecase) findEventForAck(ctx context.Context, eventId string, ticketId string) (*entity.Event, error) {
+	eventData := <-c.eventRepositoryQuery.FindEventById(ctx, eventId)
+	if eventData.Error != nil {
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal event")
+	}
+
+	for _, id := range event.TicketIds {
+		if id == ticketId {
+			return event, nil
+		}
+	}
+	return nil, errors.NotFound("ticket not found in event")
+}
+
+// publishEvent makes the tickets sellable, marks the event as published and asks the bank service to create the tickets.
+// The bank messages are stored in the outbox with the event and delivered right away, it returns how many of them are
+// left for the relay to retry.
+func (c commandUsecase) publishEvent(ctx context.Context, event *entity.Event) (int, error) {
+	fromStatus := eventStatus(event)
+	expected := eventVersion(event)
+	if err := transitionEvent(event, constants.EventStatusPublished); err != nil {
+		return 0, err
+	}
+
+	event.UpdatedAt = time.Now()
+	messages := bankTicketMessages(event.EventId, event.TicketIds, event.UpdatedAt)
+	stateChanged := stateChangedMessage(event, fromStatus)
+	if err := c.updateEventAndTickets(ctx, event, expected, true, "failed publish event", append(messages, stateChanged)...); err != nil {
+		return 0, err
+	}
+	c.deliverOutbox(ctx, []outboxEntity.Outbox{stateChanged})
+
+	return c.deliverOutbox(ctx, messages), nil
+}
+
+// deliverOutbox publishes freshly stored outbox messages and marks the delivered ones as sent. Failed messages stay
+// pending for the relay. It returns the number of messages that were not delivered.
+func (c commandUsecase) deliverOutbox(ctx context.Context, messages []outboxEntity.Outbox) int {
+	if len(messages) == 0 {
+		return 0
+	}
+
+	deliveryCtx, cancel := context.WithTimeout(ctx, outboxDeliveryTimeout)
+	defer cancel()
+
+	batch := make([]kafkaConfluent.Message, 0, len(messages))
+	for _, message := range messages {
+		batch = append(batch, kafkaConfluent.Message{
+			Topic: message.Topic,
+			Key:   []byte(message.Key),
+			Value: []byte(message.Payload),
+		})
+	}
+	errs := c.kafkaProducer.PublishBatchSync(deliveryCtx, batch)
+
+	undelivered := 0
+	for i, message := range messages {
+		if errs[i] != nil {
+			undelivered++
+			c.logger.Error(ctx, fmt.Sprintf("Failed send kafka %s, outboxId : %s", message.Topic, message.OutboxId), errs[i].Error())
+			continue
+		}
+
+		now := time.Now()
+		message.Status = constants.OutboxStatusSent
+		message.SentAt = now
+		message.UpdatedAt = now
+		respOutbox := <-c.outboxRepositoryCommand.UpdateOneOutbox(ctx, message)
+		if respOutbox.Error != nil {
+			// still pending, so the relay will send it again
+			c.logger.Error(ctx, fmt.Sprintf("Failed mark outbox sent, outboxId : %s", message.OutboxId), respOutbox.Error.Error())
+		}
+	}
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka %s, eventId : %s", messages[0].Topic, messages[0].Key),
+		fmt.Sprintf("delivered %d of %d", len(messages)-undelivered, len(messages)))
+
+	return undelivered
+}
+
+func (c commandUsecase) closeEventSales(ctx context.Context, event *entity.Event) error {
+	fromStatus := eventStatus(event)
+	expected := eventVersion(event)
+	if err := transitionEvent(event, constants.EventStatusSalesClosed); err != nil {
+		return err
+	}
+
+	event.UpdatedAt = time.Now()
+	message := stateChangedMessage(event, fromStatus)
+	if err := c.updateEventAndTickets(ctx, event, expected, false, "failed close event sales", message); err != nil {
+		return err
+	}
+	c.deliverOutbox(ctx, []outboxEntity.Outbox{message})
+
+	return nil
+}
+
+func (c commandUsecase) finishEvent(ctx context.Context, event *entity.Event) error {
+	fromStatus := eventStatus(event)
+	expected := eventVersion(event)
+	if err := transitionEvent(event, constants.EventStatusFinished); err != nil {
+		return err
+	}
+
+	event.UpdatedAt = time.Now()
+	message := stateChangedMessage(event, fromStatus)
+	if err := c.updateEventStatus(ctx, event, expected, "failed finish event", message); err != nil {
+		return err
+	}
+	c.deliverOutbox(ctx, []outboxEntity.Outbox{message})
+
+	return nil
+}
+
+// updateEventAndTickets saves the event status, flips isSellable on its tickets and queues the outbox messages in one
+// transaction. Nothing is written when the event no longer has the expected version.
+func (c commandUsecase) updateEventAndTickets(ctx context.Context, event *entity.Event, expected entity.EventVersion,
+	isSellable bool, failedMsg string, messages ...outboxEntity.Outbox) error {
+	err := c.eventRepositoryCommand.WithTransaction(ctx, func(txCtx context.Context) error {
+		respTicket := <-c.ticketRepositoryCommand.UpdateManyTicketSellable(txCtx, event.EventId, isSellable)
+		if respTicket.Error != nil {
+			return respTicket.Error
+		}
+		return c.saveEventStatus(txCtx, event, expected, failedMsg, messages)
+	})
+	if err != nil {
+		return err
+	}
+	c.refreshEventCache(ctx, *event)
+	return nil
+}
+
+// updateEventStatus saves the event status and queues the outbox messages in one transaction.
+func (c commandUsecase) updateEventStatus(ctx context.Context, event *entity.Event, expected entity.EventVersion,
+	failedMsg string, messages ...outboxEntity.Outbox) error {
+	err := c.eventRepositoryCommand.WithTransaction(ctx, func(txCtx context.Context) error {
+		return c.saveEventStatus(txCtx, event, expected, failedMsg, messages)
+	})
+	if err != nil {
+		return err
+	}
+	c.refreshEventCache(ctx, *event)
+	return nil
+}
+
+func (c commandUsecase) saveEventStatus(txCtx context.Context, event *entity.Event, expected entity.EventVersion,
+	failedMsg string, messages []outboxEntity.Outbox) error {
+	respEvent := <-c.eventRepositoryCommand.UpdateOneEventStatus(txCtx, *event, expected)
+	if err := checkEventWrite(respEvent, failedMsg); err != nil {
+		return err
+	}
+
+	if len(messages) > 0 {
+		respOutbox := <-c.outboxRepositoryCommand.InsertManyOutbox(txCtx, messages)
+		if respOutbox.Error != nil {
+			return errors.InternalServerError("failed save outbox message")
+		}
+	}
+	return nil
+}
+
+// refreshEventCache caches the event as it was just written and drops the cached lists. A failure is only logged, the
+// cache then serves the old data until its ttl expires.
+func (c commandUsecase) refreshEventCache(ctx context.Context, event entity.Event) {
+	if resp := <-c.eventRepositoryCache.RefreshEvent(ctx, event); resp.Error != nil {
+		c.logger.Error(ctx, fmt.Sprintf("Error refresh event cache, eventId : %s", event.EventId), fmt.Sprintf("%+v", resp.Error))
+	}
+}
+
+// adjustTicketStock moves the redis stock of a ticket once the quota change is settled in mongodb. A failure is only
+// logged, there is no transaction left to roll back.
+func (c commandUsecase) adjustTicketStock(ctx context.Context, ticketId string, delta int) {
+	if resp := <-c.ticketRepositoryStock.AdjustStock(ctx, ticketId, delta); resp.Error != nil {
+		c.logger.Error(ctx, fmt.Sprintf("Error adjust ticket stock, ticketId : %s", ticketId), fmt.Sprintf("%+v", resp.Error))
+	}
+}
+
+// bankTicketMessages builds the outbox messages asking the bank service to create the given tickets.
+func bankTicketMessages(eventId string, ticketIds []string, now time.Time) []outboxEntity.Outbox {
+	messages := make([]outboxEntity.Outbox, 0)
+	for _, ticketId := range ticketIds {
+		createTicketReq := request.CreateTicketReq{
+			TicketId: ticketId,
+			EventId:  eventId,
+		}
+		marshaledKafkaData, _ := json.Marshal(createTicketReq)
+		// keyed by event so every ticket of one event lands on the same partition
+		messages = append(messages, newOutboxMessage("concert-create-bank-ticket", eventId, marshaledKafkaData, now))
+	}
+	return messages
+}
+
+func newOutboxMessage(topic string, key string, payload []byte, now time.Time) outboxEntity.Outbox {
+	return outboxEntity.Outbox{
+		OutboxId:      uuid.New().String(),
+		Topic:         topic,
+		Key:           key,
+		Payload:       string(payload),
+		Status:        constants.OutboxStatusPending,
+		NextAttemptAt: now.Add(outboxDeliveryLease),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// stateChangedMessage builds the outbox message announcing the status change of the event.
+func stateChangedMessage(event *entity.Event, fromStatus string) outboxEntity.Outbox {
+	stateChangedReq := request.EventStateChangedReq{
+		EventId:    event.EventId,
+		Tag:        event.Tag,
+		FromStatus: fromStatus,
+		ToStatus:   event.Status,
+		ChangedAt:  event.UpdatedAt,
+	}
+	marshaledKafkaData, _ := json.Marshal(stateChangedReq)
+	return newOutboxMessage("event-state-changed", event.EventId, marshaledKafkaData, event.UpdatedAt)
+}
+
+func validateTicketType(ticketType string) error {
+	if ticketType != constants.Bronze && ticketType != constants.Gold && ticketType != constants.Silver &&
+		ticketType != constants.Wood && ticketType != constants.Online {
+		return errors.BadRequest("ticketType must be 'Online' or 'Wood' or 'Gold' or 'Bronze' or 'Silver'")
+	}
+	return nil
+}
+
+func (c commandUsecase) validateLocation(ctx context.Context, continentCode string, countryId int) (*addressEntity.Continent, *addressEntity.Country, error) {
+	continentData := <-c.addressRepositoryQuery.FindOneContinentByCode(ctx, continentCode)
+	if continentData.Error != nil {
+		return nil, nil, continentData.Error
+	}
+
+	if continentData.Data == nil {
+		return nil, nil, errors.BadRequest("continent not found")
+	}
+
+	continent, ok := continentData.Data.(*addressEntity.Continent)
+	if !ok {
+		return nil, nil, errors.InternalServerError("failed marshal continentData")
+	}
+
+	countryData := <-c.addressRepositoryQuery.FindOneCountry(ctx, countryId)
+	if countryData.Error != nil {
+		return nil, nil, countryData.Error
+	}
+
+	if countryData.Data == nil {
+		return nil, nil, errors.BadRequest("country not found")
+	}
+
+	country, ok := countryData.Data.(*addressEntity.Country)
+	if !ok {
+		return nil, nil, errors.InternalServerError("failed marshal countryData")
+	}
+
+	return continent, country, nil
+}
@@ -5,14 +5,22 @@ import (
 	"event-service/internal/modules/event"
 	"event-service/internal/pkg/errors"
 	"event-service/internal/pkg/helpers"
+	"net/http"
 	"testing"
+	"time"
 
 	addressEntity "event-service/internal/modules/address/models/entity"
 	eventEntity "event-service/internal/modules/event/models/entity"
 	"event-service/internal/modules/event/models/request"
 	uc "event-service/internal/modules/event/usecases"
+	outboxEntity "event-service/internal/modules/outbox/models/entity"
+	ticketEntity "event-service/internal/modules/ticket/models/entity"
+	"event-service/internal/pkg/constants"
+	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
 	mockcertAddress "event-service/mocks/modules/address"
 	mockcert "event-service/mocks/modules/event"
+	mockcertOrganizer "event-service/mocks/modules/organizer"
+	mockcertOutbox "event-service/mocks/modules/outbox"
 	mockcertTicket "event-service/mocks/modules/ticket"
 	mockkafka "event-service/mocks/pkg/kafka"
 	mocklog "event-service/mocks/pkg/log"
@@ -24,40 +32,74 @@ import (
 
 type CommandUsecaseTestSuite struct {
 	suite.Suite
-	mockEventRepositoryQuery    *mockcert.MongodbRepositoryQuery
-	mockEventRepositoryCommand  *mockcert.MongodbRepositoryCommand
-	mockTicketRepositoryCommand *mockcertTicket.MongodbRepositoryCommand
-	mockAddressRepositoryQuery  *mockcertAddress.MongodbRepositoryQuery
-	mockLogger                  *mocklog.Logger
-	mockKafkaProducer           *mockkafka.Producer
-	usecase                     event.UsecaseCommand
-	ctx                         context.Context
+	mockEventRepositoryQuery     *mockcert.MongodbRepositoryQuery
+	mockEventRepositoryCommand   *mockcert.MongodbRepositoryCommand
+	mockEventRepositoryCache     *mockcert.RedisRepositoryCache
+	mockTicketRepositoryQuery    *mockcertTicket.MongodbRepositoryQuery
+	mockTicketRepositoryCommand  *mockcertTicket.MongodbRepositoryCommand
+	mockTicketRepositoryStock    *mockcertTicket.RedisRepositoryStock
+	mockAddressRepositoryQuery   *mockcertAddress.MongodbRepositoryQuery
+	mockOutboxRepositoryCommand  *mockcertOutbox.MongodbRepositoryCommand
+	mockOrganizerRepositoryQuery *mockcertOrganizer.MongodbRepositoryQuery
+	mockLogger                   *mocklog.Logger
+	mockKafkaProducer            *mockkafka.Producer
+	usecase                      event.UsecaseCommand
+	ctx                          context.Context
 }
 
 func (suite *CommandUsecaseTestSuite) SetupTest() {
 	suite.mockEventRepositoryQuery = &mockcert.MongodbRepositoryQuery{}
 	suite.mockEventRepositoryCommand = &mockcert.MongodbRepositoryCommand{}
+	suite.mockEventRepositoryCache = &mockcert.RedisRepositoryCache{}
+	suite.mockTicketRepositoryQuery = &mockcertTicket.MongodbRepositoryQuery{}
 	suite.mockTicketRepositoryCommand = &mockcertTicket.MongodbRepositoryCommand{}
+	suite.mockTicketRepositoryStock = &mockcertTicket.RedisRepositoryStock{}
 	suite.mockAddressRepositoryQuery = &mockcertAddress.MongodbRepositoryQuery{}
+	suite.mockOutboxRepositoryCommand = &mockcertOutbox.MongodbRepositoryCommand{}
+	suite.mockOrganizerRepositoryQuery = &mockcertOrganizer.MongodbRepositoryQuery{}
 	suite.mockLogger = &mocklog.Logger{}
 	suite.mockKafkaProducer = &mockkafka.Producer{}
 	suite.ctx = context.Background()
+	// run the transaction body inline, as mongodb does once the session is started
+	suite.mockEventRepositoryCommand.On("WithTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(txCtx context.Context) error) error {
+		return fn(ctx)
+	})
+	suite.mockEventRepositoryCache.On("RefreshEvent", mock.Anything, mock.Anything).Return(func(ctx context.Context, event eventEntity.Event) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: event.EventId})
+	}).Maybe()
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(func(ctx context.Context, organizerId string) <-chan helpers.Result {
+		return mockChannel(mockOrganizer)
+	}).Maybe()
 	suite.usecase = uc.NewCommandUsecase(
 		suite.mockEventRepositoryQuery,
 		suite.mockEventRepositoryCommand,
+		suite.mockEventRepositoryCache,
+		suite.mockTicketRepositoryQuery,
 		suite.mockTicketRepositoryCommand,
+		suite.mockTicketRepositoryStock,
 		suite.mockAddressRepositoryQuery,
+		suite.mockOutboxRepositoryCommand,
+		suite.mockOrganizerRepositoryQuery,
 		suite.mockKafkaProducer,
 		suite.mockLogger,
 	)
 }
 
+var mockCountryByNumber = helpers.Result{
+	Data: &addressEntity.Country{
+		Code:   "ID",
+		Name:   "Indonesia",
+		Number: 1,
+	},
+}
+
 func TestCommandUsecaseTestSuite(t *testing.T) {
 	suite.Run(t, new(CommandUsecaseTestSuite))
 }
 
 func (suite *CommandUsecaseTestSuite) TestCreateEvent() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -126,6 +168,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEvent() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrEventName() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -152,6 +195,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrEventName() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventExistEventName() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -180,6 +224,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventExistEventName() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrEventTag() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -221,6 +266,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrEventTag() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventByTagErr() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -255,6 +301,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventByTagErr() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrUserId() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -296,6 +343,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrUserId() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrDate() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "09-02-2024T15:04",
 		ContinentCode: "code",
@@ -337,6 +385,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrDate() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrContinent() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -378,11 +427,12 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrContinent() {
 	suite.mockAddressRepositoryQuery.On("FindOneContinentByCode", mock.Anything, mock.Anything).Return(mockChannel(mockContinentByCode))
 
 	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
-	assert.NoError(suite.T(), err)
+	assert.Error(suite.T(), err)
 }
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrContinentNil() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -429,6 +479,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrContinentNil() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrContinentParse() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -478,6 +529,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrContinentParse() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrCountry() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -529,11 +581,12 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrCountry() {
 	suite.mockAddressRepositoryQuery.On("FindOneCountry", mock.Anything, mock.Anything).Return(mockChannel(mockCountry))
 
 	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
-	assert.NoError(suite.T(), err)
+	assert.Error(suite.T(), err)
 }
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrCountryNil() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -590,6 +643,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrCountryNil() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrCountryParse() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -648,6 +702,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrCountryParse() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrInsertTicket() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -716,6 +771,7 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrInsertTicket() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrInsertEvent() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -786,11 +842,60 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrInsertEvent() {
 	assert.Error(suite.T(), err)
 }
 
+func (suite *CommandUsecaseTestSuite) TestCreateEventErrInsertEventDuplicateName() {
+	payload := request.EventReq{
+		OrganizerId:   "organizerId",
+		UserId:        "userId",
+		Name:          "name",
+		DateTime:      "2024-09-02 15:04",
+		ContinentCode: "code",
+		Tickets: []request.Ticket{
+			{
+				TicketType:  "Gold",
+				TicketPrice: 50,
+				TotalQuota:  10,
+				Tag:         "tag",
+			},
+		},
+	}
+
+	mockContinentByCode := helpers.Result{
+		Data: &addressEntity.Continent{
+			Code: "code",
+			Name: "name",
+		},
+	}
+
+	mockCountry := helpers.Result{
+		Data: &addressEntity.Country{
+			Id:   1,
+			Code: "code",
+			Name: "name",
+		},
+	}
+
+	// a concurrent create passed the name check first
+	suite.mockEventRepositoryQuery.On("FindEventByName", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockAddressRepositoryQuery.On("FindOneContinentByCode", mock.Anything, mock.Anything).Return(mockChannel(mockContinentByCode))
+	suite.mockAddressRepositoryQuery.On("FindOneCountry", mock.Anything, mock.Anything).Return(mockChannel(mockCountry))
+	suite.mockTicketRepositoryCommand.On("InsertManyTicketCollection", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("InsertOneEventCollection", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.Conflict("Duplicate data")}))
+
+	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	assert.Equal(suite.T(), "event already exist", errString.Error())
+}
+
 func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfig() {
 	payload := request.OnlineTicketReq{
-		UserId:     "userId",
-		Tag:        "tag",
-		TotalQuota: 10,
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
 		CountryList: []request.CountryList{
 			{
 				CountryNumber: 1,
@@ -818,7 +923,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfig() {
 		Error: nil,
 	}
 	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, mock.Anything).Return(mockChannel(mockEventByTag))
+	suite.mockAddressRepositoryQuery.On("FindOneCountryByNumber", mock.Anything, 1).Return(mockChannel(mockCountryByNumber))
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
 	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything).Return(mockChannel(mockUpsertOnlineTicket))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
 
 	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
 	assert.NoError(suite.T(), err)
@@ -826,9 +934,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfig() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErr() {
 	payload := request.OnlineTicketReq{
-		UserId:     "userId",
-		Tag:        "tag",
-		TotalQuota: 10,
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
 		CountryList: []request.CountryList{
 			{
 				CountryNumber: 1,
@@ -847,7 +956,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErr() {
 		Error: nil,
 	}
 	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, mock.Anything).Return(mockChannel(mockEventByTag))
+	suite.mockAddressRepositoryQuery.On("FindOneCountryByNumber", mock.Anything, 1).Return(mockChannel(mockCountryByNumber))
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
 	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything).Return(mockChannel(mockUpsertOnlineTicket))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
 
 	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
 	assert.Error(suite.T(), err)
@@ -855,9 +967,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErr() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrParse() {
 	payload := request.OnlineTicketReq{
-		UserId:     "userId",
-		Tag:        "tag",
-		TotalQuota: 10,
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
 		CountryList: []request.CountryList{
 			{
 				CountryNumber: 1,
@@ -878,7 +991,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrParse() {
 		Error: nil,
 	}
 	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, mock.Anything).Return(mockChannel(mockEventByTag))
+	suite.mockAddressRepositoryQuery.On("FindOneCountryByNumber", mock.Anything, 1).Return(mockChannel(mockCountryByNumber))
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
 	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything).Return(mockChannel(mockUpsertOnlineTicket))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
 
 	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
 	assert.Error(suite.T(), err)
@@ -886,9 +1002,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrParse() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrUser() {
 	payload := request.OnlineTicketReq{
-		UserId:     "tesId",
-		Tag:        "tag",
-		TotalQuota: 10,
+		OrganizerId: "organizerId",
+		UserId:      "tesId",
+		Tag:         "tag",
+		TotalQuota:  10,
 		CountryList: []request.CountryList{
 			{
 				CountryNumber: 1,
@@ -916,7 +1033,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrUser() {
 		Error: nil,
 	}
 	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, mock.Anything).Return(mockChannel(mockEventByTag))
+	suite.mockAddressRepositoryQuery.On("FindOneCountryByNumber", mock.Anything, 1).Return(mockChannel(mockCountryByNumber))
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
 	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything).Return(mockChannel(mockUpsertOnlineTicket))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
 
 	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
 	assert.Error(suite.T(), err)
@@ -924,9 +1044,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrUser() {
 
 func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrPercentage() {
 	payload := request.OnlineTicketReq{
-		UserId:     "userId",
-		Tag:        "tag",
-		TotalQuota: 10,
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
 		CountryList: []request.CountryList{
 			{
 				CountryNumber: 1,
@@ -954,7 +1075,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrPercentage(
 		Error: nil,
 	}
 	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, mock.Anything).Return(mockChannel(mockEventByTag))
+	suite.mockAddressRepositoryQuery.On("FindOneCountryByNumber", mock.Anything, 1).Return(mockChannel(mockCountryByNumber))
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
 	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything).Return(mockChannel(mockUpsertOnlineTicket))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
 
 	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
 	assert.Error(suite.T(), err)
@@ -962,9 +1086,10 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrPercentage(
 
 func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrUpsert() {
 	payload := request.OnlineTicketReq{
-		UserId:     "userId",
-		Tag:        "tag",
-		TotalQuota: 10,
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
 		CountryList: []request.CountryList{
 			{
 				CountryNumber: 1,
@@ -992,14 +1117,18 @@ func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrUpsert() {
 		Error: errors.BadRequest("error"),
 	}
 	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, mock.Anything).Return(mockChannel(mockEventByTag))
+	suite.mockAddressRepositoryQuery.On("FindOneCountryByNumber", mock.Anything, 1).Return(mockChannel(mockCountryByNumber))
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
 	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything).Return(mockChannel(mockUpsertOnlineTicket))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
 
 	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
-	assert.NoError(suite.T(), err)
+	assert.Error(suite.T(), err)
 }
 
 func (suite *CommandUsecaseTestSuite) TestCreateEventErrTicketType() {
 	payload := request.EventReq{
+		OrganizerId:   "organizerId",
 		UserId:        "userId",
 		DateTime:      "2024-09-02 15:04",
 		ContinentCode: "code",
@@ -1065,3 +1194,2353 @@ func (suite *CommandUsecaseTestSuite) TestCreateEventErrTicketType() {
 	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
 	assert.Error(suite.T(), err)
 }
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEvent() {
+	payload := request.UpdateEventReq{
+		EventId:       "id",
+		Name:          "new name",
+		DateTime:      "2024-10-02 15:04",
+		ContinentCode: "code",
+		Country: &request.Country{
+			Id:    1,
+			City:  "city",
+			Place: "place",
+		},
+		Tag:    "new tag",
+		UserId: "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Name:      "name",
+			Tag:       "tag",
+			CreatedBy: "userId",
+		},
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{
+				TicketId:       "ticketId",
+				TotalQuota:     10,
+				TotalRemaining: 10,
+			},
+		},
+	}
+	mockContinentByCode := helpers.Result{
+		Data: &addressEntity.Continent{
+			Code: "code",
+			Name: "name",
+		},
+	}
+	mockCountry := helpers.Result{
+		Data: &addressEntity.Country{
+			Id:   1,
+			Code: "code",
+			Name: "name",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockEventRepositoryQuery.On("FindEventByName", mock.Anything, "new name").Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, "new tag").Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+	suite.mockAddressRepositoryQuery.On("FindOneContinentByCode", mock.Anything, "code").Return(mockChannel(mockContinentByCode))
+	suite.mockAddressRepositoryQuery.On("FindOneCountry", mock.Anything, 1).Return(mockChannel(mockCountry))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventDetail", mock.Anything, mock.MatchedBy(func(e eventEntity.Event) bool {
+		return e.Name == "new name" && e.Tag == "new tag" && e.UpdatedBy == "userId"
+	}), mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketEventInfo", mock.Anything, "id", "new tag", "name", "code",
+		ticketEntity.Country{Name: "name", Code: "code", City: "city", Place: "place"}).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	suite.mockEventRepositoryCommand.AssertCalled(suite.T(), "WithTransaction", mock.Anything, mock.Anything)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "UpdateManyTicketEventInfo", mock.Anything, "id", "new tag",
+		"name", "code", mock.Anything)
+	suite.mockEventRepositoryCache.AssertCalled(suite.T(), "RefreshEvent", mock.Anything, mock.MatchedBy(func(event eventEntity.Event) bool {
+		return event.EventId == "id" && event.Tag == "new tag"
+	}))
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrUpdateTickets() {
+	payload := request.UpdateEventReq{
+		EventId: "id",
+		Tag:     "new tag",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Tag:       "tag",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, "new tag").Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(helpers.Result{Data: &[]ticketEntity.Ticket{}}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventDetail", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketEventInfo", mock.Anything, "id", "new tag", mock.Anything, mock.Anything,
+		mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	suite.mockEventRepositoryCache.AssertNotCalled(suite.T(), "RefreshEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventByAdmin() {
+	payload := request.UpdateEventReq{
+		EventId:     "id",
+		Description: "desc",
+		UserId:      "adminId",
+		UserRole:    constants.RoleAdmin,
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventDetail", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrFind() {
+	payload := request.UpdateEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrNotFound() {
+	payload := request.UpdateEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrForbidden() {
+	payload := request.UpdateEventReq{
+		EventId: "id",
+		UserId:  "otherUser",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrNameExist() {
+	payload := request.UpdateEventReq{
+		EventId: "id",
+		Name:    "taken",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Name:      "name",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockEventRepositoryQuery.On("FindEventByName", mock.Anything, "taken").Return(mockChannel(helpers.Result{Data: &eventEntity.Event{EventId: "other"}}))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrTicketSold() {
+	payload := request.UpdateEventReq{
+		EventId:  "id",
+		DateTime: "2024-10-02 15:04",
+		UserId:   "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			CreatedBy: "userId",
+		},
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{
+				TicketId:       "ticketId",
+				TotalQuota:     10,
+				TotalRemaining: 9,
+			},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrDate() {
+	payload := request.UpdateEventReq{
+		EventId:  "id",
+		DateTime: "2024-10-02",
+		UserId:   "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrCountryRequired() {
+	payload := request.UpdateEventReq{
+		EventId:       "id",
+		ContinentCode: "code",
+		UserId:        "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrUpdate() {
+	payload := request.UpdateEventReq{
+		EventId:     "id",
+		Description: "desc",
+		UserId:      "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventDetail", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	suite.mockEventRepositoryCache.AssertNotCalled(suite.T(), "RefreshEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrUpdateDuplicateName() {
+	payload := request.UpdateEventReq{
+		EventId: "id",
+		Name:    "new name",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Name:      "name",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockEventRepositoryQuery.On("FindEventByName", mock.Anything, "new name").Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventDetail", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.Conflict("Duplicate data")}))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockEventRepositoryCache.AssertNotCalled(suite.T(), "RefreshEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrChangedMeanwhile() {
+	payload := request.UpdateEventReq{
+		EventId:     "id",
+		Description: "desc",
+		UserId:      "userId",
+	}
+
+	readAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusDraft,
+			CreatedBy: "userId",
+			UpdatedAt: readAt,
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	// the stored event no longer has the status and updatedAt it was read with, nothing matches
+	suite.mockEventRepositoryCommand.On("UpdateOneEventDetail", mock.Anything, mock.Anything,
+		eventEntity.EventVersion{Status: constants.EventStatusDraft, UpdatedAt: readAt}).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateManyTicketEventInfo", mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.mockEventRepositoryCache.AssertNotCalled(suite.T(), "RefreshEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEvent() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		Reason:  "artist sick",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Tag:       "tag",
+			TicketIds: []string{"ticketId"},
+			Status:    constants.EventStatusPublished,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", false).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.MatchedBy(func(e eventEntity.Event) bool {
+		return e.Status == constants.EventStatusCancelled && e.CancelReason == "artist sick"
+	}), mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.MatchedBy(func(messages []outboxEntity.Outbox) bool {
+		return len(messages) == 1 && messages[0].Topic == "event-cancelled" && messages[0].Key == "id" &&
+			messages[0].Status == constants.OutboxStatusPending
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.MatchedBy(func(message outboxEntity.Outbox) bool {
+		return message.Topic == "event-cancelled" && message.Status == constants.OutboxStatusSent
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	suite.mockOutboxRepositoryCommand.AssertExpectations(suite.T())
+	suite.mockEventRepositoryCache.AssertCalled(suite.T(), "RefreshEvent", mock.Anything, mock.MatchedBy(func(event eventEntity.Event) bool {
+		return event.EventId == "id" && event.Status == constants.EventStatusCancelled
+	}))
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrRefreshCache() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		Reason:  "artist sick",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			TicketIds: []string{"ticketId"},
+			Status:    constants.EventStatusPublished,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryCache = &mockcert.RedisRepositoryCache{}
+	suite.mockEventRepositoryCache.On("RefreshEvent", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error redis")}))
+	suite.usecase = uc.NewCommandUsecase(suite.mockEventRepositoryQuery, suite.mockEventRepositoryCommand, suite.mockEventRepositoryCache,
+		suite.mockTicketRepositoryQuery, suite.mockTicketRepositoryCommand, suite.mockTicketRepositoryStock, suite.mockAddressRepositoryQuery,
+		suite.mockOutboxRepositoryCommand, suite.mockOrganizerRepositoryQuery, suite.mockKafkaProducer, suite.mockLogger)
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", false).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	suite.mockLogger.AssertCalled(suite.T(), "Error", mock.Anything, "Error refresh event cache, eventId : id", mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrFind() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrNotFound() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrForbidden() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		UserId:  "otherUser",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrAlreadyCancelled() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusCancelled,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrTicket() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", false).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrUpdate() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", false).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrOutbox() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusPublished,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", false).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "PublishBatchSync", mock.Anything, mock.Anything)
+	suite.mockEventRepositoryCache.AssertNotCalled(suite.T(), "RefreshEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrChangedMeanwhile() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	readAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusPublished,
+			CreatedBy: "userId",
+			UpdatedAt: readAt,
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", false).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.Anything,
+		eventEntity.EventVersion{Status: constants.EventStatusPublished, UpdatedAt: readAt}).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockOutboxRepositoryCommand.AssertNotCalled(suite.T(), "InsertManyOutbox", mock.Anything, mock.Anything)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "PublishBatchSync", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrFinished() {
+	payload := request.UpdateEventReq{
+		EventId:     "id",
+		Description: "desc",
+		UserId:      "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusFinished,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestPublishEvent() {
+	payload := request.PublishEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:       "id",
+			Name:          "name",
+			DateTime:      time.Now().Add(24 * time.Hour),
+			ContinentCode: "code",
+			Country: eventEntity.Country{
+				Code:  "code",
+				City:  "city",
+				Place: "place",
+			},
+			Description: "desc",
+			Tag:         "tag",
+			EventUrl:    "url",
+			TicketIds:   []string{"ticket1", "ticket2"},
+			Status:      constants.EventStatusDraft,
+			CreatedBy:   "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", true).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.MatchedBy(func(e eventEntity.Event) bool {
+		return e.Status == constants.EventStatusPublished
+	}), mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.MatchedBy(func(messages []outboxEntity.Outbox) bool {
+		// the relay leaves them alone while they are delivered inline
+		return len(messages) == 3 && messages[0].Topic == "concert-create-bank-ticket" && messages[0].Key == "id" &&
+			messages[0].Status == constants.OutboxStatusPending && messages[0].NextAttemptAt.After(time.Now().Add(5*time.Second)) &&
+			messages[2].Topic == "event-state-changed" && messages[2].Key == "id"
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.MatchedBy(func(batch []kafkaConfluent.Message) bool {
+		return len(batch) == 1 && batch[0].Topic == "event-state-changed"
+	})).Return([]error{nil})
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.MatchedBy(func(batch []kafkaConfluent.Message) bool {
+		return len(batch) == 2 && string(batch[0].Key) == "id" && string(batch[1].Key) == "id"
+	})).Return([]error{nil, nil})
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.MatchedBy(func(message outboxEntity.Outbox) bool {
+		return message.Status == constants.OutboxStatusSent
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	result, err := suite.usecase.PublishEvent(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success publish event", *result)
+	// the bank ticket messages and the state change are all queued in the outbox with the event
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockOutboxRepositoryCommand.AssertNumberOfCalls(suite.T(), "InsertManyOutbox", 1)
+	suite.mockOutboxRepositoryCommand.AssertNumberOfCalls(suite.T(), "UpdateOneOutbox", 3)
+}
+
+func (suite *CommandUsecaseTestSuite) TestPublishEventPartialDelivery() {
+	payload := request.PublishEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:       "id",
+			Name:          "name",
+			DateTime:      time.Now().Add(24 * time.Hour),
+			ContinentCode: "code",
+			Country: eventEntity.Country{
+				Code:  "code",
+				City:  "city",
+				Place: "place",
+			},
+			Description: "desc",
+			Tag:         "tag",
+			EventUrl:    "url",
+			TicketIds:   []string{"ticket1", "ticket2", "ticket3"},
+			Status:      constants.EventStatusDraft,
+			CreatedBy:   "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", true).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.MatchedBy(func(batch []kafkaConfluent.Message) bool {
+		return len(batch) == 1
+	})).Return([]error{errors.InternalServerError("timeout")})
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.MatchedBy(func(batch []kafkaConfluent.Message) bool {
+		return len(batch) == 3
+	})).Return([]error{nil, errors.InternalServerError("timeout"), errors.InternalServerError("timeout")})
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	result, err := suite.usecase.PublishEvent(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	// the undelivered state change is left to the relay and not counted with the bank ticket messages
+	assert.Contains(suite.T(), *result, "2 of 3")
+	// only the delivered message is marked as sent, the others stay pending for the relay
+	suite.mockOutboxRepositoryCommand.AssertNumberOfCalls(suite.T(), "UpdateOneOutbox", 1)
+}
+
+func (suite *CommandUsecaseTestSuite) TestPublishEventErrOutbox() {
+	payload := request.PublishEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:       "id",
+			Name:          "name",
+			DateTime:      time.Now().Add(24 * time.Hour),
+			ContinentCode: "code",
+			Country: eventEntity.Country{
+				Code:  "code",
+				City:  "city",
+				Place: "place",
+			},
+			Description: "desc",
+			Tag:         "tag",
+			EventUrl:    "url",
+			TicketIds:   []string{"ticket1"},
+			Status:      constants.EventStatusDraft,
+			CreatedBy:   "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", true).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	_, err := suite.usecase.PublishEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "PublishBatchSync", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestPublishEventScheduled() {
+	payload := request.PublishEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:       "id",
+			Name:          "name",
+			DateTime:      time.Now().Add(48 * time.Hour),
+			PublishAt:     time.Now().Add(24 * time.Hour),
+			ContinentCode: "code",
+			Country: eventEntity.Country{
+				Code:  "code",
+				City:  "city",
+				Place: "place",
+			},
+			Description: "desc",
+			Tag:         "tag",
+			EventUrl:    "url",
+			TicketIds:   []string{"ticket1", "ticket2"},
+			Status:      constants.EventStatusDraft,
+			CreatedBy:   "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.MatchedBy(func(e eventEntity.Event) bool {
+		return e.Status == constants.EventStatusScheduled
+	}), mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.MatchedBy(func(messages []outboxEntity.Outbox) bool {
+		return len(messages) == 1 && messages[0].Topic == "event-state-changed"
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.PublishEvent(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateManyTicketSellable", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockOutboxRepositoryCommand.AssertExpectations(suite.T())
+}
+
+func (suite *CommandUsecaseTestSuite) TestPublishEventErrNotFound() {
+	payload := request.PublishEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.PublishEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestPublishEventErrForbidden() {
+	payload := request.PublishEventReq{
+		EventId: "id",
+		UserId:  "otherUser",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusDraft,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.PublishEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestPublishEventErrTransition() {
+	payload := request.PublishEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusCancelled,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.PublishEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestPublishEventErrIncomplete() {
+	payload := request.PublishEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Name:      "name",
+			Status:    constants.EventStatusDraft,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.PublishEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestPublishEventErrPastDate() {
+	payload := request.PublishEventReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:       "id",
+			Name:          "name",
+			DateTime:      time.Now().Add(-24 * time.Hour),
+			ContinentCode: "code",
+			Country: eventEntity.Country{
+				Code:  "code",
+				City:  "city",
+				Place: "place",
+			},
+			Description: "desc",
+			Tag:         "tag",
+			EventUrl:    "url",
+			TicketIds:   []string{"ticket1"},
+			Status:      constants.EventStatusDraft,
+			CreatedBy:   "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.PublishEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateEventErrSalesWindow() {
+	payload := request.EventReq{
+		Name:          "name",
+		DateTime:      "2030-01-02 15:04",
+		SalesStartAt:  "2030-01-01 15:04",
+		SalesEndAt:    "2029-12-31 15:04",
+		ContinentCode: "code",
+		Country:       request.Country{Id: 1},
+		Tag:           "tag",
+		OrganizerId:   "organizerId",
+		UserId:        "userId",
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventByName", mock.Anything, payload.Name).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, payload.Tag).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "InsertManyTicketCollection", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateEventErrSalesEndAfterEvent() {
+	payload := request.UpdateEventReq{
+		EventId:    "id",
+		SalesEndAt: "2030-01-03 15:04",
+		UserId:     "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			DateTime:  time.Date(2030, 1, 2, 15, 4, 0, 0, time.UTC),
+			Status:    constants.EventStatusDraft,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.UpdateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	suite.mockEventRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestProcessScheduledEvents() {
+	scheduled := helpers.Result{
+		Data: &[]eventEntity.Event{
+			{EventId: "scheduled", TicketIds: []string{"ticket1"}, Status: constants.EventStatusScheduled},
+		},
+	}
+	salesEnded := helpers.Result{
+		Data: &[]eventEntity.Event{
+			{EventId: "salesEnded", Status: constants.EventStatusPublished},
+		},
+	}
+	started := helpers.Result{
+		Data: &[]eventEntity.Event{
+			{EventId: "started", Status: constants.EventStatusSalesClosed},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventsByStatusBefore", mock.Anything, constants.EventStatusScheduled, "publishAt", mock.Anything).Return(mockChannel(scheduled))
+	suite.mockEventRepositoryQuery.On("FindEventsByStatusBefore", mock.Anything, constants.EventStatusPublished, "salesEndAt", mock.Anything).Return(mockChannel(salesEnded))
+	suite.mockEventRepositoryQuery.On("FindEventsByStatusBefore", mock.Anything, constants.EventStatusPublished, "dateTime", mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryQuery.On("FindEventsByStatusBefore", mock.Anything, constants.EventStatusSalesClosed, "dateTime", mock.Anything).Return(mockChannel(started))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "scheduled", true).Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "salesEnded", false).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, event eventEntity.Event, expected eventEntity.EventVersion) <-chan helpers.Result {
+			return mockChannel(helpers.Result{Count: 1})
+		})
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.ProcessScheduledEvents(suite.ctx)
+	assert.NoError(suite.T(), err)
+	suite.mockEventRepositoryCommand.AssertCalled(suite.T(), "UpdateOneEventStatus", mock.Anything, mock.MatchedBy(func(e eventEntity.Event) bool {
+		return e.EventId == "scheduled" && e.Status == constants.EventStatusPublished
+	}), mock.Anything)
+	suite.mockEventRepositoryCommand.AssertCalled(suite.T(), "UpdateOneEventStatus", mock.Anything, mock.MatchedBy(func(e eventEntity.Event) bool {
+		return e.EventId == "salesEnded" && e.Status == constants.EventStatusSalesClosed
+	}), mock.Anything)
+	suite.mockEventRepositoryCommand.AssertCalled(suite.T(), "UpdateOneEventStatus", mock.Anything, mock.MatchedBy(func(e eventEntity.Event) bool {
+		return e.EventId == "started" && e.Status == constants.EventStatusFinished
+	}), mock.Anything)
+	// every transition queues its state change in the outbox, the publish also queues the bank ticket message
+	suite.mockOutboxRepositoryCommand.AssertNumberOfCalls(suite.T(), "InsertManyOutbox", 3)
+	suite.mockKafkaProducer.AssertNumberOfCalls(suite.T(), "PublishBatchSync", 4)
+}
+
+func (suite *CommandUsecaseTestSuite) TestProcessScheduledEventsContinueOnError() {
+	scheduled := helpers.Result{
+		Data: &[]eventEntity.Event{
+			{EventId: "broken", Status: constants.EventStatusScheduled},
+			{EventId: "ok", Status: constants.EventStatusScheduled},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventsByStatusBefore", mock.Anything, constants.EventStatusScheduled, "publishAt", mock.Anything).Return(mockChannel(scheduled))
+	suite.mockEventRepositoryQuery.On("FindEventsByStatusBefore", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "broken", true).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "ok", true).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.ProcessScheduledEvents(suite.ctx)
+	assert.NoError(suite.T(), err)
+	suite.mockEventRepositoryCommand.AssertNumberOfCalls(suite.T(), "UpdateOneEventStatus", 1)
+	suite.mockLogger.AssertCalled(suite.T(), "Error", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestProcessScheduledEventsErrQuery() {
+	suite.mockEventRepositoryQuery.On("FindEventsByStatusBefore", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	err := suite.usecase.ProcessScheduledEvents(suite.ctx)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateEventRollbackOnEventInsert() {
+	payload := request.EventReq{
+		Name:          "name",
+		OrganizerId:   "organizerId",
+		UserId:        "userId",
+		DateTime:      "2030-09-02 15:04",
+		ContinentCode: "code",
+		Country:       request.Country{Id: 1},
+		Tag:           "tag",
+		Tickets: []request.Ticket{
+			{
+				TicketType:  "Gold",
+				TicketPrice: 50,
+				TotalQuota:  10,
+			},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventByName", mock.Anything, payload.Name).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, payload.Tag).Return(mockChannel(helpers.Result{}))
+	suite.mockAddressRepositoryQuery.On("FindOneContinentByCode", mock.Anything, "code").Return(mockChannel(helpers.Result{Data: &addressEntity.Continent{Code: "code"}}))
+	suite.mockAddressRepositoryQuery.On("FindOneCountry", mock.Anything, 1).Return(mockChannel(helpers.Result{Data: &addressEntity.Country{Code: "code"}}))
+	suite.mockTicketRepositoryCommand.On("InsertManyTicketCollection", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("InsertOneEventCollection", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	// both inserts ran inside the same transaction, which reports the failure
+	suite.mockEventRepositoryCommand.AssertNumberOfCalls(suite.T(), "WithTransaction", 1)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "InsertManyTicketCollection", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateEventErrTransaction() {
+	payload := request.EventReq{
+		Name:          "name",
+		OrganizerId:   "organizerId",
+		UserId:        "userId",
+		DateTime:      "2030-09-02 15:04",
+		ContinentCode: "code",
+		Country:       request.Country{Id: 1},
+		Tag:           "tag",
+	}
+
+	suite.mockEventRepositoryCommand = &mockcert.MongodbRepositoryCommand{}
+	suite.mockEventRepositoryCommand.On("WithTransaction", mock.Anything, mock.Anything).Return(errors.InternalServerError("Error mongodb transaction"))
+	suite.usecase = uc.NewCommandUsecase(suite.mockEventRepositoryQuery, suite.mockEventRepositoryCommand, suite.mockEventRepositoryCache,
+		suite.mockTicketRepositoryQuery, suite.mockTicketRepositoryCommand, suite.mockTicketRepositoryStock, suite.mockAddressRepositoryQuery,
+		suite.mockOutboxRepositoryCommand, suite.mockOrganizerRepositoryQuery, suite.mockKafkaProducer, suite.mockLogger)
+
+	suite.mockEventRepositoryQuery.On("FindEventByName", mock.Anything, payload.Name).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, payload.Tag).Return(mockChannel(helpers.Result{}))
+	suite.mockAddressRepositoryQuery.On("FindOneContinentByCode", mock.Anything, "code").Return(mockChannel(helpers.Result{Data: &addressEntity.Continent{Code: "code"}}))
+	suite.mockAddressRepositoryQuery.On("FindOneCountry", mock.Anything, 1).Return(mockChannel(helpers.Result{Data: &addressEntity.Country{Code: "code"}}))
+
+	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcknowledgeBankTicket() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			TicketIds: []string{"ticket1"},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketProvisioning", mock.Anything, "ticket1", constants.TicketProvisioningProvisioned, "").
+		Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.AcknowledgeBankTicket(suite.ctx, request.BankTicketCreatedReq{EventId: "id", TicketId: "ticket1"})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcknowledgeBankTicketFailed() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			TicketIds: []string{"ticket1"},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketProvisioning", mock.Anything, "ticket1", constants.TicketProvisioningFailed, "bank unavailable").
+		Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.AcknowledgeBankTicket(suite.ctx, request.BankTicketCreatedReq{
+		EventId:  "id",
+		TicketId: "ticket1",
+		Status:   constants.TicketProvisioningFailed,
+		Reason:   "bank unavailable",
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcknowledgeBankTicketErrUpdate() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			TicketIds: []string{"ticket1"},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketProvisioning", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	err := suite.usecase.AcknowledgeBankTicket(suite.ctx, request.BankTicketCreatedReq{EventId: "id", TicketId: "ticket1"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcknowledgeBankTicketErrUnknownTicket() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			TicketIds: []string{"ticket1"},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	err := suite.usecase.AcknowledgeBankTicket(suite.ctx, request.BankTicketCreatedReq{EventId: "id", TicketId: "other"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcknowledgeOrder() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			TicketIds: []string{"ticket1"},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryStock.On("ConfirmHold", mock.Anything, "ticket1", "holdId", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.TicketHold{Quantity: 2, CountryCode: "ID"}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{
+			TicketId: "ticket1", TicketType: constants.Online, Tag: "tag", Country: ticketEntity.Country{Code: "SG"},
+		}}))
+	suite.mockTicketRepositoryCommand.On("IncOnlineTicketSold", mock.Anything, "tag", "ID", 2).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.OnlineTicketConfig{Tag: "tag"}}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.AcknowledgeOrder(suite.ctx, orderAck(constants.OrderStatusPaid))
+	assert.NoError(suite.T(), err)
+	suite.mockTicketRepositoryStock.AssertNotCalled(suite.T(), "ReleaseHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "IncOnlineTicketSold", mock.Anything, "tag", "ID", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcknowledgeOrderCancelled() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			TicketIds: []string{"ticket1"},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryStock.On("ReleaseHold", mock.Anything, "ticket1", "holdId", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.TicketHold{Quantity: 2}}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.AcknowledgeOrder(suite.ctx, orderAck(constants.OrderStatusCancelled))
+	assert.NoError(suite.T(), err)
+	suite.mockTicketRepositoryStock.AssertNotCalled(suite.T(), "ConfirmHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcknowledgeOrderHoldSettled() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			TicketIds: []string{"ticket1"},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryStock.On("ConfirmHold", mock.Anything, "ticket1", "holdId", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.NotFound("hold not found or expired")}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	// read again after it was handled, the message is acknowledged
+	err := suite.usecase.AcknowledgeOrder(suite.ctx, orderAck(constants.OrderStatusPaid))
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcknowledgeOrderErrHold() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			TicketIds: []string{"ticket1"},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryStock.On("ConfirmHold", mock.Anything, "ticket1", "holdId", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error redis")}))
+
+	err := suite.usecase.AcknowledgeOrder(suite.ctx, orderAck(constants.OrderStatusPaid))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcknowledgeOrderErrNotFound() {
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(helpers.Result{}))
+
+	err := suite.usecase.AcknowledgeOrder(suite.ctx, orderAck(constants.OrderStatusPaid))
+	assert.Error(suite.T(), err)
+	suite.mockTicketRepositoryStock.AssertNotCalled(suite.T(), "ConfirmHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func orderAck(status string) request.OrderAckReq {
+	return request.OrderAckReq{OrderId: "order", EventId: "id", TicketId: "ticket1", HoldId: "holdId", UserId: "userId", Status: status}
+}
+
+func (suite *CommandUsecaseTestSuite) TestReprovisionTickets() {
+	payload := request.ReprovisionTicketReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusPublished,
+			TicketIds: []string{"ticket1", "ticket2"},
+			CreatedBy: "userId",
+		},
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{TicketId: "ticket1", ProvisioningStatus: constants.TicketProvisioningProvisioned},
+			{TicketId: "ticket2", ProvisioningStatus: constants.TicketProvisioningFailed},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketProvisioning", mock.Anything, "ticket2", constants.TicketProvisioningPending, "").
+		Return(mockChannel(helpers.Result{}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.MatchedBy(func(messages []outboxEntity.Outbox) bool {
+		return len(messages) == 1 && messages[0].Topic == "concert-create-bank-ticket" &&
+			messages[0].Payload == `{"ticketId":"ticket2","eventId":"id"}`
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	result, err := suite.usecase.ReprovisionTickets(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success re-provision 1 tickets", *result)
+	suite.mockTicketRepositoryCommand.AssertNumberOfCalls(suite.T(), "UpdateOneTicketProvisioning", 1)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReprovisionTicketsUndelivered() {
+	payload := request.ReprovisionTicketReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusSalesClosed,
+			CreatedBy: "userId",
+		},
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{TicketId: "ticket1", ProvisioningStatus: constants.TicketProvisioningFailed},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketProvisioning", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{errors.InternalServerError("timeout")})
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	result, err := suite.usecase.ReprovisionTickets(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), *result, "1 of 1 bank ticket messages failed to deliver")
+	suite.mockOutboxRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOneOutbox", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReprovisionTicketsErrNoFailedTickets() {
+	payload := request.ReprovisionTicketReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusPublished,
+			CreatedBy: "userId",
+		},
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{TicketId: "ticket1", ProvisioningStatus: constants.TicketProvisioningPending},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+
+	result, err := suite.usecase.ReprovisionTickets(suite.ctx, payload)
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestReprovisionTicketsErrForbidden() {
+	payload := request.ReprovisionTicketReq{
+		EventId: "id",
+		UserId:  "other",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusPublished,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	result, err := suite.usecase.ReprovisionTickets(suite.ctx, payload)
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestReprovisionTicketsErrDraft() {
+	payload := request.ReprovisionTicketReq{
+		EventId: "id",
+		UserId:  "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusDraft,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	result, err := suite.usecase.ReprovisionTickets(suite.ctx, payload)
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestReprovisionTicketsErrNotFound() {
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.ReprovisionTickets(suite.ctx, request.ReprovisionTicketReq{EventId: "id", UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReprovisionTicketsErrTransaction() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusPublished,
+			CreatedBy: "userId",
+		},
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{TicketId: "ticket1", ProvisioningStatus: constants.TicketProvisioningFailed},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketProvisioning", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	result, err := suite.usecase.ReprovisionTickets(suite.ctx, request.ReprovisionTicketReq{EventId: "id", UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "PublishBatchSync", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAddTicketTypeDraft() {
+	payload := request.AddTicketReq{
+		EventId:     "id",
+		TicketType:  constants.Gold,
+		TicketPrice: 100,
+		TotalQuota:  10,
+		UserId:      "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusDraft,
+			DateTime:  time.Now().Add(48 * time.Hour),
+			TicketIds: []string{"ticket1"},
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("InsertOneTicketIfAbsent", mock.Anything, mock.MatchedBy(func(ticket ticketEntity.Ticket) bool {
+		return ticket.EventId == "id" && ticket.TotalRemaining == 10 && !ticket.IsSellable &&
+			ticket.ProvisioningStatus == constants.TicketProvisioningPending
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("AddEventTicketId", mock.Anything, "id", mock.AnythingOfType("string"), "userId").
+		Return(mockChannel(helpers.Result{Data: &eventEntity.Event{EventId: "id"}}))
+
+	result, err := suite.usecase.AddTicketType(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success add ticket type", *result)
+	// only the ticket id is added, the rest of the event is not written back
+	suite.mockEventRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockOutboxRepositoryCommand.AssertNotCalled(suite.T(), "InsertManyOutbox", mock.Anything, mock.Anything)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "PublishBatchSync", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAddTicketTypePublished() {
+	payload := request.AddTicketReq{
+		EventId:     "id",
+		TicketType:  constants.Silver,
+		TicketPrice: 100,
+		TotalQuota:  10,
+		UserId:      "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusPublished,
+			DateTime:  time.Now().Add(48 * time.Hour),
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("InsertOneTicketIfAbsent", mock.Anything, mock.MatchedBy(func(ticket ticketEntity.Ticket) bool {
+		return ticket.IsSellable
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("AddEventTicketId", mock.Anything, "id", mock.Anything, "userId").
+		Return(mockChannel(helpers.Result{Data: &eventEntity.Event{EventId: "id"}}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.MatchedBy(func(messages []outboxEntity.Outbox) bool {
+		return len(messages) == 1 && messages[0].Topic == "concert-create-bank-ticket"
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	result, err := suite.usecase.AddTicketType(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success add ticket type", *result)
+	suite.mockKafkaProducer.AssertNumberOfCalls(suite.T(), "PublishBatchSync", 1)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAddTicketTypeErrExist() {
+	payload := request.AddTicketReq{
+		EventId:     "id",
+		TicketType:  constants.Gold,
+		TicketPrice: 100,
+		TotalQuota:  10,
+		UserId:      "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusDraft,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("InsertOneTicketIfAbsent", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{TicketId: "ticket1"}}))
+
+	result, err := suite.usecase.AddTicketType(suite.ctx, payload)
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockEventRepositoryCommand.AssertNotCalled(suite.T(), "AddEventTicketId", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAddTicketTypeErrEventDeleted() {
+	payload := request.AddTicketReq{
+		EventId:     "id",
+		TicketType:  constants.Gold,
+		TicketPrice: 100,
+		TotalQuota:  10,
+		UserId:      "userId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusDraft,
+			DateTime:  time.Now().Add(48 * time.Hour),
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("InsertOneTicketIfAbsent", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("AddEventTicketId", mock.Anything, "id", mock.Anything, "userId").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.AddTicketType(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+	suite.mockEventRepositoryCache.AssertNotCalled(suite.T(), "RefreshEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAddTicketTypeErrTicketType() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusDraft,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	result, err := suite.usecase.AddTicketType(suite.ctx, request.AddTicketReq{EventId: "id", TicketType: "Platinum", UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestAddTicketTypeErrCancelled() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusCancelled,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	result, err := suite.usecase.AddTicketType(suite.ctx, request.AddTicketReq{EventId: "id", TicketType: constants.Gold, UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestAddTicketTypeErrForbidden() {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    constants.EventStatusDraft,
+			CreatedBy: "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	result, err := suite.usecase.AddTicketType(suite.ctx, request.AddTicketReq{EventId: "id", TicketType: constants.Gold, UserId: "other"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) mockTicketForUpdate(status string) {
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Status:    status,
+			TicketIds: []string{"ticket1"},
+			CreatedBy: "userId",
+		},
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{TicketId: "ticket1", TicketType: constants.Gold, TicketPrice: 100, TotalQuota: 10, TotalRemaining: 4},
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketQuota() {
+	quota := 8
+	suite.mockTicketForUpdate(constants.EventStatusPublished)
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketQuota", mock.Anything, "ticket1", 10, -2).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{TicketId: "ticket1", TotalQuota: 8, TotalRemaining: 2}}))
+	suite.mockTicketRepositoryStock.On("AdjustStock", mock.Anything, "ticket1", -2).Return(mockChannel(helpers.Result{}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.MatchedBy(func(messages []outboxEntity.Outbox) bool {
+		return len(messages) == 1 && messages[0].Topic == "concert-update-bank-ticket" &&
+			messages[0].Key == "id"
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{
+		EventId: "id", TicketId: "ticket1", TotalQuota: &quota, UserId: "userId",
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success update ticket", *result)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOneTicketPrice", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketErrStock() {
+	quota := 8
+	suite.mockTicketForUpdate(constants.EventStatusPublished)
+	suite.mockTicketRepositoryStock.On("AdjustStock", mock.Anything, "ticket1", -2).
+		Return(mockChannel(helpers.Result{Error: errors.Conflict("not enough tickets left to lower the quota")}))
+
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{
+		EventId: "id", TicketId: "ticket1", TotalQuota: &quota, UserId: "userId",
+	})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOneTicketQuota", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketQuotaRaise() {
+	quota := 12
+	suite.mockTicketForUpdate(constants.EventStatusDraft)
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketQuota", mock.Anything, "ticket1", 10, 2).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{TicketId: "ticket1", TotalQuota: 12, TotalRemaining: 6}}))
+	suite.mockTicketRepositoryStock.On("AdjustStock", mock.Anything, "ticket1", 2).Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{
+		EventId: "id", TicketId: "ticket1", TotalQuota: &quota, UserId: "userId",
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success update ticket", *result)
+	suite.mockTicketRepositoryStock.AssertNumberOfCalls(suite.T(), "AdjustStock", 1)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketQuotaErrOutbox() {
+	quota := 8
+	suite.mockTicketForUpdate(constants.EventStatusPublished)
+	suite.mockTicketRepositoryStock.On("AdjustStock", mock.Anything, "ticket1", -2).Return(mockChannel(helpers.Result{Data: int64(2)}))
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketQuota", mock.Anything, "ticket1", 10, -2).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{TicketId: "ticket1", TotalQuota: 8, TotalRemaining: 2}}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockTicketRepositoryStock.On("AdjustStock", mock.Anything, "ticket1", 2).Return(mockChannel(helpers.Result{Data: int64(4)}))
+
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{
+		EventId: "id", TicketId: "ticket1", TotalQuota: &quota, UserId: "userId",
+	})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	// the units taken from redis before the transaction are given back once it failed
+	suite.mockTicketRepositoryStock.AssertCalled(suite.T(), "AdjustStock", mock.Anything, "ticket1", -2)
+	suite.mockTicketRepositoryStock.AssertCalled(suite.T(), "AdjustStock", mock.Anything, "ticket1", 2)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "PublishBatchSync", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketPriceDraft() {
+	price := 150
+	suite.mockTicketForUpdate(constants.EventStatusDraft)
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketPrice", mock.Anything, "ticket1", 150).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{TicketId: "ticket1", TicketPrice: 150}}))
+
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{
+		EventId: "id", TicketId: "ticket1", TicketPrice: &price, UserId: "userId",
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success update ticket", *result)
+	suite.mockOutboxRepositoryCommand.AssertNotCalled(suite.T(), "InsertManyOutbox", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketErrBelowSold() {
+	quota := 5
+	suite.mockTicketForUpdate(constants.EventStatusPublished)
+
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{
+		EventId: "id", TicketId: "ticket1", TotalQuota: &quota, UserId: "userId",
+	})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOneTicketQuota", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketErrConcurrentSale() {
+	quota := 7
+	suite.mockTicketForUpdate(constants.EventStatusPublished)
+	suite.mockTicketRepositoryStock.On("AdjustStock", mock.Anything, "ticket1", -3).Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketQuota", mock.Anything, "ticket1", 10, -3).
+		Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{
+		EventId: "id", TicketId: "ticket1", TotalQuota: &quota, UserId: "userId",
+	})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockOutboxRepositoryCommand.AssertNotCalled(suite.T(), "InsertManyOutbox", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketErrTicketNotFound() {
+	price := 150
+	suite.mockTicketForUpdate(constants.EventStatusPublished)
+
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{
+		EventId: "id", TicketId: "other", TicketPrice: &price, UserId: "userId",
+	})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketErrEmpty() {
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{EventId: "id", TicketId: "ticket1", UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	suite.mockEventRepositoryQuery.AssertNotCalled(suite.T(), "FindEventById", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpdateTicketErrOutbox() {
+	price := 150
+	suite.mockTicketForUpdate(constants.EventStatusSalesClosed)
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketPrice", mock.Anything, "ticket1", 150).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{TicketId: "ticket1", TicketPrice: 150}}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	result, err := suite.usecase.UpdateTicket(suite.ctx, request.UpdateTicketReq{
+		EventId: "id", TicketId: "ticket1", TicketPrice: &price, UserId: "userId",
+	})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "PublishBatchSync", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) mockOnlineTicketCountries(countries ...addressEntity.Country) {
+	mockEventByTag := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Tag:       "tag",
+			CreatedBy: "userId",
+		},
+	}
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(mockEventByTag))
+	for i := range countries {
+		suite.mockAddressRepositoryQuery.On("FindOneCountryByNumber", mock.Anything, countries[i].Number).
+			Return(mockChannel(helpers.Result{Data: &countries[i]}))
+	}
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigAllocation() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
+		CountryList: []request.CountryList{
+			{CountryNumber: 360, Percentage: 33},
+			{CountryNumber: 458, Percentage: 33},
+			{CountryNumber: 702, Percentage: 34},
+		},
+	}
+	suite.mockOnlineTicketCountries(
+		addressEntity.Country{Code: "ID", Name: "Indonesia", Number: 360},
+		addressEntity.Country{Code: "MY", Name: "Malaysia", Number: 458},
+		addressEntity.Country{Code: "SG", Name: "Singapore", Number: 702},
+	)
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.MatchedBy(func(config ticketEntity.OnlineTicketConfig) bool {
+		return len(config.CountryList) == 3 &&
+			config.CountryList[0].CountryCode == "ID" && config.CountryList[0].CountryName == "Indonesia" &&
+			config.CountryList[0].Quota == 3 && config.CountryList[1].Quota == 3 && config.CountryList[2].Quota == 4
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigAllocationTie() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  7,
+		CountryList: []request.CountryList{
+			{CountryNumber: 360, Percentage: 50},
+			{CountryNumber: 458, Percentage: 50},
+		},
+	}
+	suite.mockOnlineTicketCountries(
+		addressEntity.Country{Code: "ID", Number: 360},
+		addressEntity.Country{Code: "MY", Number: 458},
+	)
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.MatchedBy(func(config ticketEntity.OnlineTicketConfig) bool {
+		return config.CountryList[0].Quota == 4 && config.CountryList[1].Quota == 3
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigKeepSold() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  20,
+		CountryList: []request.CountryList{
+			{CountryNumber: 360, Percentage: 100},
+		},
+	}
+	createdAt := time.Now().Add(-time.Hour)
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:         "tag",
+			TotalQuota:  10,
+			CountryList: []ticketEntity.CountryList{{CountryNumber: 360, CountryCode: "ID", Percentage: 100, Quota: 10, Sold: 6}},
+			CreatedAt:   createdAt,
+			CreatedBy:   "userId",
+		},
+	}
+	suite.mockOnlineTicketCountries(addressEntity.Country{Code: "ID", Number: 360})
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.MatchedBy(func(config ticketEntity.OnlineTicketConfig) bool {
+		return config.CountryList[0].Quota == 20 && config.CountryList[0].Sold == 6 && config.CreatedAt.Equal(createdAt)
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrBelowSold() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
+		CountryList: []request.CountryList{
+			{CountryNumber: 360, Percentage: 50},
+			{CountryNumber: 458, Percentage: 50},
+		},
+	}
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:         "tag",
+			CountryList: []ticketEntity.CountryList{{CountryNumber: 360, CountryCode: "ID", Quota: 10, Sold: 8}},
+		},
+	}
+	suite.mockOnlineTicketCountries(
+		addressEntity.Country{Code: "ID", Number: 360},
+		addressEntity.Country{Code: "MY", Number: 458},
+	)
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrRemoveSold() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
+		CountryList: []request.CountryList{
+			{CountryNumber: 458, Percentage: 100},
+		},
+	}
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:         "tag",
+			CountryList: []ticketEntity.CountryList{{CountryNumber: 360, CountryCode: "ID", Quota: 10, Sold: 1}},
+		},
+	}
+	suite.mockOnlineTicketCountries(addressEntity.Country{Code: "MY", Number: 458})
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrCountry() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
+		CountryList: []request.CountryList{
+			{CountryNumber: 999, Percentage: 100},
+		},
+	}
+	suite.mockOnlineTicketCountries()
+	suite.mockAddressRepositoryQuery.On("FindOneCountryByNumber", mock.Anything, 999).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrDuplicateCountry() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
+		CountryList: []request.CountryList{
+			{CountryNumber: 360, Percentage: 50},
+			{CountryNumber: 360, Percentage: 50},
+		},
+	}
+	suite.mockOnlineTicketCountries(addressEntity.Country{Code: "ID", Number: 360})
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigVersion() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
+		CountryList: []request.CountryList{
+			{CountryNumber: 360, Percentage: 100},
+		},
+	}
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:       "tag",
+			Version:   3,
+			CreatedBy: "userId",
+		},
+	}
+	suite.mockOnlineTicketCountries(addressEntity.Country{Code: "ID", Number: 360})
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.MatchedBy(func(config ticketEntity.OnlineTicketConfig) bool {
+		return config.Version == 4
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.MatchedBy(func(history ticketEntity.OnlineTicketConfigHistory) bool {
+		return history.Tag == "tag" && history.Version == 4 && history.Action == constants.OnlineTicketConfigUpserted &&
+			history.ChangedBy == "userId" && history.CountryList[0].Quota == 10
+	})).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrOwner() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
+		CountryList: []request.CountryList{
+			{CountryNumber: 360, Percentage: 100},
+		},
+	}
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:       "tag",
+			CreatedBy: "other",
+		},
+	}
+	suite.mockOnlineTicketCountries(addressEntity.Country{Code: "ID", Number: 360})
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrHistory() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+		Tag:         "tag",
+		TotalQuota:  10,
+		CountryList: []request.CountryList{
+			{CountryNumber: 360, Percentage: 100},
+		},
+	}
+	suite.mockOnlineTicketCountries(addressEntity.Country{Code: "ID", Number: 360})
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("UpsertOneOnlineTicketConfig", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error mongodb connection")}))
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) mockOnlineTicketConfig(createdBy string, sold int) {
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:         "tag",
+			Version:     2,
+			TotalQuota:  10,
+			CountryList: []ticketEntity.CountryList{{CountryNumber: 360, CountryCode: "ID", Percentage: 100, Quota: 10, Sold: sold}},
+			CreatedBy:   createdBy,
+		},
+	}
+	mockEventByTag := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:   "id",
+			Tag:       "tag",
+			CreatedBy: createdBy,
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(mockEventByTag))
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfig() {
+	suite.mockOnlineTicketConfig("userId", 0)
+	suite.mockTicketRepositoryCommand.On("DeleteOneOnlineTicketConfig", mock.Anything, "tag").Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.MatchedBy(func(history ticketEntity.OnlineTicketConfigHistory) bool {
+		return history.Version == 3 && history.Action == constants.OnlineTicketConfigDeleted && history.ChangedBy == "userId"
+	})).Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success delete online ticket config", *result)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfigAdmin() {
+	suite.mockOnlineTicketConfig("userId", 0)
+	suite.mockTicketRepositoryCommand.On("DeleteOneOnlineTicketConfig", mock.Anything, "tag").Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "adminId", UserRole: constants.RoleAdmin})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfigErrForbidden() {
+	suite.mockOnlineTicketConfig("userId", 0)
+
+	_, err := suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "other"})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "DeleteOneOnlineTicketConfig", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfigErrSold() {
+	suite.mockOnlineTicketConfig("userId", 1)
+
+	_, err := suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfigErrSoldMeanwhile() {
+	suite.mockOnlineTicketConfig("userId", 0)
+	suite.mockTicketRepositoryCommand.On("DeleteOneOnlineTicketConfig", mock.Anything, "tag").Return(mockChannel(helpers.Result{Count: 0}))
+
+	_, err := suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfigErrAfterSale() {
+	// an online ticket of the tag is sold, the sale is counted in the config which then cannot be deleted
+	suite.mockTicketRepositoryStock.On("ConfirmHold", mock.Anything, "ticket1", "hold1", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.TicketHold{Quantity: 1, CountryCode: "ID"}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{
+			TicketId: "ticket1", TicketType: constants.Online, Tag: "tag", Country: ticketEntity.Country{Code: "SG"},
+		}}))
+	suite.mockTicketRepositoryCommand.On("IncOnlineTicketSold", mock.Anything, "tag", "ID", 1).Run(func(args mock.Arguments) {
+		suite.mockOnlineTicketConfig("userId", 1)
+	}).Return(mockChannel(helpers.Result{Data: &ticketEntity.OnlineTicketConfig{Tag: "tag"}}))
+
+	_, err := suite.usecase.ConfirmTicketHold(suite.ctx, request.TicketHoldReq{TicketId: "ticket1", HoldId: "hold1", UserId: "userId"})
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "DeleteOneOnlineTicketConfig", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfigErrNotFound() {
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfigErrDelete() {
+	suite.mockOnlineTicketConfig("userId", 0)
+	suite.mockTicketRepositoryCommand.On("DeleteOneOnlineTicketConfig", mock.Anything, "tag").
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error mongodb connection")}))
+
+	_, err := suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	assert.Error(suite.T(), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneOnlineTicketConfigHistory", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateEventErrNotTeamEditor() {
+	payload := request.EventReq{
+		Name:        "name",
+		OrganizerId: "organizerId",
+		UserId:      "viewerId",
+	}
+
+	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+	suite.mockEventRepositoryQuery.AssertNotCalled(suite.T(), "FindEventByName", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateEventErrOrganizerNotFound() {
+	payload := request.EventReq{
+		Name:        "name",
+		OrganizerId: "missing",
+		UserId:      "userId",
+	}
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "missing").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateEventErrFindOrganizer() {
+	payload := request.EventReq{
+		Name:        "name",
+		OrganizerId: "broken",
+		UserId:      "userId",
+	}
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "broken").
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error mongodb connection")}))
+
+	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusInternalServerError, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateEventErrTagOfOtherOrganizer() {
+	payload := request.EventReq{
+		Name:        "name",
+		Tag:         "tag",
+		OrganizerId: "organizerId",
+		UserId:      "userId",
+	}
+	mockEventByTag := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:     "id",
+			Tag:         "tag",
+			OrganizerId: "otherOrganizerId",
+			CreatedBy:   "userId",
+		},
+	}
+	suite.mockEventRepositoryQuery.On("FindEventByName", mock.Anything, "name").Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(mockEventByTag))
+
+	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateEventTagOfTeammate() {
+	payload := request.EventReq{
+		Name:          "name",
+		OrganizerId:   "organizerId",
+		UserId:        "editorId",
+		DateTime:      "2030-09-02 15:04",
+		ContinentCode: "code",
+		Country:       request.Country{Id: 1},
+		Tag:           "tag",
+		Tickets: []request.Ticket{
+			{
+				TicketType:  "Gold",
+				TicketPrice: 50,
+				TotalQuota:  10,
+			},
+		},
+	}
+	mockEventByTag := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:     "id",
+			Tag:         "tag",
+			OrganizerId: "organizerId",
+			CreatedBy:   "userId",
+		},
+	}
+	suite.mockEventRepositoryQuery.On("FindEventByName", mock.Anything, "name").Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(mockEventByTag))
+	suite.mockAddressRepositoryQuery.On("FindOneContinentByCode", mock.Anything, "code").Return(mockChannel(helpers.Result{
+		Data: &addressEntity.Continent{Code: "code", Name: "name"},
+	}))
+	suite.mockAddressRepositoryQuery.On("FindOneCountry", mock.Anything, 1).Return(mockChannel(helpers.Result{
+		Data: &addressEntity.Country{Id: 1, Code: "code", Name: "name"},
+	}))
+	suite.mockTicketRepositoryCommand.On("InsertManyTicketCollection", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("InsertOneEventCollection", mock.Anything, mock.MatchedBy(func(e eventEntity.Event) bool {
+		return e.OrganizerId == "organizerId" && e.CreatedBy == "editorId"
+	})).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.CreateEvent(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	suite.mockEventRepositoryCommand.AssertCalled(suite.T(), "InsertOneEventCollection", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventTeamEditor() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		Reason:  "artist sick",
+		UserId:  "editorId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:     "id",
+			TicketIds:   []string{"ticketId"},
+			Status:      constants.EventStatusPublished,
+			OrganizerId: "organizerId",
+			CreatedBy:   "userId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("UpdateManyTicketSellable", mock.Anything, "id", false).Return(mockChannel(helpers.Result{}))
+	suite.mockEventRepositoryCommand.On("UpdateOneEventStatus", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockOutboxRepositoryCommand.On("InsertManyOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrTeamViewer() {
+	payload := request.CancelEventReq{
+		EventId: "id",
+		UserId:  "viewerId",
+	}
+
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId:     "id",
+			OrganizerId: "organizerId",
+			CreatedBy:   "viewerId",
+		},
+	}
+
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.CancelEvent(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOnlineTicketConfigErrNotTeamEditor() {
+	payload := request.OnlineTicketReq{
+		OrganizerId: "organizerId",
+		UserId:      "viewerId",
+		Tag:         "tag",
+		TotalQuota:  10,
+	}
+
+	_, err := suite.usecase.CreateOnlineTicketConfig(suite.ctx, payload)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+	suite.mockEventRepositoryQuery.AssertNotCalled(suite.T(), "FindEventByTag", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfigTeamEditor() {
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:         "tag",
+			OrganizerId: "organizerId",
+			Version:     2,
+			CreatedBy:   "userId",
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+	suite.mockEventRepositoryQuery.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryCommand.On("DeleteOneOnlineTicketConfig", mock.Anything, "tag").Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockTicketRepositoryCommand.On("InsertOneOnlineTicketConfigHistory", mock.Anything, mock.MatchedBy(func(history ticketEntity.OnlineTicketConfigHistory) bool {
+		return history.OrganizerId == "organizerId" && history.ChangedBy == "editorId"
+	})).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "editorId"})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDeleteOnlineTicketConfigErrTeamViewer() {
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:         "tag",
+			OrganizerId: "organizerId",
+			CreatedBy:   "userId",
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+
+	_, err := suite.usecase.DeleteOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "viewerId"})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+}
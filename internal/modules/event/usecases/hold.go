@@ -0,0 +1,272 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+
+	"event-service/internal/modules/event/models/entity"
+	"event-service/internal/modules/event/models/request"
+	"event-service/internal/modules/event/models/response"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+
+	ticketEntity "event-service/internal/modules/ticket/models/entity"
+)
+
+// ticketHoldTTL is how long held units stay reserved before they go back to the stock.
+const ticketHoldTTL = 10 * time.Minute
+
+func (c commandUsecase) HoldTicket(origCtx context.Context, payload request.HoldTicketReq) (*response.TicketHold, error) {
+	domain := "eventUsecase-HoldTicket"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	ticketData := <-c.ticketRepositoryQuery.FindTicketById(ctx, payload.TicketId)
+	if ticketData.Error != nil {
+		return nil, ticketData.Error
+	}
+
+	if ticketData.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	ticket, ok := ticketData.Data.(*ticketEntity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal ticket")
+	}
+
+	eventData := <-c.eventRepositoryQuery.FindEventById(ctx, ticket.EventId)
+	if eventData.Error != nil {
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal event")
+	}
+
+	now := time.Now()
+	if !isTicketSalesOpen(event, ticket, now) {
+		return nil, errors.Conflict("ticket is not on sale")
+	}
+
+	countryLeft, err := c.onlineTicketCountryLeft(ctx, ticket, payload.CountryCode)
+	if err != nil {
+		return nil, err
+	}
+
+	hold := ticketEntity.TicketHold{
+		HoldId:      uuid.New().String(),
+		TicketId:    ticket.TicketId,
+		EventId:     ticket.EventId,
+		UserId:      payload.UserId,
+		Quantity:    payload.Quantity,
+		CountryCode: payload.CountryCode,
+		ExpiresAt:   now.Add(ticketHoldTTL),
+	}
+
+	respHold := <-c.ticketRepositoryStock.HoldStock(ctx, hold, countryLeft, now)
+	if respHold.Error != nil {
+		return nil, respHold.Error
+	}
+
+	// the first hold of a ticket seeds its redis stock from mongodb
+	if respHold.Data == nil {
+		respStock := <-c.ticketRepositoryStock.InitStock(ctx, ticket.TicketId, ticket.TotalRemaining)
+		if respStock.Error != nil {
+			return nil, respStock.Error
+		}
+
+		respHold = <-c.ticketRepositoryStock.HoldStock(ctx, hold, countryLeft, now)
+		if respHold.Error != nil {
+			return nil, respHold.Error
+		}
+
+		if respHold.Data == nil {
+			return nil, errors.InternalServerError("failed init ticket stock")
+		}
+	}
+
+	return &response.TicketHold{
+		HoldId:    hold.HoldId,
+		TicketId:  hold.TicketId,
+		EventId:   hold.EventId,
+		Quantity:  hold.Quantity,
+		ExpiresAt: hold.ExpiresAt,
+	}, nil
+}
+
+// onlineTicketCountryLeft is how many units of an online ticket the country of the buyer may still hold, the quota of
+// the country minus its sales in the online ticket config of the tag, read from the master. It is -1 for the tickets
+// without a country quota.
+func (c commandUsecase) onlineTicketCountryLeft(ctx context.Context, ticket *ticketEntity.Ticket, countryCode string) (int, error) {
+	if ticket.TicketType != constants.Online || ticket.Tag == "" {
+		return -1, nil
+	}
+
+	configData := <-c.ticketRepositoryCommand.FindOnlineTicketConfigByTag(ctx, ticket.Tag)
+	if configData.Error != nil {
+		return 0, configData.Error
+	}
+
+	if configData.Data == nil {
+		return -1, nil
+	}
+
+	config, ok := configData.Data.(*ticketEntity.OnlineTicketConfig)
+	if !ok {
+		return 0, errors.InternalServerError("failed marshal online ticket config")
+	}
+
+	if countryCode == "" {
+		return 0, errors.BadRequest("set the country of your profile to buy online tickets")
+	}
+
+	for _, v := range config.CountryList {
+		if v.CountryCode == countryCode {
+			if v.Sold >= v.Quota {
+				return 0, errors.Conflict("not enough tickets left for your country")
+			}
+			return v.Quota - v.Sold, nil
+		}
+	}
+	return 0, errors.BadRequest(fmt.Sprintf("online ticket is not sold in country %s", countryCode))
+}
+
+func (c commandUsecase) ConfirmTicketHold(origCtx context.Context, payload request.TicketHoldReq) (*string, error) {
+	domain := "eventUsecase-ConfirmTicketHold"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if err := c.confirmHold(ctx, payload.TicketId, payload.HoldId, payload.UserId); err != nil {
+		return nil, err
+	}
+
+	rs := "Success confirm ticket hold"
+	return &rs, nil
+}
+
+// confirmHold turns a hold into a sale, for the buyer through the api or for the order service once the order is paid.
+func (c commandUsecase) confirmHold(ctx context.Context, ticketId string, holdId string, userId string) error {
+	respHold := <-c.ticketRepositoryStock.ConfirmHold(ctx, ticketId, holdId, userId, time.Now())
+	if respHold.Error != nil {
+		return respHold.Error
+	}
+
+	hold, ok := respHold.Data.(*ticketEntity.TicketHold)
+	if !ok {
+		return errors.InternalServerError("failed marshal ticket hold")
+	}
+	c.countOnlineTicketSold(ctx, ticketId, hold.CountryCode, hold.Quantity)
+	return nil
+}
+
+// countOnlineTicketSold adds a confirmed sale of an online ticket to the country of its buyer in the online ticket
+// config of its tag. The sale is already taken in redis, a failure is only logged.
+func (c commandUsecase) countOnlineTicketSold(ctx context.Context, ticketId string, countryCode string, quantity int) {
+	ticketData := <-c.ticketRepositoryQuery.FindTicketById(ctx, ticketId)
+	if ticketData.Error != nil {
+		c.logger.Error(ctx, fmt.Sprintf("Failed find sold ticket, ticketId : %s", ticketId), ticketData.Error.Error())
+		return
+	}
+
+	ticket, ok := ticketData.Data.(*ticketEntity.Ticket)
+	if !ok || ticket == nil || ticket.TicketType != constants.Online || ticket.Tag == "" || countryCode == "" || quantity <= 0 {
+		return
+	}
+
+	respSold := <-c.ticketRepositoryCommand.IncOnlineTicketSold(ctx, ticket.Tag, countryCode, quantity)
+	if respSold.Error != nil {
+		c.logger.Error(ctx, fmt.Sprintf("Failed count online ticket sold, ticketId : %s", ticketId), respSold.Error.Error())
+	}
+}
+
+func (c commandUsecase) ReleaseTicketHold(origCtx context.Context, payload request.TicketHoldReq) (*string, error) {
+	domain := "eventUsecase-ReleaseTicketHold"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	respHold := <-c.ticketRepositoryStock.ReleaseHold(ctx, payload.TicketId, payload.HoldId, payload.UserId, time.Now())
+	if respHold.Error != nil {
+		return nil, respHold.Error
+	}
+
+	rs := "Success release ticket hold"
+	return &rs, nil
+}
+
+// ReconcileTicketStock releases expired holds and writes the redis stock back to totalRemaining. Held units still
+// count as remaining until they are confirmed.
+func (c commandUsecase) ReconcileTicketStock(origCtx context.Context) error {
+	domain := "eventUsecase-ReconcileTicketStock"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	idsData := <-c.ticketRepositoryStock.FindStockTicketIds(ctx)
+	if idsData.Error != nil {
+		return idsData.Error
+	}
+
+	ticketIds, ok := idsData.Data.([]string)
+	if !ok {
+		return errors.InternalServerError("failed marshal ticket ids")
+	}
+
+	for _, ticketId := range ticketIds {
+		stockData := <-c.ticketRepositoryStock.SyncStock(ctx, ticketId, time.Now())
+		if stockData.Error != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed sync ticket stock, ticketId : %s", ticketId), stockData.Error.Error())
+			continue
+		}
+
+		// the counter was evicted, the next hold seeds it again from mongodb
+		if stockData.Data == nil {
+			<-c.ticketRepositoryStock.RemoveStockTicketId(ctx, ticketId)
+			continue
+		}
+
+		stock, ok := stockData.Data.(*ticketEntity.TicketStock)
+		if !ok {
+			return errors.InternalServerError("failed marshal ticket stock")
+		}
+
+		ticketData := <-c.ticketRepositoryQuery.FindTicketById(ctx, ticketId)
+		if ticketData.Error != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed find ticket to reconcile, ticketId : %s", ticketId), ticketData.Error.Error())
+			continue
+		}
+
+		ticket, ok := ticketData.Data.(*ticketEntity.Ticket)
+		if !ok || ticket == nil || ticket.TotalRemaining == stock.Available+stock.Held {
+			continue
+		}
+
+		// a quota update or a sale written since the ticket was read wins, the next run reconciles it
+		respTicket := <-c.ticketRepositoryCommand.UpdateOneTicketRemaining(ctx, ticketId, ticket.TotalRemaining, stock.Available+stock.Held)
+		if respTicket.Error != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed reconcile ticket stock, ticketId : %s", ticketId), respTicket.Error.Error())
+		}
+	}
+
+	return nil
+}
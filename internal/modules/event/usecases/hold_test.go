@@ -0,0 +1,304 @@
+package usecases_test
+
+import (
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	"net/http"
+	"time"
+
+	eventEntity "event-service/internal/modules/event/models/entity"
+	"event-service/internal/modules/event/models/request"
+	ticketEntity "event-service/internal/modules/ticket/models/entity"
+	"event-service/internal/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *CommandUsecaseTestSuite) mockTicketOnSale(isSellable bool) {
+	mockTicket := helpers.Result{
+		Data: &ticketEntity.Ticket{
+			TicketId:       "ticket1",
+			EventId:        "id",
+			TotalQuota:     10,
+			TotalRemaining: 8,
+			IsSellable:     isSellable,
+		},
+	}
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId: "id",
+			Status:  constants.EventStatusPublished,
+		},
+	}
+
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").Return(mockChannel(mockTicket))
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicket() {
+	suite.mockTicketOnSale(true)
+	suite.mockTicketRepositoryStock.On("HoldStock", mock.Anything, mock.MatchedBy(func(hold ticketEntity.TicketHold) bool {
+		return hold.TicketId == "ticket1" && hold.UserId == "userId" && hold.Quantity == 2 && hold.HoldId != "" &&
+			hold.ExpiresAt.After(time.Now())
+	}), -1, mock.Anything).Return(mockChannel(helpers.Result{Data: int64(6)}))
+
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 2, UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "ticket1", result.TicketId)
+	assert.Equal(suite.T(), 2, result.Quantity)
+	assert.NotEmpty(suite.T(), result.HoldId)
+	suite.mockTicketRepositoryStock.AssertNotCalled(suite.T(), "InitStock", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicketInitStock() {
+	suite.mockTicketOnSale(true)
+	suite.mockTicketRepositoryStock.On("HoldStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{})).Once()
+	suite.mockTicketRepositoryStock.On("InitStock", mock.Anything, "ticket1", 8).Return(mockChannel(helpers.Result{Data: true}))
+	suite.mockTicketRepositoryStock.On("HoldStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: int64(6)})).Once()
+
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 2, UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result)
+	suite.mockTicketRepositoryStock.AssertNumberOfCalls(suite.T(), "HoldStock", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicketErrSoldOut() {
+	suite.mockTicketOnSale(true)
+	suite.mockTicketRepositoryStock.On("HoldStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.Conflict("not enough tickets left")}))
+
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 20, UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicketErrNotOnSale() {
+	suite.mockTicketOnSale(false)
+
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 2, UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockTicketRepositoryStock.AssertNotCalled(suite.T(), "HoldStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicketErrNotFound() {
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 2, UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicketErrInitStock() {
+	suite.mockTicketOnSale(true)
+	suite.mockTicketRepositoryStock.On("HoldStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryStock.On("InitStock", mock.Anything, "ticket1", 8).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error redis")}))
+
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 2, UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) mockOnlineTicketOnSale(sold int) {
+	mockTicket := helpers.Result{
+		Data: &ticketEntity.Ticket{
+			TicketId:       "ticket1",
+			EventId:        "id",
+			TicketType:     constants.Online,
+			Tag:            "tag",
+			Country:        ticketEntity.Country{Code: "SG"},
+			TotalQuota:     10,
+			TotalRemaining: 8,
+			IsSellable:     true,
+		},
+	}
+	mockEvent := helpers.Result{
+		Data: &eventEntity.Event{
+			EventId: "id",
+			Status:  constants.EventStatusPublished,
+		},
+	}
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag: "tag",
+			CountryList: []ticketEntity.CountryList{
+				{CountryCode: "ID", Quota: 5, Sold: sold},
+				{CountryCode: "SG", Quota: 5},
+			},
+		},
+	}
+
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").Return(mockChannel(mockTicket))
+	suite.mockEventRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryCommand.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicketOnlineCountryQuota() {
+	suite.mockOnlineTicketOnSale(3)
+	suite.mockTicketRepositoryStock.On("HoldStock", mock.Anything, mock.MatchedBy(func(hold ticketEntity.TicketHold) bool {
+		return hold.CountryCode == "ID" && hold.Quantity == 2
+	}), 2, mock.Anything).Return(mockChannel(helpers.Result{Data: int64(6)}))
+
+	// the quota of the buyer country counts, not the one of the venue
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 2, UserId: "userId", CountryCode: "ID"})
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result)
+	suite.mockTicketRepositoryStock.AssertNumberOfCalls(suite.T(), "HoldStock", 1)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicketOnlineErrCountrySoldOut() {
+	suite.mockOnlineTicketOnSale(5)
+
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 1, UserId: "userId", CountryCode: "ID"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+	suite.mockTicketRepositoryStock.AssertNotCalled(suite.T(), "HoldStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicketOnlineErrCountryNotListed() {
+	suite.mockOnlineTicketOnSale(0)
+
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 1, UserId: "userId", CountryCode: "MY"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+	suite.mockTicketRepositoryStock.AssertNotCalled(suite.T(), "HoldStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldTicketOnlineErrNoCountry() {
+	suite.mockOnlineTicketOnSale(0)
+
+	result, err := suite.usecase.HoldTicket(suite.ctx, request.HoldTicketReq{TicketId: "ticket1", Quantity: 1, UserId: "userId", CountryCode: ""})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+	suite.mockTicketRepositoryStock.AssertNotCalled(suite.T(), "HoldStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestConfirmTicketHold() {
+	suite.mockTicketRepositoryStock.On("ConfirmHold", mock.Anything, "ticket1", "hold1", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.TicketHold{Quantity: 2, CountryCode: "ID"}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{TicketId: "ticket1", TicketType: constants.Gold, Tag: "tag"}}))
+
+	result, err := suite.usecase.ConfirmTicketHold(suite.ctx, request.TicketHoldReq{TicketId: "ticket1", HoldId: "hold1", UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success confirm ticket hold", *result)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "IncOnlineTicketSold", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestConfirmTicketHoldOnline() {
+	suite.mockTicketRepositoryStock.On("ConfirmHold", mock.Anything, "ticket1", "hold1", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.TicketHold{Quantity: 2, CountryCode: "ID"}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{
+			TicketId: "ticket1", TicketType: constants.Online, Tag: "tag", Country: ticketEntity.Country{Code: "SG"},
+		}}))
+	suite.mockTicketRepositoryCommand.On("IncOnlineTicketSold", mock.Anything, "tag", "ID", 2).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.OnlineTicketConfig{Tag: "tag"}}))
+
+	result, err := suite.usecase.ConfirmTicketHold(suite.ctx, request.TicketHoldReq{TicketId: "ticket1", HoldId: "hold1", UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success confirm ticket hold", *result)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "IncOnlineTicketSold", mock.Anything, "tag", "ID", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestConfirmTicketHoldOnlineErrSold() {
+	suite.mockTicketRepositoryStock.On("ConfirmHold", mock.Anything, "ticket1", "hold1", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.TicketHold{Quantity: 2, CountryCode: "ID"}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{
+			TicketId: "ticket1", TicketType: constants.Online, Tag: "tag", Country: ticketEntity.Country{Code: "SG"},
+		}}))
+	suite.mockTicketRepositoryCommand.On("IncOnlineTicketSold", mock.Anything, "tag", "ID", 2).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// the units are sold in redis already, the confirmation still succeeds
+	result, err := suite.usecase.ConfirmTicketHold(suite.ctx, request.TicketHoldReq{TicketId: "ticket1", HoldId: "hold1", UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success confirm ticket hold", *result)
+	suite.mockLogger.AssertNumberOfCalls(suite.T(), "Error", 1)
+}
+
+func (suite *CommandUsecaseTestSuite) TestConfirmTicketHoldErr() {
+	suite.mockTicketRepositoryStock.On("ConfirmHold", mock.Anything, "ticket1", "hold1", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.NotFound("hold not found or expired")}))
+
+	result, err := suite.usecase.ConfirmTicketHold(suite.ctx, request.TicketHoldReq{TicketId: "ticket1", HoldId: "hold1", UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReleaseTicketHold() {
+	suite.mockTicketRepositoryStock.On("ReleaseHold", mock.Anything, "ticket1", "hold1", "userId", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.TicketHold{Quantity: 2}}))
+
+	result, err := suite.usecase.ReleaseTicketHold(suite.ctx, request.TicketHoldReq{TicketId: "ticket1", HoldId: "hold1", UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success release ticket hold", *result)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReleaseTicketHoldErr() {
+	suite.mockTicketRepositoryStock.On("ReleaseHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.ForbiddenError("hold belongs to another user")}))
+
+	result, err := suite.usecase.ReleaseTicketHold(suite.ctx, request.TicketHoldReq{TicketId: "ticket1", HoldId: "hold1", UserId: "other"})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReconcileTicketStock() {
+	suite.mockTicketRepositoryStock.On("FindStockTicketIds", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: []string{"ticket1", "ticket2", "ticket3"}}))
+	suite.mockTicketRepositoryStock.On("SyncStock", mock.Anything, "ticket1", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.TicketStock{TicketId: "ticket1", Available: 3, Held: 2}}))
+	suite.mockTicketRepositoryStock.On("SyncStock", mock.Anything, "ticket2", mock.Anything).
+		Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryStock.On("SyncStock", mock.Anything, "ticket3", mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error redis")}))
+	suite.mockTicketRepositoryStock.On("RemoveStockTicketId", mock.Anything, "ticket2").Return(mockChannel(helpers.Result{}))
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{TicketId: "ticket1", TotalRemaining: 6}}))
+	suite.mockTicketRepositoryCommand.On("UpdateOneTicketRemaining", mock.Anything, "ticket1", 6, 5).Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.ReconcileTicketStock(suite.ctx)
+	assert.NoError(suite.T(), err)
+	suite.mockTicketRepositoryCommand.AssertNumberOfCalls(suite.T(), "UpdateOneTicketRemaining", 1)
+	suite.mockTicketRepositoryStock.AssertCalled(suite.T(), "RemoveStockTicketId", mock.Anything, "ticket2")
+	suite.mockLogger.AssertNumberOfCalls(suite.T(), "Error", 1)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReconcileTicketStockUnchanged() {
+	suite.mockTicketRepositoryStock.On("FindStockTicketIds", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: []string{"ticket1"}}))
+	suite.mockTicketRepositoryStock.On("SyncStock", mock.Anything, "ticket1", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.TicketStock{TicketId: "ticket1", Available: 3, Held: 2}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticket1").
+		Return(mockChannel(helpers.Result{Data: &ticketEntity.Ticket{TicketId: "ticket1", TotalRemaining: 5}}))
+
+	err := suite.usecase.ReconcileTicketStock(suite.ctx)
+	assert.NoError(suite.T(), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOneTicketRemaining", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReconcileTicketStockErr() {
+	suite.mockTicketRepositoryStock.On("FindStockTicketIds", mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error redis")}))
+
+	err := suite.usecase.ReconcileTicketStock(suite.ctx)
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"event-service/internal/modules/event/models/entity"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	"fmt"
+	"strings"
+	"time"
+
+	ticketEntity "event-service/internal/modules/ticket/models/entity"
+)
+
+const dateTimeLayout = "2006-01-02 15:04"
+
+// eventTransitions lists, for every event status, the statuses it may move to.
+var eventTransitions = map[string][]string{
+	constants.EventStatusDraft:       {constants.EventStatusScheduled, constants.EventStatusPublished, constants.EventStatusCancelled},
+	constants.EventStatusScheduled:   {constants.EventStatusDraft, constants.EventStatusPublished, constants.EventStatusCancelled},
+	constants.EventStatusPublished:   {constants.EventStatusSalesClosed, constants.EventStatusCancelled},
+	constants.EventStatusSalesClosed: {constants.EventStatusFinished, constants.EventStatusCancelled},
+	constants.EventStatusFinished:    {},
+	constants.EventStatusCancelled:   {},
+}
+
+// eventStatus returns the lifecycle status of an event. Events stored before the
+// lifecycle existed have no status and were public, so they count as published.
+func eventStatus(event *entity.Event) string {
+	return helpers.CustomIfEmpty(event.Status, constants.EventStatusPublished)
+}
+
+// eventVersion is the version the event was read with, take it before the event is changed.
+func eventVersion(event *entity.Event) entity.EventVersion {
+	return entity.EventVersion{Status: event.Status, UpdatedAt: event.UpdatedAt}
+}
+
+// checkEventWrite maps the result of a conditional event write. Nothing matched means the event was changed since it
+// was read, so the caller retries on fresh data instead of writing over that change.
+func checkEventWrite(resp helpers.Result, failedMsg string) error {
+	if resp.Error != nil {
+		return errors.InternalServerError(failedMsg)
+	}
+	if resp.Count == 0 {
+		return errors.Conflict("event was changed meanwhile, please retry")
+	}
+	return nil
+}
+
+func transitionEvent(event *entity.Event, to string) error {
+	if err := checkTransition(event, to); err != nil {
+		return err
+	}
+	event.Status = to
+	return nil
+}
+
+func checkTransition(event *entity.Event, to string) error {
+	from := eventStatus(event)
+	for _, next := range eventTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return errors.InvalidStateTransition(from, to)
+}
+
+// isEventPublic reports whether the event is shown to everyone, drafts and scheduled events are only shown to the
+// ones managing them.
+func isEventPublic(event *entity.Event) bool {
+	status := eventStatus(event)
+	return status != constants.EventStatusDraft && status != constants.EventStatusScheduled
+}
+
+func isEventEditable(event *entity.Event) bool {
+	status := eventStatus(event)
+	return status != constants.EventStatusFinished && status != constants.EventStatusCancelled
+}
+
+// isBankRequested reports whether the bank service has been asked to create the event tickets, which happens on publish.
+func isBankRequested(event *entity.Event) bool {
+	status := eventStatus(event)
+	return status == constants.EventStatusPublished || status == constants.EventStatusSalesClosed
+}
+
+// canViewAll reports whether the role reads the data of every organizer, support looks into it to help users.
+func canViewAll(userRole string) bool {
+	return userRole == constants.RoleAdmin || userRole == constants.RoleSupport
+}
+
+// validateEventCompleteness checks every field a fan needs to see before the event can go public.
+func validateEventCompleteness(event *entity.Event) error {
+	missing := make([]string, 0)
+	if event.Name == "" {
+		missing = append(missing, "name")
+	}
+	if event.DateTime.IsZero() {
+		missing = append(missing, "dateTime")
+	}
+	if event.ContinentCode == "" {
+		missing = append(missing, "continentCode")
+	}
+	if event.Country.Code == "" || event.Country.City == "" || event.Country.Place == "" {
+		missing = append(missing, "country")
+	}
+	if event.Description == "" {
+		missing = append(missing, "description")
+	}
+	if event.Tag == "" {
+		missing = append(missing, "tag")
+	}
+	if event.EventUrl == "" {
+		missing = append(missing, "eventUrl")
+	}
+	if len(event.TicketIds) == 0 {
+		missing = append(missing, "tickets")
+	}
+	if len(missing) > 0 {
+		return errors.BadRequest("event is incomplete, missing: " + strings.Join(missing, ", "))
+	}
+
+	if !event.DateTime.After(time.Now()) {
+		return errors.BadRequest("event dateTime must be in the future")
+	}
+	return nil
+}
+
+// parseOptionalTime parses an optional 'YYYY-MM-DD HH:MM' field, an empty value yields the zero time.
+func parseOptionalTime(field string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(dateTimeLayout, value)
+	if err != nil {
+		return time.Time{}, errors.BadRequest(fmt.Sprintf("Format %s must be 'YYYY-MM-DD HH:MM'", field))
+	}
+	return parsed, nil
+}
+
+func parseSchedule(publishAt, salesStartAt, salesEndAt string) (time.Time, time.Time, time.Time, error) {
+	publish, err := parseOptionalTime("publishAt", publishAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, time.Time{}, err
+	}
+	salesStart, err := parseOptionalTime("salesStartAt", salesStartAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, time.Time{}, err
+	}
+	salesEnd, err := parseOptionalTime("salesEndAt", salesEndAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, time.Time{}, err
+	}
+	return publish, salesStart, salesEnd, nil
+}
+
+// validateSchedule checks that publish and sales times are ordered: publishAt <= salesStartAt < salesEndAt <= dateTime.
+func validateSchedule(dateTime, publishAt, salesStartAt, salesEndAt time.Time) error {
+	if !publishAt.IsZero() && !publishAt.Before(dateTime) {
+		return errors.BadRequest("publishAt must be before dateTime")
+	}
+	if !publishAt.IsZero() && !salesStartAt.IsZero() && salesStartAt.Before(publishAt) {
+		return errors.BadRequest("salesStartAt must not be before publishAt")
+	}
+	if !salesStartAt.IsZero() && !salesEndAt.IsZero() && !salesStartAt.Before(salesEndAt) {
+		return errors.BadRequest("salesStartAt must be before salesEndAt")
+	}
+	if !salesEndAt.IsZero() && salesEndAt.After(dateTime) {
+		return errors.BadRequest("salesEndAt must not be after dateTime")
+	}
+	return nil
+}
+
+func isWithinWindow(now, start, end time.Time) bool {
+	if !start.IsZero() && now.Before(start) {
+		return false
+	}
+	if !end.IsZero() && !now.Before(end) {
+		return false
+	}
+	return true
+}
+
+// isEventSalesOpen reports whether fans can buy tickets for the event at the given time.
+func isEventSalesOpen(event *entity.Event, now time.Time) bool {
+	if eventStatus(event) != constants.EventStatusPublished {
+		return false
+	}
+	return isWithinWindow(now, event.SalesStartAt, event.SalesEndAt)
+}
+
+// isTicketSalesOpen also honours the ticket type's own sales window on top of the event's.
+func isTicketSalesOpen(event *entity.Event, ticket *ticketEntity.Ticket, now time.Time) bool {
+	return ticket.IsSellable && isEventSalesOpen(event, now) && isWithinWindow(now, ticket.SalesStartAt, ticket.SalesEndAt)
+}
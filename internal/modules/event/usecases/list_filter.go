@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"event-service/internal/modules/event/models/entity"
+	"event-service/internal/modules/event/models/request"
+	ticketEntity "event-service/internal/modules/ticket/models/entity"
+	"event-service/internal/pkg/errors"
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// toEventFilter maps the list request to a repository filter, the to date includes its whole day.
+// A limit switches the list to cursor pagination.
+func toEventFilter(payload request.AllEventReq) (entity.EventFilter, error) {
+	filter := entity.EventFilter{
+		Search:           payload.Search,
+		ContinentCode:    payload.ContinentCode,
+		CountryCode:      payload.CountryCode,
+		City:             payload.City,
+		Tag:              payload.Tag,
+		IncludeCancelled: payload.IncludeCancelled,
+		SortBy:           payload.SortBy,
+		Page:             payload.Page,
+		Size:             payload.Size,
+	}
+	if payload.Limit > 0 {
+		if payload.Search != "" && payload.SortBy == "" {
+			return filter, errors.BadRequest("cursor pagination of a search requires sortBy")
+		}
+		filter.Keyset = true
+		filter.Page = 0
+		filter.Size = payload.Limit
+		filter.Cursor = payload.Cursor
+		filter.WithCount = payload.WithCount
+	}
+
+	if payload.From != "" {
+		from, err := time.Parse(dateLayout, payload.From)
+		if err != nil {
+			return filter, errors.BadRequest("Format from must be 'YYYY-MM-DD'")
+		}
+		filter.From = from
+	}
+	if payload.To != "" {
+		to, err := time.Parse(dateLayout, payload.To)
+		if err != nil {
+			return filter, errors.BadRequest("Format to must be 'YYYY-MM-DD'")
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return filter, errors.BadRequest("from must not be after to")
+	}
+
+	return filter, nil
+}
+
+func hasTicketFilter(payload request.AllEventReq) bool {
+	return payload.TicketType != "" || payload.MinPrice > 0 || payload.MaxPrice > 0 || payload.Available
+}
+
+// findEventIdsByTicket lists the events offering a ticket that matches the request's ticket filters.
+func (q queryUsecase) findEventIdsByTicket(ctx context.Context, payload request.AllEventReq) ([]string, error) {
+	resp := <-q.ticketRepositoryQuery.FindEventIdsByTicket(ctx, ticketEntity.TicketFilter{
+		TicketType: payload.TicketType,
+		MinPrice:   payload.MinPrice,
+		MaxPrice:   payload.MaxPrice,
+		Available:  payload.Available,
+	})
+	if resp.Error != nil {
+		msg := "Error query ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	eventIds := make([]string, 0)
+	if resp.Data == nil {
+		return eventIds, nil
+	}
+
+	tickets, ok := resp.Data.(*[]ticketEntity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	for _, value := range *tickets {
+		eventIds = append(eventIds, value.EventId)
+	}
+	return eventIds, nil
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+
+	"event-service/internal/modules/event"
+	"event-service/internal/modules/event/models/entity"
+	"event-service/internal/modules/event/models/response"
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/ticket"
+	ticketEntity "event-service/internal/modules/ticket/models/entity"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+)
+
+func findOnlineTicketConfig(ctx context.Context, ticketRepositoryQuery ticket.MongodbRepositoryQuery, tag string) (*ticketEntity.OnlineTicketConfig, error) {
+	configData := <-ticketRepositoryQuery.FindOnlineTicketConfigByTag(ctx, tag)
+	if configData.Error != nil {
+		return nil, configData.Error
+	}
+
+	if configData.Data == nil {
+		return nil, errors.NotFound("online ticket config not found")
+	}
+
+	config, ok := configData.Data.(*ticketEntity.OnlineTicketConfig)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal online ticket config")
+	}
+	return config, nil
+}
+
+// checkOnlineTicketConfigOwner applies the tag ownership rule of CreateOnlineTicketConfig to an existing config: the
+// user must hold one of roles in the organizer of the config and of the events using its tag. Admins manage every config.
+func checkOnlineTicketConfigOwner(ctx context.Context, eventRepositoryQuery event.MongodbRepositoryQuery,
+	organizerRepositoryQuery organizer.MongodbRepositoryQuery, config *ticketEntity.OnlineTicketConfig, userId string,
+	userRole string, roles ...string) error {
+	if userRole == constants.RoleAdmin {
+		return nil
+	}
+
+	allowed, err := checkTeamAccess(ctx, organizerRepositoryQuery, config.OrganizerId, config.CreatedBy, userId, userRole, roles...)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.ForbiddenError("you are not allowed to manage this online ticket config")
+	}
+
+	eventTagData := <-eventRepositoryQuery.FindEventByTag(ctx, config.Tag)
+	if eventTagData.Error != nil {
+		return eventTagData.Error
+	}
+
+	if eventTagData.Data != nil {
+		eventTag, ok := eventTagData.Data.(*entity.Event)
+		if !ok {
+			return errors.InternalServerError("failed marshal event")
+		}
+
+		allowed, err := checkTeamAccess(ctx, organizerRepositoryQuery, eventTag.OrganizerId, eventTag.CreatedBy, userId, userRole, roles...)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return errors.ForbiddenError("you are not allowed to manage this online ticket config")
+		}
+	}
+	return nil
+}
+
+func newOnlineTicketConfigHistory(config ticketEntity.OnlineTicketConfig, action string) ticketEntity.OnlineTicketConfigHistory {
+	return ticketEntity.OnlineTicketConfigHistory{
+		Tag:         config.Tag,
+		OrganizerId: config.OrganizerId,
+		Version:     config.Version,
+		Action:      action,
+		TotalQuota:  config.TotalQuota,
+		CountryList: config.CountryList,
+		CreatedBy:   config.CreatedBy,
+		ChangedBy:   config.UpdatedBy,
+		ChangedAt:   config.UpdatedAt,
+	}
+}
+
+func toOnlineTicketConfigResp(config *ticketEntity.OnlineTicketConfig) response.OnlineTicketConfig {
+	return response.OnlineTicketConfig{
+		Tag:         config.Tag,
+		OrganizerId: config.OrganizerId,
+		Version:     config.Version,
+		TotalQuota:  config.TotalQuota,
+		CountryList: toCountryListResp(config.CountryList),
+		CreatedAt:   config.CreatedAt,
+		UpdatedAt:   config.UpdatedAt,
+		CreatedBy:   config.CreatedBy,
+		UpdatedBy:   config.UpdatedBy,
+	}
+}
+
+func toCountryListResp(countryList []ticketEntity.CountryList) []response.CountryList {
+	result := make([]response.CountryList, 0)
+	for _, value := range countryList {
+		result = append(result, response.CountryList(value))
+	}
+	return result
+}
@@ -6,36 +6,66 @@ import (
 	"event-service/internal/modules/event/models/entity"
 	"event-service/internal/modules/event/models/request"
 	"event-service/internal/modules/event/models/response"
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/ticket"
+	ticketEntity "event-service/internal/modules/ticket/models/entity"
 	"event-service/internal/pkg/errors"
 	"event-service/internal/pkg/helpers"
 	"event-service/internal/pkg/log"
 	"fmt"
+	"regexp"
 	"time"
 
 	"go.elastic.co/apm"
 )
 
 type queryUsecase struct {
-	eventRepositoryQuery event.MongodbRepositoryQuery
-	logger               log.Logger
+	eventRepositoryQuery     event.MongodbRepositoryQuery
+	ticketRepositoryQuery    ticket.MongodbRepositoryQuery
+	ticketRepositoryStock    ticket.RedisRepositoryStock
+	organizerRepositoryQuery organizer.MongodbRepositoryQuery
+	logger                   log.Logger
 }
 
-func NewQueryUsecase(emq event.MongodbRepositoryQuery, log log.Logger) event.UsecaseQuery {
+func NewQueryUsecase(emq event.MongodbRepositoryQuery, tmq ticket.MongodbRepositoryQuery, trs ticket.RedisRepositoryStock,
+	omq organizer.MongodbRepositoryQuery, log log.Logger) event.UsecaseQuery {
 	return queryUsecase{
-		eventRepositoryQuery: emq,
-		logger:               log,
+		eventRepositoryQuery:     emq,
+		ticketRepositoryQuery:    tmq,
+		ticketRepositoryStock:    trs,
+		organizerRepositoryQuery: omq,
+		logger:                   log,
 	}
 }
 
 func (q queryUsecase) FindEvents(origCtx context.Context, payload request.AllEventReq) (*response.EventResp, error) {
-	domain := "addressUsecase-FindCountries"
+	domain := "eventUsecase-FindEvents"
 	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
 		Start:  time.Now(),
 		Parent: apm.TraceContext{},
 	})
 	defer span.End()
 
-	resp := <-q.eventRepositoryQuery.FindAllEvent(ctx, payload)
+	filter, err := toEventFilter(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasTicketFilter(payload) {
+		eventIds, err := q.findEventIdsByTicket(ctx, payload)
+		if err != nil {
+			return nil, err
+		}
+		if len(eventIds) == 0 {
+			return &response.EventResp{
+				CollectionData: make([]response.Event, 0),
+				MetaData:       helpers.GenerateMetaData(0, 0, filter.Page, filter.Size),
+			}, nil
+		}
+		filter.EventIds = eventIds
+	}
+
+	resp := <-q.eventRepositoryQuery.FindAllEvent(ctx, filter)
 	if resp.Error != nil {
 		msg := "Error query event"
 		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
@@ -53,6 +83,12 @@ func (q queryUsecase) FindEvents(origCtx context.Context, payload request.AllEve
 		return nil, errors.InternalServerError("cannot parsing data")
 	}
 
+	var pattern *regexp.Regexp
+	if payload.Highlight {
+		pattern = highlightPattern(payload.Search)
+	}
+
+	now := time.Now()
 	var collectionData = make([]response.Event, 0)
 	for _, value := range *event {
 		collectionData = append(collectionData, response.Event{
@@ -65,13 +101,383 @@ func (q queryUsecase) FindEvents(origCtx context.Context, payload request.AllEve
 			Country:       response.Country(value.Country),
 			Description:   value.Description,
 			Tag:           value.Tag,
+			OrganizerId:   value.OrganizerId,
 			TicketIds:     value.TicketIds,
+			Status:        value.Status,
+			SalesStartAt:  value.SalesStartAt,
+			SalesEndAt:    value.SalesEndAt,
+			IsSalesOpen:   isEventSalesOpen(&value, now),
+			Score:         value.Score,
+			Highlights:    highlightEvent(pattern, value),
 		})
 	}
 
+	metaData := helpers.GenerateMetaData(resp.Count, int64(len(*event)), filter.Page, filter.Size)
+	metaData.NextCursor = resp.NextCursor
 	return &response.EventResp{
 		CollectionData: collectionData,
-		MetaData:       helpers.GenerateMetaData(resp.Count, int64(len(*event)), payload.Page, payload.Size),
+		MetaData:       metaData,
+	}, nil
+
+}
+
+func (q queryUsecase) FindEventDetail(origCtx context.Context, payload request.EventDetailReq) (*response.EventDetail, error) {
+	domain := "eventUsecase-FindEventDetail"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	eventData := <-q.eventRepositoryQuery.FindEventById(ctx, payload.EventId)
+	if eventData.Error != nil {
+		msg := "Error query event"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", eventData.Error))
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	// an event not published yet is not found for the public, its team previews it
+	if !isEventPublic(event) {
+		allowed, err := canManageEvent(ctx, q.organizerRepositoryQuery, event, payload.UserId, payload.UserRole)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, errors.NotFound("event not found")
+		}
+	}
+
+	ticketData := <-q.ticketRepositoryQuery.FindTicketsByEventId(ctx, event.EventId)
+	if ticketData.Error != nil {
+		msg := "Error query ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", ticketData.Error))
+		return nil, ticketData.Error
+	}
+
+	now := time.Now()
+	tickets := make([]response.Ticket, 0)
+	if ticketData.Data != nil {
+		ticketList, ok := ticketData.Data.(*[]ticketEntity.Ticket)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+
+		for _, value := range *ticketList {
+			tickets = append(tickets, response.Ticket{
+				TicketId:           value.TicketId,
+				TicketType:         value.TicketType,
+				TicketPrice:        value.TicketPrice,
+				TotalQuota:         value.TotalQuota,
+				TotalRemaining:     value.TotalRemaining,
+				IsSellable:         value.IsSellable,
+				SalesStartAt:       value.SalesStartAt,
+				SalesEndAt:         value.SalesEndAt,
+				IsSalesOpen:        isTicketSalesOpen(event, &value, now),
+				ProvisioningStatus: value.ProvisioningStatus,
+				ProvisioningError:  value.ProvisioningError,
+			})
+		}
+	}
+
+	configData := <-q.ticketRepositoryQuery.FindOnlineTicketConfigByTag(ctx, event.Tag)
+	if configData.Error != nil {
+		msg := "Error query online ticket config"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", configData.Error))
+		return nil, configData.Error
+	}
+
+	var onlineTicketConfig *response.OnlineTicketConfig
+	if configData.Data != nil {
+		config, ok := configData.Data.(*ticketEntity.OnlineTicketConfig)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+
+		resp := toOnlineTicketConfigResp(config)
+		onlineTicketConfig = &resp
+	}
+
+	return &response.EventDetail{
+		EventId:            event.EventId,
+		Name:               event.Name,
+		DateTime:           event.DateTime,
+		ContinentName:      event.ContinentName,
+		ContinentCode:      event.ContinentCode,
+		Country:            response.Country(event.Country),
+		Description:        event.Description,
+		Tag:                event.Tag,
+		OrganizerId:        event.OrganizerId,
+		EventUrl:           event.EventUrl,
+		Status:             event.Status,
+		CancelReason:       event.CancelReason,
+		PublishAt:          event.PublishAt,
+		SalesStartAt:       event.SalesStartAt,
+		SalesEndAt:         event.SalesEndAt,
+		IsSalesOpen:        isEventSalesOpen(event, now),
+		Tickets:            tickets,
+		OnlineTicketConfig: onlineTicketConfig,
+	}, nil
+}
+
+func (q queryUsecase) FindOnlineTicketAllocation(origCtx context.Context, tag string) (*response.OnlineTicketAllocation, error) {
+	domain := "eventUsecase-FindOnlineTicketAllocation"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	configData := <-q.ticketRepositoryQuery.FindOnlineTicketConfigByTag(ctx, tag)
+	if configData.Error != nil {
+		msg := "Error query online ticket config"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", configData.Error))
+		return nil, configData.Error
+	}
+
+	if configData.Data == nil {
+		return nil, errors.NotFound("online ticket config not found")
+	}
+
+	config, ok := configData.Data.(*ticketEntity.OnlineTicketConfig)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	allocationData := <-q.ticketRepositoryQuery.AggregateOnlineTicketAllocation(ctx, tag)
+	if allocationData.Error != nil {
+		msg := "Error aggregate online ticket allocation"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", allocationData.Error))
+		return nil, allocationData.Error
+	}
+
+	allocation, ok := allocationData.Data.(*[]ticketEntity.AggregateTotalTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	countryList := make([]response.CountryAllocation, 0)
+	for _, value := range *allocation {
+		countryList = append(countryList, response.CountryAllocation{
+			CountryCode: value.Id,
+			CountryName: value.CountryName,
+			Allocated:   value.TotalQuota,
+			Sold:        value.TotalSoldTicket,
+			Remaining:   value.TotalAvailableTicket,
+		})
+	}
+
+	return &response.OnlineTicketAllocation{
+		Tag:         config.Tag,
+		TotalQuota:  config.TotalQuota,
+		CountryList: countryList,
+	}, nil
+}
+
+// FindTicketAvailability reads the stock of a ticket from the redis counter, the holds that expired are released first.
+// A ticket without a counter has no pending hold, its remaining tickets in mongodb are all available.
+func (q queryUsecase) FindTicketAvailability(origCtx context.Context, ticketId string) (*response.TicketAvailability, error) {
+	domain := "eventUsecase-FindTicketAvailability"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	ticketData := <-q.ticketRepositoryQuery.FindTicketById(ctx, ticketId)
+	if ticketData.Error != nil {
+		msg := "Error query ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", ticketData.Error))
+		return nil, ticketData.Error
+	}
+
+	if ticketData.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	ticket, ok := ticketData.Data.(*ticketEntity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	eventData := <-q.eventRepositoryQuery.FindEventById(ctx, ticket.EventId)
+	if eventData.Error != nil {
+		msg := "Error query event"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", eventData.Error))
+		return nil, eventData.Error
+	}
+
+	if eventData.Data == nil {
+		return nil, errors.NotFound("event not found")
+	}
+
+	event, ok := eventData.Data.(*entity.Event)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	now := time.Now()
+	available, held := ticket.TotalRemaining, 0
+	stockData := <-q.ticketRepositoryStock.SyncStock(ctx, ticketId, now)
+	if stockData.Error != nil {
+		msg := "Error sync ticket stock"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", stockData.Error))
+		return nil, stockData.Error
+	}
+	if stockData.Data != nil {
+		stock, ok := stockData.Data.(*ticketEntity.TicketStock)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		available, held = stock.Available, stock.Held
+	}
+
+	return &response.TicketAvailability{
+		TicketId:    ticket.TicketId,
+		EventId:     ticket.EventId,
+		TicketType:  ticket.TicketType,
+		TotalQuota:  ticket.TotalQuota,
+		Available:   available,
+		Held:        held,
+		IsSalesOpen: isTicketSalesOpen(event, ticket, now),
+	}, nil
+}
+
+func (q queryUsecase) FindOnlineTicketConfig(origCtx context.Context, payload request.OnlineTicketConfigReq) (*response.OnlineTicketConfig, error) {
+	domain := "eventUsecase-FindOnlineTicketConfig"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	config, err := findOnlineTicketConfig(ctx, q.ticketRepositoryQuery, payload.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canViewAll(payload.UserRole) {
+		if err := checkOnlineTicketConfigOwner(ctx, q.eventRepositoryQuery, q.organizerRepositoryQuery, config,
+			payload.UserId, payload.UserRole, memberRoles...); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := toOnlineTicketConfigResp(config)
+	return &resp, nil
+}
+
+func (q queryUsecase) FindOnlineTicketConfigs(origCtx context.Context, payload request.AllOnlineTicketConfigReq) (*response.OnlineTicketConfigResp, error) {
+	domain := "eventUsecase-FindOnlineTicketConfigs"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	// members list the configs of their organizer, admins and support may look at any organizer
+	var respData <-chan helpers.Result
+	if payload.OrganizerId != "" {
+		if !canViewAll(payload.UserRole) {
+			allowed, err := checkTeamAccess(ctx, q.organizerRepositoryQuery, payload.OrganizerId, "", payload.UserId,
+				payload.UserRole, memberRoles...)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				return nil, errors.ForbiddenError("you are not allowed to list online ticket configs of this organizer")
+			}
+		}
+		respData = q.ticketRepositoryQuery.FindAllOnlineTicketConfigByOrganizer(ctx, payload.OrganizerId, payload.Page, payload.Size)
+	} else {
+		createdBy := payload.UserId
+		if payload.CreatedBy != "" && payload.CreatedBy != payload.UserId {
+			if !canViewAll(payload.UserRole) {
+				return nil, errors.ForbiddenError("you are not allowed to list online ticket configs of another user")
+			}
+			createdBy = payload.CreatedBy
+		}
+		respData = q.ticketRepositoryQuery.FindAllOnlineTicketConfig(ctx, createdBy, payload.Page, payload.Size)
+	}
+
+	resp := <-respData
+	if resp.Error != nil {
+		msg := "Error query online ticket config"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	configs, ok := resp.Data.(*[]ticketEntity.OnlineTicketConfig)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	collectionData := make([]response.OnlineTicketConfig, 0)
+	for i := range *configs {
+		collectionData = append(collectionData, toOnlineTicketConfigResp(&(*configs)[i]))
+	}
+
+	return &response.OnlineTicketConfigResp{
+		CollectionData: collectionData,
+		MetaData:       helpers.GenerateMetaData(resp.Count, int64(len(*configs)), payload.Page, payload.Size),
 	}, nil
+}
 
+func (q queryUsecase) FindOnlineTicketConfigHistory(origCtx context.Context, payload request.OnlineTicketConfigReq) ([]response.OnlineTicketConfigHistory, error) {
+	domain := "eventUsecase-FindOnlineTicketConfigHistory"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	historyData := <-q.ticketRepositoryQuery.FindOnlineTicketConfigHistory(ctx, payload.Tag)
+	if historyData.Error != nil {
+		msg := "Error query online ticket config history"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", historyData.Error))
+		return nil, historyData.Error
+	}
+
+	history, ok := historyData.Data.(*[]ticketEntity.OnlineTicketConfigHistory)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if len(*history) == 0 {
+		return nil, errors.NotFound("online ticket config history not found")
+	}
+
+	// the history outlives a deleted config, so ownership is checked on the latest snapshot
+	latest := (*history)[0]
+	if !canViewAll(payload.UserRole) {
+		if err := checkOnlineTicketConfigOwner(ctx, q.eventRepositoryQuery, q.organizerRepositoryQuery, &ticketEntity.OnlineTicketConfig{
+			Tag:         latest.Tag,
+			OrganizerId: latest.OrganizerId,
+			CreatedBy:   latest.CreatedBy,
+		}, payload.UserId, payload.UserRole, memberRoles...); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]response.OnlineTicketConfigHistory, 0)
+	for _, value := range *history {
+		result = append(result, response.OnlineTicketConfigHistory{
+			Tag:         value.Tag,
+			Version:     value.Version,
+			Action:      value.Action,
+			TotalQuota:  value.TotalQuota,
+			CountryList: toCountryListResp(value.CountryList),
+			ChangedBy:   value.ChangedBy,
+			ChangedAt:   value.ChangedAt,
+		})
+	}
+	return result, nil
 }
@@ -3,14 +3,21 @@ package usecases_test
 import (
 	"context"
 	"event-service/internal/modules/event"
+	"net/http"
 	"testing"
+	"time"
 
 	"event-service/internal/modules/event/models/entity"
 	"event-service/internal/modules/event/models/request"
 	uc "event-service/internal/modules/event/usecases"
+	organizerEntity "event-service/internal/modules/organizer/models/entity"
+	ticketEntity "event-service/internal/modules/ticket/models/entity"
+	"event-service/internal/pkg/constants"
 	"event-service/internal/pkg/errors"
 	"event-service/internal/pkg/helpers"
 	mockcert "event-service/mocks/modules/event"
+	mockcertOrganizer "event-service/mocks/modules/organizer"
+	mockcertTicket "event-service/mocks/modules/ticket"
 	mocklog "event-service/mocks/pkg/log"
 
 	"github.com/stretchr/testify/assert"
@@ -20,18 +27,30 @@ import (
 
 type QueryUsecaseTestSuite struct {
 	suite.Suite
-	mockOrderRepositoryQuery *mockcert.MongodbRepositoryQuery
-	mockLogger               *mocklog.Logger
-	usecase                  event.UsecaseQuery
-	ctx                      context.Context
+	mockOrderRepositoryQuery     *mockcert.MongodbRepositoryQuery
+	mockTicketRepositoryQuery    *mockcertTicket.MongodbRepositoryQuery
+	mockTicketRepositoryStock    *mockcertTicket.RedisRepositoryStock
+	mockOrganizerRepositoryQuery *mockcertOrganizer.MongodbRepositoryQuery
+	mockLogger                   *mocklog.Logger
+	usecase                      event.UsecaseQuery
+	ctx                          context.Context
 }
 
 func (suite *QueryUsecaseTestSuite) SetupTest() {
 	suite.mockOrderRepositoryQuery = &mockcert.MongodbRepositoryQuery{}
+	suite.mockTicketRepositoryQuery = &mockcertTicket.MongodbRepositoryQuery{}
+	suite.mockTicketRepositoryStock = &mockcertTicket.RedisRepositoryStock{}
+	suite.mockOrganizerRepositoryQuery = &mockcertOrganizer.MongodbRepositoryQuery{}
 	suite.mockLogger = &mocklog.Logger{}
 	suite.ctx = context.Background()
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(func(ctx context.Context, organizerId string) <-chan helpers.Result {
+		return mockChannel(mockOrganizer)
+	}).Maybe()
 	suite.usecase = uc.NewQueryUsecase(
 		suite.mockOrderRepositoryQuery,
+		suite.mockTicketRepositoryQuery,
+		suite.mockTicketRepositoryStock,
+		suite.mockOrganizerRepositoryQuery,
 		suite.mockLogger,
 	)
 }
@@ -54,7 +73,7 @@ func (suite *QueryUsecaseTestSuite) TestFindEvents() {
 		Error: nil,
 	}
 
-	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, payload).Return(mockChannel(mockAllEvent))
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, entity.EventFilter{Page: 1, Size: 1}).Return(mockChannel(mockAllEvent))
 
 	_, err := suite.usecase.FindEvents(suite.ctx, payload)
 	assert.NoError(suite.T(), err)
@@ -75,7 +94,7 @@ func (suite *QueryUsecaseTestSuite) TestFindEventsErr() {
 		Error: errors.BadRequest("error"),
 	}
 
-	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, payload).Return(mockChannel(mockAllEvent))
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, entity.EventFilter{Page: 1, Size: 1}).Return(mockChannel(mockAllEvent))
 	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
 
 	_, err := suite.usecase.FindEvents(suite.ctx, payload)
@@ -92,7 +111,7 @@ func (suite *QueryUsecaseTestSuite) TestFindEventsErrNil() {
 		Error: nil,
 	}
 
-	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, payload).Return(mockChannel(mockAllEvent))
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, entity.EventFilter{Page: 1, Size: 1}).Return(mockChannel(mockAllEvent))
 	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
 
 	_, err := suite.usecase.FindEvents(suite.ctx, payload)
@@ -111,14 +130,495 @@ func (suite *QueryUsecaseTestSuite) TestFindEventsErrParse() {
 		Error: nil,
 	}
 
-	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, payload).Return(mockChannel(mockAllEvent))
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, entity.EventFilter{Page: 1, Size: 1}).Return(mockChannel(mockAllEvent))
 	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
 
 	_, err := suite.usecase.FindEvents(suite.ctx, payload)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *QueryUsecaseTestSuite) TestFindEventsFilters() {
+	payload := request.AllEventReq{
+		Page:          1,
+		Size:          1,
+		Search:        "rock",
+		ContinentCode: "AS",
+		CountryCode:   "ID",
+		City:          "Jakarta",
+		Tag:           "tag",
+		From:          "2024-06-01",
+		To:            "2024-06-30",
+		SortBy:        constants.EventSortDate,
+	}
+	filter := entity.EventFilter{
+		Page:          1,
+		Size:          1,
+		Search:        "rock",
+		ContinentCode: "AS",
+		CountryCode:   "ID",
+		City:          "Jakarta",
+		Tag:           "tag",
+		From:          time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		To:            time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		SortBy:        constants.EventSortDate,
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, filter).Return(mockChannel(helpers.Result{Data: &[]entity.Event{}}))
+
+	_, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	suite.mockTicketRepositoryQuery.AssertNotCalled(suite.T(), "FindEventIdsByTicket", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsErrDateRange() {
+	payload := request.AllEventReq{
+		Page: 1,
+		Size: 1,
+		From: "2024-07-01",
+		To:   "2024-06-01",
+	}
+
+	_, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsErrDateFormat() {
+	payload := request.AllEventReq{
+		Page: 1,
+		Size: 1,
+		From: "01-07-2024",
+	}
+
+	_, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsTicketFilter() {
+	payload := request.AllEventReq{
+		Page:       1,
+		Size:       1,
+		TicketType: constants.Gold,
+		MinPrice:   100,
+		MaxPrice:   500,
+		Available:  true,
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{EventId: "id1"},
+			{EventId: "id2"},
+		},
+	}
+	filter := entity.EventFilter{Page: 1, Size: 1, EventIds: []string{"id1", "id2"}}
+
+	suite.mockTicketRepositoryQuery.On("FindEventIdsByTicket", mock.Anything, ticketEntity.TicketFilter{
+		TicketType: constants.Gold,
+		MinPrice:   100,
+		MaxPrice:   500,
+		Available:  true,
+	}).Return(mockChannel(mockTickets))
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, filter).Return(mockChannel(helpers.Result{
+		Data:  &[]entity.Event{{EventId: "id1"}},
+		Count: 1,
+	}))
+
+	result, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.CollectionData, 1)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsTicketFilterNoMatch() {
+	payload := request.AllEventReq{
+		Page:      1,
+		Size:      1,
+		Available: true,
+	}
+
+	suite.mockTicketRepositoryQuery.On("FindEventIdsByTicket", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &[]ticketEntity.Ticket{}}))
+
+	result, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.CollectionData)
+	assert.Equal(suite.T(), int64(0), result.MetaData.TotalData)
+	suite.mockOrderRepositoryQuery.AssertNotCalled(suite.T(), "FindAllEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsTicketFilterErr() {
+	payload := request.AllEventReq{
+		Page:       1,
+		Size:       1,
+		TicketType: constants.Gold,
+	}
+
+	suite.mockTicketRepositoryQuery.On("FindEventIdsByTicket", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsTicketFilterErrParse() {
+	payload := request.AllEventReq{
+		Page:     1,
+		Size:     1,
+		MinPrice: 100,
+	}
+
+	suite.mockTicketRepositoryQuery.On("FindEventIdsByTicket", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &entity.Event{}}))
+
+	_, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsCursor() {
+	payload := request.AllEventReq{
+		Limit:     2,
+		Cursor:    "cursor",
+		WithCount: true,
+	}
+	filter := entity.EventFilter{Keyset: true, Size: 2, Cursor: "cursor", WithCount: true}
+
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, filter).Return(mockChannel(helpers.Result{
+		Data:       &[]entity.Event{{EventId: "id1"}, {EventId: "id2"}},
+		Count:      5,
+		NextCursor: "next",
+	}))
+
+	result, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "next", result.MetaData.NextCursor)
+	assert.Equal(suite.T(), int64(2), result.MetaData.Count)
+	assert.Equal(suite.T(), int64(5), result.MetaData.TotalData)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsCursorLastPage() {
+	payload := request.AllEventReq{Limit: 2}
+
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, entity.EventFilter{Keyset: true, Size: 2}).Return(mockChannel(helpers.Result{
+		Data: &[]entity.Event{{EventId: "id1"}},
+	}))
+
+	result, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.MetaData.NextCursor)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsSearchHighlight() {
+	payload := request.AllEventReq{
+		Page:      1,
+		Size:      10,
+		Search:    `rock "jakarta" -jazz`,
+		Highlight: true,
+	}
+	mockEvent := helpers.Result{
+		Data: &[]entity.Event{
+			{
+				EventId:     "id",
+				Name:        "Rock Concerts",
+				Description: "<b>Jazz</b> & rocking night",
+				Country:     entity.Country{City: "Jakarta", Place: "Stadium"},
+				Score:       1.5,
+			},
+		},
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, entity.EventFilter{
+		Page:   1,
+		Size:   10,
+		Search: `rock "jakarta" -jazz`,
+	}).Return(mockChannel(mockEvent))
+
+	result, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1.5, result.CollectionData[0].Score)
+	assert.Equal(suite.T(), map[string]string{
+		"name":         "<em>Rock</em> Concerts",
+		"description":  "&lt;b&gt;Jazz&lt;/b&gt; &amp; <em>rocking</em> night",
+		"country.city": "<em>Jakarta</em>",
+	}, result.CollectionData[0].Highlights)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsSearchWithoutHighlight() {
+	payload := request.AllEventReq{
+		Page:   1,
+		Size:   10,
+		Search: "rock",
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{
+		Data: &[]entity.Event{{EventId: "id", Name: "Rock"}},
+	}))
+
+	result, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result.CollectionData[0].Highlights)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsErrSearchCursor() {
+	payload := request.AllEventReq{
+		Limit:  10,
+		Search: "rock",
+	}
+
+	_, err := suite.usecase.FindEvents(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusBadRequest, errString.Code())
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetail() {
+	mockEvent := helpers.Result{
+		Data: &entity.Event{
+			EventId: "id",
+			Name:    "name",
+			Tag:     "tag",
+		},
+		Error: nil,
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{
+				TicketId:           "ticketId",
+				EventId:            "id",
+				TicketType:         "Gold",
+				TicketPrice:        50,
+				TotalQuota:         10,
+				TotalRemaining:     5,
+				ProvisioningStatus: constants.TicketProvisioningFailed,
+				ProvisioningError:  "bank unavailable",
+			},
+		},
+		Error: nil,
+	}
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:        "tag",
+			TotalQuota: 10,
+			CountryList: []ticketEntity.CountryList{
+				{
+					CountryNumber: 1,
+					Percentage:    100,
+				},
+			},
+		},
+		Error: nil,
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+
+	result, err := suite.usecase.FindEventDetail(suite.ctx, request.EventDetailReq{EventId: "id"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Tickets, 1)
+	assert.Equal(suite.T(), 5, result.Tickets[0].TotalRemaining)
+	assert.Equal(suite.T(), constants.TicketProvisioningFailed, result.Tickets[0].ProvisioningStatus)
+	assert.Equal(suite.T(), "bank unavailable", result.Tickets[0].ProvisioningError)
+	assert.NotNil(suite.T(), result.OnlineTicketConfig)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetailSalesWindow() {
+	mockEvent := helpers.Result{
+		Data: &entity.Event{
+			EventId:      "id",
+			Tag:          "tag",
+			Status:       constants.EventStatusPublished,
+			SalesStartAt: time.Now().Add(-time.Hour),
+			SalesEndAt:   time.Now().Add(time.Hour),
+		},
+	}
+	mockTickets := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{TicketId: "open", IsSellable: true},
+			{TicketId: "notStarted", IsSellable: true, SalesStartAt: time.Now().Add(time.Minute)},
+			{TicketId: "locked", IsSellable: false},
+		},
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.FindEventDetail(suite.ctx, request.EventDetailReq{EventId: "id"})
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.IsSalesOpen)
+	assert.True(suite.T(), result.Tickets[0].IsSalesOpen)
+	assert.False(suite.T(), result.Tickets[1].IsSalesOpen)
+	assert.False(suite.T(), result.Tickets[2].IsSalesOpen)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventsSalesClosed() {
+	mockEvent := helpers.Result{
+		Data: &[]entity.Event{
+			{EventId: "ended", Status: constants.EventStatusPublished, SalesEndAt: time.Now().Add(-time.Hour)},
+			{EventId: "closed", Status: constants.EventStatusSalesClosed},
+		},
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindAllEvent", mock.Anything, mock.Anything).Return(mockChannel(mockEvent))
+
+	result, err := suite.usecase.FindEvents(suite.ctx, request.AllEventReq{Page: 1, Size: 10})
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), result.CollectionData[0].IsSalesOpen)
+	assert.False(suite.T(), result.CollectionData[1].IsSalesOpen)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetailWithoutConfig() {
+	mockEvent := helpers.Result{
+		Data: &entity.Event{
+			EventId: "id",
+			Tag:     "tag",
+		},
+		Error: nil,
+	}
+	mockTickets := helpers.Result{
+		Data:  &[]ticketEntity.Ticket{},
+		Error: nil,
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(mockTickets))
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.FindEventDetail(suite.ctx, request.EventDetailReq{EventId: "id"})
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result.OnlineTicketConfig)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetailDraftByTeam() {
+	for _, payload := range []request.EventDetailReq{
+		{EventId: "id", UserId: "editorId", UserRole: constants.RoleOrganizer},
+		{EventId: "id", UserId: "adminId", UserRole: constants.RoleAdmin},
+	} {
+		suite.SetupTest()
+		mockEvent := helpers.Result{
+			Data: &entity.Event{
+				EventId:     "id",
+				Tag:         "tag",
+				Status:      constants.EventStatusDraft,
+				OrganizerId: "organizerId",
+			},
+		}
+		suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+		suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(helpers.Result{}))
+		suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+
+		result, err := suite.usecase.FindEventDetail(suite.ctx, payload)
+		assert.NoError(suite.T(), err, payload.UserId)
+		assert.Equal(suite.T(), "id", result.EventId)
+	}
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetailErrUnpublished() {
+	for _, status := range []string{constants.EventStatusDraft, constants.EventStatusScheduled} {
+		for _, payload := range []request.EventDetailReq{
+			{EventId: "id"},
+			{EventId: "id", UserId: "viewerId", UserRole: constants.RoleOrganizer},
+			{EventId: "id", UserId: "fanId", UserRole: constants.RoleFan},
+		} {
+			suite.SetupTest()
+			mockEvent := helpers.Result{
+				Data: &entity.Event{
+					EventId:     "id",
+					Status:      status,
+					OrganizerId: "organizerId",
+				},
+			}
+			suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+			_, err := suite.usecase.FindEventDetail(suite.ctx, payload)
+			errString, ok := err.(*errors.ErrorString)
+			assert.True(suite.T(), ok, status)
+			assert.Equal(suite.T(), http.StatusNotFound, errString.Code(), status)
+			suite.mockTicketRepositoryQuery.AssertNotCalled(suite.T(), "FindTicketsByEventId", mock.Anything, mock.Anything)
+		}
+	}
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetailErr() {
+	mockEvent := helpers.Result{
+		Error: errors.BadRequest("error"),
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.FindEventDetail(suite.ctx, request.EventDetailReq{EventId: "id"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetailErrNil() {
+	suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.FindEventDetail(suite.ctx, request.EventDetailReq{EventId: "id"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetailErrParse() {
+	mockEvent := helpers.Result{
+		Data: &entity.Country{
+			Name: "name",
+		},
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+
+	_, err := suite.usecase.FindEventDetail(suite.ctx, request.EventDetailReq{EventId: "id"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetailErrTicket() {
+	mockEvent := helpers.Result{
+		Data: &entity.Event{
+			EventId: "id",
+			Tag:     "tag",
+		},
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.FindEventDetail(suite.ctx, request.EventDetailReq{EventId: "id"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindEventDetailErrConfig() {
+	mockEvent := helpers.Result{
+		Data: &entity.Event{
+			EventId: "id",
+			Tag:     "tag",
+		},
+	}
+
+	suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(mockEvent))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventId", mock.Anything, "id").Return(mockChannel(helpers.Result{Data: &[]ticketEntity.Ticket{}}))
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.FindEventDetail(suite.ctx, request.EventDetailReq{EventId: "id"})
+	assert.Error(suite.T(), err)
+}
+
 // Helper function to create a channel
+// mockOrganizer is the team of the suites, "userId" owns it, "editorId" edits and "viewerId" views its events.
+var mockOrganizer = helpers.Result{
+	Data: &organizerEntity.Organizer{
+		OrganizerId: "organizerId",
+		Members: []organizerEntity.Member{
+			{UserId: "userId", Role: constants.OrganizerRoleOwner},
+			{UserId: "editorId", Role: constants.OrganizerRoleEditor},
+			{UserId: "viewerId", Role: constants.OrganizerRoleViewer},
+		},
+	},
+}
+
 func mockChannel(result helpers.Result) <-chan helpers.Result {
 	responseChan := make(chan helpers.Result)
 
@@ -129,3 +629,364 @@ func mockChannel(result helpers.Result) <-chan helpers.Result {
 
 	return responseChan
 }
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketAllocation() {
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:        "tag",
+			TotalQuota: 10,
+		},
+	}
+	mockAllocation := helpers.Result{
+		Data: &[]ticketEntity.AggregateTotalTicket{
+			{
+				Id:                   "ID",
+				CountryName:          "Indonesia",
+				TotalQuota:           7,
+				TotalSoldTicket:      2,
+				TotalAvailableTicket: 5,
+			},
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+	suite.mockTicketRepositoryQuery.On("AggregateOnlineTicketAllocation", mock.Anything, "tag").Return(mockChannel(mockAllocation))
+
+	result, err := suite.usecase.FindOnlineTicketAllocation(suite.ctx, "tag")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 10, result.TotalQuota)
+	assert.Equal(suite.T(), "ID", result.CountryList[0].CountryCode)
+	assert.Equal(suite.T(), 7, result.CountryList[0].Allocated)
+	assert.Equal(suite.T(), 2, result.CountryList[0].Sold)
+	assert.Equal(suite.T(), 5, result.CountryList[0].Remaining)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketAllocationErrNil() {
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.FindOnlineTicketAllocation(suite.ctx, "tag")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketAllocationErrAggregate() {
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag: "tag",
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+	suite.mockTicketRepositoryQuery.On("AggregateOnlineTicketAllocation", mock.Anything, "tag").
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	result, err := suite.usecase.FindOnlineTicketAllocation(suite.ctx, "tag")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) mockTicketAvailability() {
+	now := time.Now()
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticketId").Return(mockChannel(helpers.Result{
+		Data: &ticketEntity.Ticket{
+			TicketId:       "ticketId",
+			EventId:        "id",
+			TicketType:     "VIP",
+			TotalQuota:     10,
+			TotalRemaining: 8,
+			IsSellable:     true,
+		},
+	}))
+	suite.mockOrderRepositoryQuery.On("FindEventById", mock.Anything, "id").Return(mockChannel(helpers.Result{
+		Data: &entity.Event{
+			EventId:      "id",
+			Status:       constants.EventStatusPublished,
+			SalesStartAt: now.Add(-time.Hour),
+			SalesEndAt:   now.Add(time.Hour),
+		},
+	}))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketAvailability() {
+	suite.mockTicketAvailability()
+	suite.mockTicketRepositoryStock.On("SyncStock", mock.Anything, "ticketId", mock.Anything).Return(mockChannel(helpers.Result{
+		Data: &ticketEntity.TicketStock{TicketId: "ticketId", Available: 5, Held: 3},
+	}))
+
+	result, err := suite.usecase.FindTicketAvailability(suite.ctx, "ticketId")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "VIP", result.TicketType)
+	assert.Equal(suite.T(), 10, result.TotalQuota)
+	assert.Equal(suite.T(), 5, result.Available)
+	assert.Equal(suite.T(), 3, result.Held)
+	assert.True(suite.T(), result.IsSalesOpen)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketAvailabilityWithoutCounter() {
+	suite.mockTicketAvailability()
+	suite.mockTicketRepositoryStock.On("SyncStock", mock.Anything, "ticketId", mock.Anything).Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.FindTicketAvailability(suite.ctx, "ticketId")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 8, result.Available)
+	assert.Equal(suite.T(), 0, result.Held)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketAvailabilityErrNil() {
+	suite.mockTicketRepositoryQuery.On("FindTicketById", mock.Anything, "ticketId").Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.FindTicketAvailability(suite.ctx, "ticketId")
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketAvailabilityErrStock() {
+	suite.mockTicketAvailability()
+	suite.mockTicketRepositoryStock.On("SyncStock", mock.Anything, "ticketId", mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	result, err := suite.usecase.FindTicketAvailability(suite.ctx, "ticketId")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) mockOnlineTicketConfig(createdBy string) {
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:         "tag",
+			Version:     1,
+			TotalQuota:  10,
+			CountryList: []ticketEntity.CountryList{{CountryNumber: 360, CountryCode: "ID", Percentage: 100, Quota: 10}},
+			CreatedBy:   createdBy,
+		},
+	}
+	mockEventByTag := helpers.Result{
+		Data: &entity.Event{
+			EventId:   "id",
+			Tag:       "tag",
+			CreatedBy: createdBy,
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+	suite.mockOrderRepositoryQuery.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(mockEventByTag))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfig() {
+	suite.mockOnlineTicketConfig("userId")
+
+	result, err := suite.usecase.FindOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Version)
+	assert.Equal(suite.T(), 10, result.CountryList[0].Quota)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigErrForbidden() {
+	suite.mockOnlineTicketConfig("userId")
+
+	result, err := suite.usecase.FindOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "other"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigSupport() {
+	suite.mockOnlineTicketConfig("userId")
+
+	result, err := suite.usecase.FindOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{
+		Tag: "tag", UserId: "supportId", UserRole: constants.RoleSupport,
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "tag", result.Tag)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigErrEventOwner() {
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{
+			Tag:       "tag",
+			CreatedBy: "userId",
+		},
+	}
+	mockEventByTag := helpers.Result{
+		Data: &entity.Event{
+			Tag:       "tag",
+			CreatedBy: "other",
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+	suite.mockOrderRepositoryQuery.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(mockEventByTag))
+
+	result, err := suite.usecase.FindOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigErrNotFound() {
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.FindOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigs() {
+	mockConfigs := helpers.Result{
+		Data:  &[]ticketEntity.OnlineTicketConfig{{Tag: "tag", CreatedBy: "userId"}},
+		Count: 1,
+	}
+	suite.mockTicketRepositoryQuery.On("FindAllOnlineTicketConfig", mock.Anything, "userId", int64(1), int64(10)).Return(mockChannel(mockConfigs))
+
+	result, err := suite.usecase.FindOnlineTicketConfigs(suite.ctx, request.AllOnlineTicketConfigReq{Page: 1, Size: 10, UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "tag", result.CollectionData[0].Tag)
+	assert.Equal(suite.T(), int64(1), result.MetaData.TotalData)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigsAdmin() {
+	mockConfigs := helpers.Result{
+		Data: &[]ticketEntity.OnlineTicketConfig{},
+	}
+	suite.mockTicketRepositoryQuery.On("FindAllOnlineTicketConfig", mock.Anything, "other", int64(1), int64(10)).Return(mockChannel(mockConfigs))
+
+	_, err := suite.usecase.FindOnlineTicketConfigs(suite.ctx, request.AllOnlineTicketConfigReq{
+		Page: 1, Size: 10, CreatedBy: "other", UserId: "adminId", UserRole: constants.RoleAdmin,
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigsSupport() {
+	mockConfigs := helpers.Result{
+		Data: &[]ticketEntity.OnlineTicketConfig{},
+	}
+	suite.mockTicketRepositoryQuery.On("FindAllOnlineTicketConfig", mock.Anything, "other", int64(1), int64(10)).Return(mockChannel(mockConfigs))
+
+	_, err := suite.usecase.FindOnlineTicketConfigs(suite.ctx, request.AllOnlineTicketConfigReq{
+		Page: 1, Size: 10, CreatedBy: "other", UserId: "supportId", UserRole: constants.RoleSupport,
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigsErrForbiddenOrganizer() {
+	_, err := suite.usecase.FindOnlineTicketConfigs(suite.ctx, request.AllOnlineTicketConfigReq{
+		Page: 1, Size: 10, CreatedBy: "other", UserId: "userId", UserRole: constants.RoleOrganizer,
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigsErrForbidden() {
+	_, err := suite.usecase.FindOnlineTicketConfigs(suite.ctx, request.AllOnlineTicketConfigReq{
+		Page: 1, Size: 10, CreatedBy: "other", UserId: "userId",
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+	suite.mockTicketRepositoryQuery.AssertNotCalled(suite.T(), "FindAllOnlineTicketConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigsByOrganizer() {
+	mockConfigs := helpers.Result{
+		Data:  &[]ticketEntity.OnlineTicketConfig{{Tag: "tag", OrganizerId: "organizerId", CreatedBy: "userId"}},
+		Count: 1,
+	}
+	suite.mockTicketRepositoryQuery.On("FindAllOnlineTicketConfigByOrganizer", mock.Anything, "organizerId", int64(1), int64(10)).
+		Return(mockChannel(mockConfigs))
+
+	result, err := suite.usecase.FindOnlineTicketConfigs(suite.ctx, request.AllOnlineTicketConfigReq{
+		Page: 1, Size: 10, OrganizerId: "organizerId", UserId: "viewerId", UserRole: constants.RoleOrganizer,
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "organizerId", result.CollectionData[0].OrganizerId)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigsByOrganizerErrNotMember() {
+	_, err := suite.usecase.FindOnlineTicketConfigs(suite.ctx, request.AllOnlineTicketConfigReq{
+		Page: 1, Size: 10, OrganizerId: "organizerId", UserId: "other", UserRole: constants.RoleOrganizer,
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+	suite.mockTicketRepositoryQuery.AssertNotCalled(suite.T(), "FindAllOnlineTicketConfigByOrganizer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigTeamViewer() {
+	mockConfig := helpers.Result{
+		Data: &ticketEntity.OnlineTicketConfig{Tag: "tag", OrganizerId: "organizerId", CreatedBy: "userId"},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigByTag", mock.Anything, "tag").Return(mockChannel(mockConfig))
+	suite.mockOrderRepositoryQuery.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{
+		Data: &entity.Event{Tag: "tag", OrganizerId: "organizerId", CreatedBy: "editorId"},
+	}))
+
+	result, err := suite.usecase.FindOnlineTicketConfig(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "viewerId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "organizerId", result.OrganizerId)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigsErr() {
+	suite.mockTicketRepositoryQuery.On("FindAllOnlineTicketConfig", mock.Anything, "userId", int64(1), int64(10)).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.FindOnlineTicketConfigs(suite.ctx, request.AllOnlineTicketConfigReq{Page: 1, Size: 10, UserId: "userId"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigHistory() {
+	changedAt := time.Now()
+	mockHistory := helpers.Result{
+		Data: &[]ticketEntity.OnlineTicketConfigHistory{
+			{Tag: "tag", Version: 2, Action: constants.OnlineTicketConfigDeleted, CreatedBy: "userId", ChangedBy: "userId", ChangedAt: changedAt},
+			{Tag: "tag", Version: 1, Action: constants.OnlineTicketConfigUpserted, CreatedBy: "userId", ChangedBy: "userId"},
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigHistory", mock.Anything, "tag").Return(mockChannel(mockHistory))
+	suite.mockOrderRepositoryQuery.On("FindEventByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.FindOnlineTicketConfigHistory(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), constants.OnlineTicketConfigDeleted, result[0].Action)
+	assert.Equal(suite.T(), changedAt, result[0].ChangedAt)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigHistoryErrForbidden() {
+	mockHistory := helpers.Result{
+		Data: &[]ticketEntity.OnlineTicketConfigHistory{{Tag: "tag", Version: 1, CreatedBy: "userId"}},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigHistory", mock.Anything, "tag").Return(mockChannel(mockHistory))
+
+	result, err := suite.usecase.FindOnlineTicketConfigHistory(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "other"})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigHistorySupport() {
+	mockHistory := helpers.Result{
+		Data: &[]ticketEntity.OnlineTicketConfigHistory{{Tag: "tag", Version: 1, CreatedBy: "userId"}},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigHistory", mock.Anything, "tag").Return(mockChannel(mockHistory))
+
+	result, err := suite.usecase.FindOnlineTicketConfigHistory(suite.ctx, request.OnlineTicketConfigReq{
+		Tag: "tag", UserId: "supportId", UserRole: constants.RoleSupport,
+	})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	suite.mockOrderRepositoryQuery.AssertNotCalled(suite.T(), "FindEventByTag", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketConfigHistoryErrNotFound() {
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketConfigHistory", mock.Anything, "tag").
+		Return(mockChannel(helpers.Result{Data: &[]ticketEntity.OnlineTicketConfigHistory{}}))
+
+	result, err := suite.usecase.FindOnlineTicketConfigHistory(suite.ctx, request.OnlineTicketConfigReq{Tag: "tag", UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"event-service/internal/modules/event/models/entity"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightOpen  = "<em>"
+	highlightClose = "</em>"
+)
+
+// searchTerms extracts the words of a text search, negated terms are left out since they never match.
+func searchTerms(search string) []string {
+	terms := make([]string, 0)
+	for _, word := range strings.Fields(strings.ReplaceAll(search, `"`, " ")) {
+		if strings.HasPrefix(word, "-") {
+			continue
+		}
+		word = strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if word != "" {
+			terms = append(terms, regexp.QuoteMeta(word))
+		}
+	}
+	return terms
+}
+
+// highlightPattern matches the words starting with a search term, so a stemmed match such as
+// "concerts" for "concert" is highlighted whole. It is nil when the search has no terms.
+func highlightPattern(search string) *regexp.Regexp {
+	terms := searchTerms(search)
+	if len(terms) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(terms, "|") + `)\w*`)
+}
+
+// highlightEvent returns the HTML escaped text fields of the event that match the search,
+// with every match wrapped in <em>.
+func highlightEvent(pattern *regexp.Regexp, event entity.Event) map[string]string {
+	if pattern == nil {
+		return nil
+	}
+
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"name", event.Name},
+		{"description", event.Description},
+		{"tag", event.Tag},
+		{"country.city", event.Country.City},
+		{"country.place", event.Country.Place},
+	}
+
+	highlights := make(map[string]string)
+	for _, field := range fields {
+		value := html.EscapeString(field.value)
+		if !pattern.MatchString(value) {
+			continue
+		}
+		highlights[field.name] = pattern.ReplaceAllString(value, highlightOpen+"$0"+highlightClose)
+	}
+	if len(highlights) == 0 {
+		return nil
+	}
+	return highlights
+}
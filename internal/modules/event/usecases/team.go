@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+
+	"event-service/internal/modules/event/models/entity"
+	"event-service/internal/modules/organizer"
+	organizerEntity "event-service/internal/modules/organizer/models/entity"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+)
+
+// editorRoles manage the events and tags of their organizer, viewers only read them.
+var (
+	editorRoles = []string{constants.OrganizerRoleOwner, constants.OrganizerRoleEditor}
+	memberRoles = []string{constants.OrganizerRoleOwner, constants.OrganizerRoleEditor, constants.OrganizerRoleViewer}
+)
+
+// checkTeamAccess lets through admins and the members of organizerId holding one of roles. A record made before the
+// organizers existed has no organizerId, only its creator gets through.
+func checkTeamAccess(ctx context.Context, organizerRepositoryQuery organizer.MongodbRepositoryQuery, organizerId string,
+	createdBy string, userId string, userRole string, roles ...string) (bool, error) {
+	if userRole == constants.RoleAdmin {
+		return true, nil
+	}
+	if organizerId == "" {
+		return createdBy == userId, nil
+	}
+
+	organizerData := <-organizerRepositoryQuery.FindOrganizerById(ctx, organizerId)
+	if organizerData.Error != nil {
+		return false, organizerData.Error
+	}
+	if organizerData.Data == nil {
+		return false, nil
+	}
+
+	team, ok := organizerData.Data.(*organizerEntity.Organizer)
+	if !ok {
+		return false, errors.InternalServerError("failed marshal organizer")
+	}
+
+	memberRole := team.MemberRole(userId)
+	for _, role := range roles {
+		if memberRole == role {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func canManageEvent(ctx context.Context, organizerRepositoryQuery organizer.MongodbRepositoryQuery, event *entity.Event,
+	userId string, userRole string) (bool, error) {
+	return checkTeamAccess(ctx, organizerRepositoryQuery, event.OrganizerId, event.CreatedBy, userId, userRole, editorRoles...)
+}
+
+// sameOwner reports whether two records belong to the same organizer. When either was made before the organizers
+// existed, they must share their creator instead.
+func sameOwner(organizerId string, createdBy string, otherOrganizerId string, otherCreatedBy string) bool {
+	if organizerId != "" && otherOrganizerId != "" {
+		return organizerId == otherOrganizerId
+	}
+	return createdBy == otherCreatedBy
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/organizer/models/request"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+	"event-service/internal/pkg/redis"
+
+	middlewares "event-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrganizerHttpHandler struct {
+	OrganizerUsecaseCommand organizer.UsecaseCommand
+	OrganizerUsecaseQuery   organizer.UsecaseQuery
+	Logger                  log.Logger
+	Validator               *validator.Validate
+}
+
+func InitOrganizerHttpHandler(app *fiber.App, ouc organizer.UsecaseCommand, ouq organizer.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &OrganizerHttpHandler{
+		OrganizerUsecaseCommand: ouc,
+		OrganizerUsecaseQuery:   ouq,
+		Logger:                  log,
+		Validator:               validator.New(),
+	}
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	handler.RegisterRoutes(app.Group("/api/event"), middlewares.VerifyBearer())
+}
+
+// RegisterRoutes declares the permission each route needs, auth must set the userId and userRole locals.
+func (o OrganizerHttpHandler) RegisterRoutes(route fiber.Router, auth fiber.Handler) {
+	route.Post("/v1/organizers", auth, middlewares.RequirePermission(constants.PermissionOrganizerManage), o.CreateOrganizer)
+	route.Get("/v1/organizers/:organizerId", auth, middlewares.RequirePermission(constants.PermissionOrganizerRead), o.GetOrganizer)
+	route.Post("/v1/organizers/:organizerId/members", auth, middlewares.RequirePermission(constants.PermissionOrganizerManage), o.InviteMember)
+	route.Delete("/v1/organizers/:organizerId/members/:memberId", auth, middlewares.RequirePermission(constants.PermissionOrganizerManage), o.RemoveMember)
+}
+
+func (o OrganizerHttpHandler) CreateOrganizer(c *fiber.Ctx) error {
+	req := new(request.CreateOrganizerReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.UserId = c.Locals("userId").(string)
+
+	if err := o.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := o.OrganizerUsecaseCommand.CreateOrganizer(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, o.Logger, err)
+	}
+	return helpers.RespSuccess(c, o.Logger, resp, "Create organizer success")
+}
+
+func (o OrganizerHttpHandler) GetOrganizer(c *fiber.Ctx) error {
+	req := new(request.OrganizerReq)
+	req.OrganizerId = c.Params("organizerId")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := o.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := o.OrganizerUsecaseQuery.FindOrganizer(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, o.Logger, err)
+	}
+	return helpers.RespSuccess(c, o.Logger, resp, "Get organizer success")
+}
+
+func (o OrganizerHttpHandler) InviteMember(c *fiber.Ctx) error {
+	req := new(request.InviteMemberReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.OrganizerId = c.Params("organizerId")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := o.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := o.OrganizerUsecaseCommand.InviteMember(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, o.Logger, err)
+	}
+	return helpers.RespSuccess(c, o.Logger, resp, "Invite member success")
+}
+
+func (o OrganizerHttpHandler) RemoveMember(c *fiber.Ctx) error {
+	req := new(request.RemoveMemberReq)
+	req.OrganizerId = c.Params("organizerId")
+	req.MemberId = c.Params("memberId")
+	req.UserId = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := o.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := o.OrganizerUsecaseCommand.RemoveMember(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, o.Logger, err)
+	}
+	return helpers.RespSuccess(c, o.Logger, resp, "Remove member success")
+}
@@ -0,0 +1,198 @@
+package handlers_test
+
+import (
+	"bytes"
+	"event-service/internal/modules/organizer/handlers"
+	"event-service/internal/modules/organizer/models/request"
+	"event-service/internal/modules/organizer/models/response"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/log"
+	mockcert "event-service/mocks/modules/organizer"
+	mocklog "event-service/mocks/pkg/log"
+	mockredis "event-service/mocks/pkg/redis"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OrganizerHttpHandlerTestSuite struct {
+	suite.Suite
+
+	cUC       *mockcert.UsecaseCommand
+	cUQ       *mockcert.UsecaseQuery
+	cLog      *mocklog.Logger
+	validator *validator.Validate
+	handler   *handlers.OrganizerHttpHandler
+	cRedis    *mockredis.Collections
+	app       *fiber.App
+}
+
+func (suite *OrganizerHttpHandlerTestSuite) SetupTest() {
+	suite.cUC = new(mockcert.UsecaseCommand)
+	suite.cUQ = new(mockcert.UsecaseQuery)
+	suite.cLog = new(mocklog.Logger)
+	suite.validator = validator.New()
+	suite.cRedis = new(mockredis.Collections)
+	suite.handler = &handlers.OrganizerHttpHandler{
+		OrganizerUsecaseCommand: suite.cUC,
+		OrganizerUsecaseQuery:   suite.cUQ,
+		Logger:                  suite.cLog,
+		Validator:               suite.validator,
+	}
+	suite.app = fiber.New()
+	handlers.InitOrganizerHttpHandler(suite.app, suite.cUC, suite.cUQ, suite.cLog, suite.cRedis)
+}
+
+func TestOrganizerHttpHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizerHttpHandlerTestSuite))
+}
+
+func withUser(userId string, userRole string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("userId", userId)
+		c.Locals("userRole", userRole)
+		return c.Next()
+	}
+}
+
+func (suite *OrganizerHttpHandlerTestSuite) TestCreateOrganizer() {
+	suite.cUC.On("CreateOrganizer", mock.Anything, request.CreateOrganizerReq{Name: "name", UserId: "userId"}).
+		Return(&response.Organizer{OrganizerId: "organizerId"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/organizers", withUser("userId", constants.RoleOrganizer), suite.handler.CreateOrganizer)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/organizers", bytes.NewBufferString(`{"name":"name"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *OrganizerHttpHandlerTestSuite) TestCreateOrganizerErrValidation() {
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/organizers", withUser("userId", constants.RoleOrganizer), suite.handler.CreateOrganizer)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/organizers", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *OrganizerHttpHandlerTestSuite) TestGetOrganizer() {
+	suite.cUQ.On("FindOrganizer", mock.Anything, request.OrganizerReq{OrganizerId: "organizerId", UserId: "userId", UserRole: constants.RoleOrganizer}).
+		Return(&response.Organizer{OrganizerId: "organizerId"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/organizers/:organizerId", withUser("userId", constants.RoleOrganizer), suite.handler.GetOrganizer)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/organizers/organizerId", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *OrganizerHttpHandlerTestSuite) TestInviteMember() {
+	suite.cUC.On("InviteMember", mock.Anything, mock.MatchedBy(func(req request.InviteMemberReq) bool {
+		return req.OrganizerId == "organizerId" && req.MemberId == "memberId" && req.Role == constants.OrganizerRoleEditor &&
+			req.UserId == "userId"
+	})).Return(new(string), nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/organizers/:organizerId/members", withUser("userId", constants.RoleOrganizer), suite.handler.InviteMember)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/organizers/organizerId/members",
+		bytes.NewBufferString(`{"memberId":"memberId","role":"editor"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *OrganizerHttpHandlerTestSuite) TestInviteMemberErrRole() {
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/v1/organizers/:organizerId/members", withUser("userId", constants.RoleOrganizer), suite.handler.InviteMember)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/organizers/organizerId/members",
+		bytes.NewBufferString(`{"memberId":"memberId","role":"admin"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+	suite.cUC.AssertNotCalled(suite.T(), "InviteMember", mock.Anything, mock.Anything)
+}
+
+func (suite *OrganizerHttpHandlerTestSuite) TestRemoveMemberErrLastOwner() {
+	suite.cUC.On("RemoveMember", mock.Anything, request.RemoveMemberReq{
+		OrganizerId: "organizerId", MemberId: "userId", UserId: "userId", UserRole: constants.RoleOrganizer,
+	}).Return(nil, errors.Conflict("the organizer must keep at least one owner"))
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Delete("/v1/organizers/:organizerId/members/:memberId", withUser("userId", constants.RoleOrganizer), suite.handler.RemoveMember)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/v1/organizers/organizerId/members/userId", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusConflict, resp.StatusCode)
+}
+
+func (suite *OrganizerHttpHandlerTestSuite) TestRoutePermissions() {
+	// the role middleware logs through the global logger
+	log.Init((&log.LoggerConf{}).Clone(zap.NewNop()))
+	suite.cUQ.On("FindOrganizer", mock.Anything, mock.Anything).Return(&response.Organizer{}, nil)
+	suite.cUC.On("RemoveMember", mock.Anything, mock.Anything).Return(new(string), nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	routes := []struct {
+		method string
+		path   string
+		roles  []string
+	}{
+		{fiber.MethodGet, "/v1/organizers/organizerId",
+			[]string{constants.RoleAdmin, constants.RoleOrganizer, constants.RoleSupport}},
+		{fiber.MethodDelete, "/v1/organizers/organizerId/members/memberId",
+			[]string{constants.RoleAdmin, constants.RoleOrganizer}},
+	}
+	for _, role := range []string{constants.RoleAdmin, constants.RoleOrganizer, constants.RoleFan, constants.RoleSupport} {
+		app := fiber.New()
+		suite.handler.RegisterRoutes(app, withUser("userId", role))
+
+		for _, route := range routes {
+			status := fiber.StatusForbidden
+			for _, allowed := range route.roles {
+				if allowed == role {
+					status = fiber.StatusOK
+				}
+			}
+
+			resp, err := app.Test(httptest.NewRequest(route.method, route.path, nil))
+			assert.Nil(suite.T(), err)
+			assert.Equal(suite.T(), status, resp.StatusCode, "%s %s as %s", route.method, route.path, role)
+		}
+	}
+}
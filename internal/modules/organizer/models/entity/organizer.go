@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// Organizer is the team owning events and tags, its members act on them according to their role.
+type Organizer struct {
+	OrganizerId string    `json:"organizerId" bson:"organizerId"`
+	Name        string    `json:"name" bson:"name"`
+	Members     []Member  `json:"members" bson:"members"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+	CreatedBy   string    `json:"createdBy" bson:"createdBy"`
+	UpdatedBy   string    `json:"updatedBy" bson:"updatedBy"`
+}
+
+type Member struct {
+	UserId    string    `json:"userId" bson:"userId"`
+	Role      string    `json:"role" bson:"role"`
+	InvitedBy string    `json:"invitedBy" bson:"invitedBy"`
+	JoinedAt  time.Time `json:"joinedAt" bson:"joinedAt"`
+}
+
+// MemberRole returns the role of userId in the team, empty when the user is not a member.
+func (o Organizer) MemberRole(userId string) string {
+	for _, member := range o.Members {
+		if member.UserId == userId {
+			return member.Role
+		}
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+package request
+
+type CreateOrganizerReq struct {
+	Name   string `json:"name" validate:"required"`
+	UserId string `json:"userId" validate:"required"`
+}
+
+type OrganizerReq struct {
+	OrganizerId string `json:"organizerId" validate:"required"`
+	UserId      string `json:"userId" validate:"required"`
+	UserRole    string `json:"userRole"`
+}
+
+type InviteMemberReq struct {
+	OrganizerId string `json:"organizerId" validate:"required"`
+	MemberId    string `json:"memberId" validate:"required"`
+	Role        string `json:"role" validate:"required,oneof=owner editor viewer"`
+	UserId      string `json:"userId" validate:"required"`
+	UserRole    string `json:"userRole"`
+}
+
+type RemoveMemberReq struct {
+	OrganizerId string `json:"organizerId" validate:"required"`
+	MemberId    string `json:"memberId" validate:"required"`
+	UserId      string `json:"userId" validate:"required"`
+	UserRole    string `json:"userRole"`
+}
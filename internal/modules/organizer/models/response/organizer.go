@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+type Organizer struct {
+	OrganizerId string    `json:"organizerId"`
+	Name        string    `json:"name"`
+	Members     []Member  `json:"members"`
+	CreatedAt   time.Time `json:"createdAt"`
+	CreatedBy   string    `json:"createdBy"`
+}
+
+type Member struct {
+	UserId    string    `json:"userId"`
+	Role      string    `json:"role"`
+	InvitedBy string    `json:"invitedBy"`
+	JoinedAt  time.Time `json:"joinedAt"`
+}
@@ -0,0 +1,30 @@
+package organizer
+
+import (
+	"context"
+
+	"event-service/internal/modules/organizer/models/entity"
+	"event-service/internal/modules/organizer/models/request"
+	"event-service/internal/modules/organizer/models/response"
+	wrapper "event-service/internal/pkg/helpers"
+)
+
+type UsecaseQuery interface {
+	FindOrganizer(origCtx context.Context, payload request.OrganizerReq) (*response.Organizer, error)
+}
+
+type UsecaseCommand interface {
+	CreateOrganizer(origCtx context.Context, payload request.CreateOrganizerReq) (*response.Organizer, error)
+	InviteMember(origCtx context.Context, payload request.InviteMemberReq) (*string, error)
+	RemoveMember(origCtx context.Context, payload request.RemoveMemberReq) (*string, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindOrganizerById(ctx context.Context, organizerId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	InsertOneOrganizer(ctx context.Context, organizer entity.Organizer) <-chan wrapper.Result
+	AddMember(ctx context.Context, organizerId string, member entity.Member) <-chan wrapper.Result
+	RemoveMember(ctx context.Context, organizerId string, userId string) <-chan wrapper.Result
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/organizer/models/entity"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/databases/mongodb"
+	wrapper "event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) organizer.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) InsertOneOrganizer(ctx context.Context, organizer entity.Organizer) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "organizer",
+			Document:       organizer,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// AddMember adds member to the team and returns the updated organizer, Data is nil when the user is already a member.
+func (c commandMongodbRepository) AddMember(ctx context.Context, organizerId string, member entity.Member) <-chan wrapper.Result {
+	var organizer entity.Organizer
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			CollectionName: "organizer",
+			Filter: bson.M{
+				"organizerId":    organizerId,
+				"members.userId": bson.M{"$ne": member.UserId},
+			},
+			Update: bson.M{
+				"$push": bson.M{"members": member},
+				"$set":  bson.M{"updatedAt": time.Now(), "updatedBy": member.InvitedBy},
+			},
+			Result: &organizer,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// RemoveMember removes userId from the team and returns the updated organizer. Data is nil when the user is not a
+// member or is the last owner, a team always keeps an owner.
+func (c commandMongodbRepository) RemoveMember(ctx context.Context, organizerId string, userId string) <-chan wrapper.Result {
+	var organizer entity.Organizer
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			CollectionName: "organizer",
+			Filter: bson.M{
+				"organizerId":    organizerId,
+				"members.userId": userId,
+				"members": bson.M{"$elemMatch": bson.M{
+					"role":   constants.OrganizerRoleOwner,
+					"userId": bson.M{"$ne": userId},
+				}},
+			},
+			Update: bson.M{
+				"$pull": bson.M{"members": bson.M{"userId": userId}},
+				"$set":  bson.M{"updatedAt": time.Now()},
+			},
+			Result: &organizer,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,106 @@
+package commands_test
+
+import (
+	"context"
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/organizer/models/entity"
+	mongoRC "event-service/internal/modules/organizer/repositories/commands"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/helpers"
+	mocks "event-service/mocks/pkg/databases/mongodb"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommandTestSuite struct {
+	suite.Suite
+	mockMongodb *mocks.Collections
+	mockLogger  *mocklog.Logger
+	repository  organizer.MongodbRepositoryCommand
+	ctx         context.Context
+}
+
+func (suite *CommandTestSuite) SetupTest() {
+	suite.mockMongodb = new(mocks.Collections)
+	suite.mockLogger = &mocklog.Logger{}
+	suite.repository = mongoRC.NewCommandMongodbRepository(
+		suite.mockMongodb,
+		suite.mockLogger,
+	)
+	suite.ctx = context.Background()
+}
+
+func TestCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandTestSuite))
+}
+
+func (suite *CommandTestSuite) TestInsertOneOrganizer() {
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("InsertOne", mock.MatchedBy(func(payload mongodb.InsertOne) bool {
+		return payload.CollectionName == "organizer"
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := suite.repository.InsertOneOrganizer(suite.ctx, entity.Organizer{OrganizerId: "organizerId"})
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	<-result
+
+	suite.mockMongodb.AssertCalled(suite.T(), "InsertOne", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandTestSuite) TestAddMember() {
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOneAndUpdate", mock.MatchedBy(func(payload mongodb.FindOneAndUpdate) bool {
+		filter := payload.Filter.(bson.M)
+		// the user must not be a member yet
+		return payload.CollectionName == "organizer" && filter["organizerId"] == "organizerId" &&
+			filter["members.userId"].(bson.M)["$ne"] == "memberId"
+	}), options.After, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := suite.repository.AddMember(suite.ctx, "organizerId", entity.Member{UserId: "memberId", Role: constants.OrganizerRoleEditor})
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	<-result
+
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestRemoveMember() {
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOneAndUpdate", mock.MatchedBy(func(payload mongodb.FindOneAndUpdate) bool {
+		filter := payload.Filter.(bson.M)
+		// another owner must stay in the team
+		owner := filter["members"].(bson.M)["$elemMatch"].(bson.M)
+		return payload.CollectionName == "organizer" && filter["members.userId"] == "memberId" &&
+			owner["role"] == constants.OrganizerRoleOwner && owner["userId"].(bson.M)["$ne"] == "memberId"
+	}), options.After, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := suite.repository.RemoveMember(suite.ctx, "organizerId", "memberId")
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	<-result
+
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
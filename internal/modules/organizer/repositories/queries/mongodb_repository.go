@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"context"
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/organizer/models/entity"
+	"event-service/internal/pkg/databases/mongodb"
+	wrapper "event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) organizer.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindOrganizerById(ctx context.Context, organizerId string) <-chan wrapper.Result {
+	var organizer entity.Organizer
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &organizer,
+			CollectionName: "organizer",
+			Filter: bson.M{
+				"organizerId": organizerId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,58 @@
+package queries_test
+
+import (
+	"context"
+	"event-service/internal/modules/organizer"
+	mongoRQ "event-service/internal/modules/organizer/repositories/queries"
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/helpers"
+	mocks "event-service/mocks/pkg/databases/mongodb"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type QueryTestSuite struct {
+	suite.Suite
+	mockMongodb *mocks.Collections
+	mockLogger  *mocklog.Logger
+	repository  organizer.MongodbRepositoryQuery
+	ctx         context.Context
+}
+
+func (suite *QueryTestSuite) SetupTest() {
+	suite.mockMongodb = new(mocks.Collections)
+	suite.mockLogger = &mocklog.Logger{}
+	suite.repository = mongoRQ.NewQueryMongodbRepository(
+		suite.mockMongodb,
+		suite.mockLogger,
+	)
+	suite.ctx = context.Background()
+}
+
+func TestQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryTestSuite))
+}
+
+func (suite *QueryTestSuite) TestFindOrganizerById() {
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOne", mock.MatchedBy(func(payload mongodb.FindOne) bool {
+		return payload.CollectionName == "organizer" && payload.Filter.(bson.M)["organizerId"] == "organizerId"
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := suite.repository.FindOrganizerById(suite.ctx, "organizerId")
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	<-result
+
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
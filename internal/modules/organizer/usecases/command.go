@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/organizer/models/entity"
+	"event-service/internal/modules/organizer/models/request"
+	"event-service/internal/modules/organizer/models/response"
+	"event-service/internal/modules/user"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/log"
+)
+
+type commandUsecase struct {
+	organizerRepositoryQuery   organizer.MongodbRepositoryQuery
+	organizerRepositoryCommand organizer.MongodbRepositoryCommand
+	userRepositoryQuery        user.MongodbRepositoryQuery
+	logger                     log.Logger
+}
+
+func NewCommandUsecase(orq organizer.MongodbRepositoryQuery, orc organizer.MongodbRepositoryCommand,
+	urq user.MongodbRepositoryQuery, log log.Logger) organizer.UsecaseCommand {
+	return commandUsecase{
+		organizerRepositoryQuery:   orq,
+		organizerRepositoryCommand: orc,
+		userRepositoryQuery:        urq,
+		logger:                     log,
+	}
+}
+
+// CreateOrganizer starts a team with its creator as the only owner.
+func (c commandUsecase) CreateOrganizer(origCtx context.Context, payload request.CreateOrganizerReq) (*response.Organizer, error) {
+	domain := "organizerUsecase-CreateOrganizer"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	now := time.Now()
+	organizer := entity.Organizer{
+		OrganizerId: uuid.New().String(),
+		Name:        payload.Name,
+		Members: []entity.Member{
+			{
+				UserId:    payload.UserId,
+				Role:      constants.OrganizerRoleOwner,
+				InvitedBy: payload.UserId,
+				JoinedAt:  now,
+			},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: payload.UserId,
+		UpdatedBy: payload.UserId,
+	}
+
+	resp := <-c.organizerRepositoryCommand.InsertOneOrganizer(ctx, organizer)
+	if resp.Error != nil {
+		return nil, errors.InternalServerError("failed save organizer")
+	}
+
+	result := toOrganizerResp(organizer)
+	return &result, nil
+}
+
+// InviteMember adds an existing user to the team, only its owners and admins invite.
+func (c commandUsecase) InviteMember(origCtx context.Context, payload request.InviteMemberReq) (*string, error) {
+	domain := "organizerUsecase-InviteMember"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	organizer, err := findOrganizer(ctx, c.organizerRepositoryQuery, payload.OrganizerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.UserRole != constants.RoleAdmin && organizer.MemberRole(payload.UserId) != constants.OrganizerRoleOwner {
+		return nil, errors.ForbiddenError("only an owner of the organizer can invite members")
+	}
+
+	if organizer.MemberRole(payload.MemberId) != "" {
+		return nil, errors.Conflict("user is already a member of the organizer")
+	}
+
+	userData := <-c.userRepositoryQuery.FindOneUserId(ctx, payload.MemberId)
+	if userData.Error != nil {
+		return nil, userData.Error
+	}
+	if userData.Data == nil {
+		return nil, errors.NotFound("user not found")
+	}
+
+	resp := <-c.organizerRepositoryCommand.AddMember(ctx, payload.OrganizerId, entity.Member{
+		UserId:    payload.MemberId,
+		Role:      payload.Role,
+		InvitedBy: payload.UserId,
+		JoinedAt:  time.Now(),
+	})
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	// a concurrent invite added the user first
+	if resp.Data == nil {
+		return nil, errors.Conflict("user is already a member of the organizer")
+	}
+
+	result := "Success invite member"
+	return &result, nil
+}
+
+// RemoveMember removes a member from the team. Owners and admins remove anyone, the other members only leave.
+func (c commandUsecase) RemoveMember(origCtx context.Context, payload request.RemoveMemberReq) (*string, error) {
+	domain := "organizerUsecase-RemoveMember"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	organizer, err := findOrganizer(ctx, c.organizerRepositoryQuery, payload.OrganizerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.UserRole != constants.RoleAdmin && payload.UserId != payload.MemberId &&
+		organizer.MemberRole(payload.UserId) != constants.OrganizerRoleOwner {
+		return nil, errors.ForbiddenError("only an owner of the organizer can remove members")
+	}
+
+	if organizer.MemberRole(payload.MemberId) == "" {
+		return nil, errors.NotFound("member not found")
+	}
+
+	resp := <-c.organizerRepositoryCommand.RemoveMember(ctx, payload.OrganizerId, payload.MemberId)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	if resp.Data == nil {
+		return nil, errors.Conflict("the organizer must keep at least one owner")
+	}
+
+	result := "Success remove member"
+	return &result, nil
+}
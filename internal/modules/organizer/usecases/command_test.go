@@ -0,0 +1,220 @@
+package usecases_test
+
+import (
+	"context"
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/organizer/models/entity"
+	"event-service/internal/modules/organizer/models/request"
+	uc "event-service/internal/modules/organizer/usecases"
+	userEntity "event-service/internal/modules/user/models/entity"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	mockcert "event-service/mocks/modules/organizer"
+	mockcertUser "event-service/mocks/modules/user"
+	mocklog "event-service/mocks/pkg/log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockOrganizerRepositoryQuery   *mockcert.MongodbRepositoryQuery
+	mockOrganizerRepositoryCommand *mockcert.MongodbRepositoryCommand
+	mockUserRepositoryQuery        *mockcertUser.MongodbRepositoryQuery
+	mockLogger                     *mocklog.Logger
+	usecase                        organizer.UsecaseCommand
+	ctx                            context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockOrganizerRepositoryQuery = &mockcert.MongodbRepositoryQuery{}
+	suite.mockOrganizerRepositoryCommand = &mockcert.MongodbRepositoryCommand{}
+	suite.mockUserRepositoryQuery = &mockcertUser.MongodbRepositoryQuery{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockOrganizerRepositoryQuery,
+		suite.mockOrganizerRepositoryCommand,
+		suite.mockUserRepositoryQuery,
+		suite.mockLogger,
+	)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOrganizer() {
+	suite.mockOrganizerRepositoryCommand.On("InsertOneOrganizer", mock.Anything, mock.MatchedBy(func(o entity.Organizer) bool {
+		return o.OrganizerId != "" && len(o.Members) == 1 && o.MemberRole("userId") == constants.OrganizerRoleOwner
+	})).Return(mockChannel(helpers.Result{}))
+
+	result, err := suite.usecase.CreateOrganizer(suite.ctx, request.CreateOrganizerReq{Name: "name", UserId: "userId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "name", result.Name)
+	assert.Equal(suite.T(), constants.OrganizerRoleOwner, result.Members[0].Role)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateOrganizerErr() {
+	suite.mockOrganizerRepositoryCommand.On("InsertOneOrganizer", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error mongodb connection")}))
+
+	result, err := suite.usecase.CreateOrganizer(suite.ctx, request.CreateOrganizerReq{Name: "name", UserId: "userId"})
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestInviteMember() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+	suite.mockUserRepositoryQuery.On("FindOneUserId", mock.Anything, "newId").Return(mockChannel(helpers.Result{
+		Data: &userEntity.User{UserId: "newId"},
+	}))
+	suite.mockOrganizerRepositoryCommand.On("AddMember", mock.Anything, "organizerId", mock.MatchedBy(func(m entity.Member) bool {
+		return m.UserId == "newId" && m.Role == constants.OrganizerRoleEditor && m.InvitedBy == "ownerId"
+	})).Return(mockChannel(helpers.Result{Data: &entity.Organizer{}}))
+
+	result, err := suite.usecase.InviteMember(suite.ctx, request.InviteMemberReq{
+		OrganizerId: "organizerId", MemberId: "newId", Role: constants.OrganizerRoleEditor, UserId: "ownerId",
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success invite member", *result)
+}
+
+func (suite *CommandUsecaseTestSuite) TestInviteMemberAdmin() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+	suite.mockUserRepositoryQuery.On("FindOneUserId", mock.Anything, "newId").Return(mockChannel(helpers.Result{
+		Data: &userEntity.User{UserId: "newId"},
+	}))
+	suite.mockOrganizerRepositoryCommand.On("AddMember", mock.Anything, "organizerId", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &entity.Organizer{}}))
+
+	_, err := suite.usecase.InviteMember(suite.ctx, request.InviteMemberReq{
+		OrganizerId: "organizerId", MemberId: "newId", Role: constants.OrganizerRoleViewer, UserId: "adminId", UserRole: constants.RoleAdmin,
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestInviteMemberErrForbidden() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+
+	_, err := suite.usecase.InviteMember(suite.ctx, request.InviteMemberReq{
+		OrganizerId: "organizerId", MemberId: "newId", Role: constants.OrganizerRoleOwner, UserId: "editorId",
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+	suite.mockOrganizerRepositoryCommand.AssertNotCalled(suite.T(), "AddMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestInviteMemberErrNotFound() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.InviteMember(suite.ctx, request.InviteMemberReq{
+		OrganizerId: "organizerId", MemberId: "newId", Role: constants.OrganizerRoleEditor, UserId: "ownerId",
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestInviteMemberErrAlreadyMember() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+
+	_, err := suite.usecase.InviteMember(suite.ctx, request.InviteMemberReq{
+		OrganizerId: "organizerId", MemberId: "viewerId", Role: constants.OrganizerRoleEditor, UserId: "ownerId",
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestInviteMemberErrUserNotFound() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+	suite.mockUserRepositoryQuery.On("FindOneUserId", mock.Anything, "newId").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.InviteMember(suite.ctx, request.InviteMemberReq{
+		OrganizerId: "organizerId", MemberId: "newId", Role: constants.OrganizerRoleEditor, UserId: "ownerId",
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+	suite.mockOrganizerRepositoryCommand.AssertNotCalled(suite.T(), "AddMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestInviteMemberErrConcurrentInvite() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+	suite.mockUserRepositoryQuery.On("FindOneUserId", mock.Anything, "newId").Return(mockChannel(helpers.Result{
+		Data: &userEntity.User{UserId: "newId"},
+	}))
+	suite.mockOrganizerRepositoryCommand.On("AddMember", mock.Anything, "organizerId", mock.Anything).Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.InviteMember(suite.ctx, request.InviteMemberReq{
+		OrganizerId: "organizerId", MemberId: "newId", Role: constants.OrganizerRoleEditor, UserId: "ownerId",
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestRemoveMember() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+	suite.mockOrganizerRepositoryCommand.On("RemoveMember", mock.Anything, "organizerId", "editorId").
+		Return(mockChannel(helpers.Result{Data: &entity.Organizer{}}))
+
+	result, err := suite.usecase.RemoveMember(suite.ctx, request.RemoveMemberReq{
+		OrganizerId: "organizerId", MemberId: "editorId", UserId: "ownerId",
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Success remove member", *result)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRemoveMemberLeave() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+	suite.mockOrganizerRepositoryCommand.On("RemoveMember", mock.Anything, "organizerId", "viewerId").
+		Return(mockChannel(helpers.Result{Data: &entity.Organizer{}}))
+
+	_, err := suite.usecase.RemoveMember(suite.ctx, request.RemoveMemberReq{
+		OrganizerId: "organizerId", MemberId: "viewerId", UserId: "viewerId",
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRemoveMemberErrForbidden() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+
+	_, err := suite.usecase.RemoveMember(suite.ctx, request.RemoveMemberReq{
+		OrganizerId: "organizerId", MemberId: "viewerId", UserId: "editorId",
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+	suite.mockOrganizerRepositoryCommand.AssertNotCalled(suite.T(), "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRemoveMemberErrNotMember() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+
+	_, err := suite.usecase.RemoveMember(suite.ctx, request.RemoveMemberReq{
+		OrganizerId: "organizerId", MemberId: "other", UserId: "ownerId",
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+}
+
+func (suite *CommandUsecaseTestSuite) TestRemoveMemberErrLastOwner() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+	suite.mockOrganizerRepositoryCommand.On("RemoveMember", mock.Anything, "organizerId", "ownerId").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.RemoveMember(suite.ctx, request.RemoveMemberReq{
+		OrganizerId: "organizerId", MemberId: "ownerId", UserId: "ownerId",
+	})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusConflict, errString.Code())
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"go.elastic.co/apm"
+
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/organizer/models/entity"
+	"event-service/internal/modules/organizer/models/request"
+	"event-service/internal/modules/organizer/models/response"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/log"
+)
+
+type queryUsecase struct {
+	organizerRepositoryQuery organizer.MongodbRepositoryQuery
+	logger                   log.Logger
+}
+
+func NewQueryUsecase(orq organizer.MongodbRepositoryQuery, log log.Logger) organizer.UsecaseQuery {
+	return queryUsecase{
+		organizerRepositoryQuery: orq,
+		logger:                   log,
+	}
+}
+
+// FindOrganizer shows the team to its members, admins and support.
+func (q queryUsecase) FindOrganizer(origCtx context.Context, payload request.OrganizerReq) (*response.Organizer, error) {
+	domain := "organizerUsecase-FindOrganizer"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	organizer, err := findOrganizer(ctx, q.organizerRepositoryQuery, payload.OrganizerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.UserRole != constants.RoleAdmin && payload.UserRole != constants.RoleSupport &&
+		organizer.MemberRole(payload.UserId) == "" {
+		return nil, errors.ForbiddenError("you are not a member of this organizer")
+	}
+
+	result := toOrganizerResp(*organizer)
+	return &result, nil
+}
+
+func findOrganizer(ctx context.Context, organizerRepositoryQuery organizer.MongodbRepositoryQuery, organizerId string) (*entity.Organizer, error) {
+	organizerData := <-organizerRepositoryQuery.FindOrganizerById(ctx, organizerId)
+	if organizerData.Error != nil {
+		return nil, organizerData.Error
+	}
+
+	if organizerData.Data == nil {
+		return nil, errors.NotFound("organizer not found")
+	}
+
+	organizer, ok := organizerData.Data.(*entity.Organizer)
+	if !ok {
+		return nil, errors.InternalServerError("failed marshal organizer")
+	}
+	return organizer, nil
+}
+
+func toOrganizerResp(organizer entity.Organizer) response.Organizer {
+	members := make([]response.Member, 0, len(organizer.Members))
+	for _, member := range organizer.Members {
+		members = append(members, response.Member{
+			UserId:    member.UserId,
+			Role:      member.Role,
+			InvitedBy: member.InvitedBy,
+			JoinedAt:  member.JoinedAt,
+		})
+	}
+	return response.Organizer{
+		OrganizerId: organizer.OrganizerId,
+		Name:        organizer.Name,
+		Members:     members,
+		CreatedAt:   organizer.CreatedAt,
+		CreatedBy:   organizer.CreatedBy,
+	}
+}
@@ -0,0 +1,108 @@
+package usecases_test
+
+import (
+	"context"
+	"event-service/internal/modules/organizer"
+	"event-service/internal/modules/organizer/models/entity"
+	"event-service/internal/modules/organizer/models/request"
+	uc "event-service/internal/modules/organizer/usecases"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	mockcert "event-service/mocks/modules/organizer"
+	mocklog "event-service/mocks/pkg/log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryUsecaseTestSuite struct {
+	suite.Suite
+	mockOrganizerRepositoryQuery *mockcert.MongodbRepositoryQuery
+	mockLogger                   *mocklog.Logger
+	usecase                      organizer.UsecaseQuery
+	ctx                          context.Context
+}
+
+func (suite *QueryUsecaseTestSuite) SetupTest() {
+	suite.mockOrganizerRepositoryQuery = &mockcert.MongodbRepositoryQuery{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewQueryUsecase(
+		suite.mockOrganizerRepositoryQuery,
+		suite.mockLogger,
+	)
+}
+
+func TestQueryUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryUsecaseTestSuite))
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result, 1)
+	responseChan <- result
+	close(responseChan)
+	return responseChan
+}
+
+var mockOrganizer = helpers.Result{
+	Data: &entity.Organizer{
+		OrganizerId: "organizerId",
+		Name:        "name",
+		Members: []entity.Member{
+			{UserId: "ownerId", Role: constants.OrganizerRoleOwner},
+			{UserId: "editorId", Role: constants.OrganizerRoleEditor},
+			{UserId: "viewerId", Role: constants.OrganizerRoleViewer},
+		},
+	},
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOrganizer() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+
+	result, err := suite.usecase.FindOrganizer(suite.ctx, request.OrganizerReq{OrganizerId: "organizerId", UserId: "viewerId"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "organizerId", result.OrganizerId)
+	assert.Len(suite.T(), result.Members, 3)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOrganizerSupport() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+
+	_, err := suite.usecase.FindOrganizer(suite.ctx, request.OrganizerReq{
+		OrganizerId: "organizerId", UserId: "supportId", UserRole: constants.RoleSupport,
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOrganizerErrForbidden() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(mockOrganizer))
+
+	result, err := suite.usecase.FindOrganizer(suite.ctx, request.OrganizerReq{
+		OrganizerId: "organizerId", UserId: "other", UserRole: constants.RoleOrganizer,
+	})
+	assert.Nil(suite.T(), result)
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusForbidden, errString.Code())
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOrganizerErrNotFound() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").Return(mockChannel(helpers.Result{}))
+
+	_, err := suite.usecase.FindOrganizer(suite.ctx, request.OrganizerReq{OrganizerId: "organizerId", UserId: "ownerId"})
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), http.StatusNotFound, errString.Code())
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindOrganizerErr() {
+	suite.mockOrganizerRepositoryQuery.On("FindOrganizerById", mock.Anything, "organizerId").
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error mongodb connection")}))
+
+	_, err := suite.usecase.FindOrganizer(suite.ctx, request.OrganizerReq{OrganizerId: "organizerId", UserId: "ownerId"})
+	assert.Error(suite.T(), err)
+}
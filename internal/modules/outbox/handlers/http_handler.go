@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"event-service/internal/modules/outbox"
+	"event-service/internal/modules/outbox/models/request"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+	"event-service/internal/pkg/redis"
+
+	middlewares "event-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type OutboxHttpHandler struct {
+	OutboxUsecaseQuery outbox.UsecaseQuery
+	Logger             log.Logger
+	Validator          *validator.Validate
+}
+
+func InitOutboxHttpHandler(app *fiber.App, ouq outbox.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &OutboxHttpHandler{
+		OutboxUsecaseQuery: ouq,
+		Logger:             log,
+		Validator:          validator.New(),
+	}
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	handler.RegisterRoutes(app.Group("/api/event"), middlewares.VerifyBearer())
+}
+
+// RegisterRoutes declares the permission each route needs, auth must set the userId and userRole locals.
+func (o OutboxHttpHandler) RegisterRoutes(route fiber.Router, auth fiber.Handler) {
+	route.Get("/v1/admin/outbox/stuck", auth, middlewares.RequirePermission(constants.PermissionOutboxRead), o.GetStuckMessages)
+}
+
+func (o OutboxHttpHandler) GetStuckMessages(c *fiber.Ctx) error {
+	req := new(request.StuckMessageReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.UserRole, _ = c.Locals("userRole").(string)
+
+	if err := o.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := o.OutboxUsecaseQuery.FindStuckMessages(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, o.Logger, err)
+	}
+	return helpers.RespPagination(c, o.Logger, resp.CollectionData, resp.MetaData, "Get stuck outbox messages success")
+}
@@ -0,0 +1,134 @@
+package handlers_test
+
+import (
+	"event-service/internal/modules/outbox/handlers"
+	"event-service/internal/modules/outbox/models/request"
+	"event-service/internal/modules/outbox/models/response"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/log"
+	mockcert "event-service/mocks/modules/outbox"
+	mocklog "event-service/mocks/pkg/log"
+	mockredis "event-service/mocks/pkg/redis"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OutboxHttpHandlerTestSuite struct {
+	suite.Suite
+
+	cUQ       *mockcert.UsecaseQuery
+	cLog      *mocklog.Logger
+	validator *validator.Validate
+	handler   *handlers.OutboxHttpHandler
+	cRedis    *mockredis.Collections
+	app       *fiber.App
+}
+
+func (suite *OutboxHttpHandlerTestSuite) SetupTest() {
+	suite.cUQ = new(mockcert.UsecaseQuery)
+	suite.cLog = new(mocklog.Logger)
+	suite.validator = validator.New()
+	suite.cRedis = new(mockredis.Collections)
+	suite.handler = &handlers.OutboxHttpHandler{
+		OutboxUsecaseQuery: suite.cUQ,
+		Logger:             suite.cLog,
+		Validator:          suite.validator,
+	}
+	suite.app = fiber.New()
+	handlers.InitOutboxHttpHandler(suite.app, suite.cUQ, suite.cLog, suite.cRedis)
+}
+
+func TestOutboxHttpHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxHttpHandlerTestSuite))
+}
+
+func (suite *OutboxHttpHandlerTestSuite) TestGetStuckMessages() {
+	response := &response.OutboxResp{
+		CollectionData: []response.Outbox{
+			{
+				OutboxId: "id",
+			},
+		},
+		MetaData: constants.MetaData{},
+	}
+	suite.cUQ.On("FindStuckMessages", mock.Anything, mock.MatchedBy(func(req request.StuckMessageReq) bool {
+		return req.UserRole == constants.RoleAdmin && req.OlderThanMinutes == 10
+	})).Return(response, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/admin/outbox/stuck", func(c *fiber.Ctx) error {
+		c.Locals("userRole", constants.RoleAdmin)
+		return c.Next()
+	}, suite.handler.GetStuckMessages)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/admin/outbox/stuck?page=1&size=10&olderThanMinutes=10", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *OutboxHttpHandlerTestSuite) TestGetStuckMessagesErrValidation() {
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/admin/outbox/stuck", suite.handler.GetStuckMessages)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/admin/outbox/stuck", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *OutboxHttpHandlerTestSuite) TestGetStuckMessagesErrForbidden() {
+	suite.cUQ.On("FindStuckMessages", mock.Anything, mock.Anything).Return(nil, errors.ForbiddenError("only an admin can list outbox messages"))
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Get("/v1/admin/outbox/stuck", suite.handler.GetStuckMessages)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/admin/outbox/stuck?page=1&size=10", nil)
+
+	resp, err := app.Test(req)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusForbidden, resp.StatusCode)
+}
+
+func (suite *OutboxHttpHandlerTestSuite) TestRoutePermissions() {
+	// the role middleware logs through the global logger
+	log.Init((&log.LoggerConf{}).Clone(zap.NewNop()))
+	suite.cUQ.On("FindStuckMessages", mock.Anything, mock.Anything).Return(&response.OutboxResp{}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	for role, status := range map[string]int{
+		constants.RoleAdmin:     fiber.StatusOK,
+		constants.RoleOrganizer: fiber.StatusForbidden,
+		constants.RoleFan:       fiber.StatusForbidden,
+		constants.RoleSupport:   fiber.StatusForbidden,
+	} {
+		role := role
+		app := fiber.New()
+		suite.handler.RegisterRoutes(app, func(c *fiber.Ctx) error {
+			c.Locals("userRole", role)
+			return c.Next()
+		})
+
+		req := httptest.NewRequest(fiber.MethodGet, "/v1/admin/outbox/stuck?page=1&size=10", nil)
+
+		resp, err := app.Test(req)
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), status, resp.StatusCode, role)
+	}
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type Outbox struct {
+	OutboxId      string    `json:"outboxId" bson:"outboxId"`
+	Topic         string    `json:"topic" bson:"topic"`
+	Key           string    `json:"key" bson:"key"`
+	Payload       string    `json:"payload" bson:"payload"`
+	Status        string    `json:"status" bson:"status"`
+	Attempts      int       `json:"attempts" bson:"attempts"`
+	LastError     string    `json:"lastError" bson:"lastError"`
+	NextAttemptAt time.Time `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	SentAt        time.Time `json:"sentAt,omitempty" bson:"sentAt,omitempty"`
+	CreatedAt     time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt" bson:"updatedAt"`
+}
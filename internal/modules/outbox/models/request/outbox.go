@@ -0,0 +1,8 @@
+package request
+
+type StuckMessageReq struct {
+	Page             int64  `query:"page" validate:"required"`
+	Size             int64  `query:"size" validate:"required"`
+	OlderThanMinutes int64  `query:"olderThanMinutes"`
+	UserRole         string `json:"userRole"`
+}
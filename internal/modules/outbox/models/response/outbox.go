@@ -0,0 +1,23 @@
+package response
+
+import (
+	"event-service/internal/pkg/constants"
+	"time"
+)
+
+type Outbox struct {
+	OutboxId      string    `json:"outboxId"`
+	Topic         string    `json:"topic"`
+	Key           string    `json:"key"`
+	Payload       string    `json:"payload"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"lastError"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type OutboxResp struct {
+	CollectionData []Outbox
+	MetaData       constants.MetaData
+}
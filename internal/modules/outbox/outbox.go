@@ -0,0 +1,29 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"event-service/internal/modules/outbox/models/entity"
+	"event-service/internal/modules/outbox/models/request"
+	"event-service/internal/modules/outbox/models/response"
+	wrapper "event-service/internal/pkg/helpers"
+)
+
+type UsecaseQuery interface {
+	FindStuckMessages(origCtx context.Context, payload request.StuckMessageReq) (*response.OutboxResp, error)
+}
+
+type UsecaseCommand interface {
+	RelayMessages(origCtx context.Context) error
+}
+
+type MongodbRepositoryQuery interface {
+	FindDueMessages(ctx context.Context, now time.Time, limit int64) <-chan wrapper.Result
+	FindStuckMessages(ctx context.Context, createdBefore time.Time, payload request.StuckMessageReq) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	InsertManyOutbox(ctx context.Context, messages []entity.Outbox) <-chan wrapper.Result
+	UpdateOneOutbox(ctx context.Context, message entity.Outbox) <-chan wrapper.Result
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"event-service/internal/modules/outbox"
+	"event-service/internal/modules/outbox/models/entity"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/databases/mongodb"
+	wrapper "event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) outbox.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) InsertManyOutbox(ctx context.Context, messages []entity.Outbox) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		var documentsInsert []interface{}
+		for _, v := range messages {
+			documentsInsert = append(documentsInsert, v)
+		}
+		resp := <-c.mongoDb.InsertMany(mongodb.InsertMany{
+			CollectionName: "outbox",
+			Documents:      documentsInsert,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateOneOutbox only writes a message still pending, a delivery report arriving late must not flip a message
+// marked sent back to pending.
+func (c commandMongodbRepository) UpdateOneOutbox(ctx context.Context, message entity.Outbox) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpdateOne(mongodb.UpdateOne{
+			CollectionName: "outbox",
+			Filter: bson.M{
+				"outboxId": message.OutboxId,
+				"status":   constants.OutboxStatusPending,
+			},
+			Document: message,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
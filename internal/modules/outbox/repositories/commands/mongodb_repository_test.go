@@ -0,0 +1,81 @@
+package commands_test
+
+import (
+	"context"
+	"event-service/internal/modules/outbox"
+	"event-service/internal/modules/outbox/models/entity"
+	mongoRC "event-service/internal/modules/outbox/repositories/commands"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/helpers"
+	mocks "event-service/mocks/pkg/databases/mongodb"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type CommandTestSuite struct {
+	suite.Suite
+	mockMongodb *mocks.Collections
+	mockLogger  *mocklog.Logger
+	repository  outbox.MongodbRepositoryCommand
+	ctx         context.Context
+}
+
+func (suite *CommandTestSuite) SetupTest() {
+	suite.mockMongodb = new(mocks.Collections)
+	suite.mockLogger = &mocklog.Logger{}
+	suite.repository = mongoRC.NewCommandMongodbRepository(
+		suite.mockMongodb,
+		suite.mockLogger,
+	)
+	suite.ctx = context.Background()
+}
+
+func TestCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandTestSuite))
+}
+
+func (suite *CommandTestSuite) TestInsertManyOutbox() {
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("InsertMany", mock.MatchedBy(func(payload mongodb.InsertMany) bool {
+		return payload.CollectionName == "outbox" && len(payload.Documents) == 2
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := suite.repository.InsertManyOutbox(suite.ctx, []entity.Outbox{{OutboxId: "1"}, {OutboxId: "2"}})
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	<-result
+
+	suite.mockMongodb.AssertCalled(suite.T(), "InsertMany", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandTestSuite) TestUpdateOneOutbox() {
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("UpdateOne", mock.MatchedBy(func(payload mongodb.UpdateOne) bool {
+		filter, ok := payload.Filter.(bson.M)
+		return payload.CollectionName == "outbox" && ok && filter["outboxId"] == "1" &&
+			filter["status"] == constants.OutboxStatusPending
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := suite.repository.UpdateOneOutbox(suite.ctx, entity.Outbox{OutboxId: "1"})
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	<-result
+
+	suite.mockMongodb.AssertCalled(suite.T(), "UpdateOne", mock.Anything, mock.Anything)
+}
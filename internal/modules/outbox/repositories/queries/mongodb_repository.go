@@ -0,0 +1,81 @@
+package queries
+
+import (
+	"context"
+	"event-service/internal/modules/outbox"
+	"event-service/internal/modules/outbox/models/entity"
+	"event-service/internal/modules/outbox/models/request"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/databases/mongodb"
+	wrapper "event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) outbox.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindDueMessages(ctx context.Context, now time.Time, limit int64) <-chan wrapper.Result {
+	var messages []entity.Outbox
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindAllData(mongodb.FindAllData{
+			Result:         &messages,
+			CollectionName: "outbox",
+			Filter: bson.M{
+				"status":        constants.OutboxStatusPending,
+				"nextAttemptAt": bson.M{"$lte": now},
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "createdAt",
+				By:        mongodb.SortAscending,
+			},
+			Page: 1,
+			Size: limit,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindStuckMessages(ctx context.Context, createdBefore time.Time, payload request.StuckMessageReq) <-chan wrapper.Result {
+	var messages []entity.Outbox
+	var countData int64
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindAllData(mongodb.FindAllData{
+			Result:         &messages,
+			CountData:      &countData,
+			CollectionName: "outbox",
+			Filter: bson.M{
+				"status":    constants.OutboxStatusPending,
+				"createdAt": bson.M{"$lte": createdBefore},
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "createdAt",
+				By:        mongodb.SortAscending,
+			},
+			Page: payload.Page,
+			Size: payload.Size,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,81 @@
+package queries_test
+
+import (
+	"context"
+	"event-service/internal/modules/outbox"
+	"event-service/internal/modules/outbox/models/request"
+	mongoRQ "event-service/internal/modules/outbox/repositories/queries"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/helpers"
+	mocks "event-service/mocks/pkg/databases/mongodb"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type QueryTestSuite struct {
+	suite.Suite
+	mockMongodb *mocks.Collections
+	mockLogger  *mocklog.Logger
+	repository  outbox.MongodbRepositoryQuery
+	ctx         context.Context
+}
+
+func (suite *QueryTestSuite) SetupTest() {
+	suite.mockMongodb = new(mocks.Collections)
+	suite.mockLogger = &mocklog.Logger{}
+	suite.repository = mongoRQ.NewQueryMongodbRepository(
+		suite.mockMongodb,
+		suite.mockLogger,
+	)
+	suite.ctx = context.Background()
+}
+
+func TestQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryTestSuite))
+}
+
+func (suite *QueryTestSuite) TestFindDueMessages() {
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		filter, ok := payload.Filter.(bson.M)
+		return ok && payload.CollectionName == "outbox" && filter["status"] == constants.OutboxStatusPending && payload.Size == 100
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := suite.repository.FindDueMessages(suite.ctx, time.Now(), 100)
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	<-result
+
+	suite.mockMongodb.AssertCalled(suite.T(), "FindAllData", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryTestSuite) TestFindStuckMessages() {
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		return payload.CollectionName == "outbox" && payload.CountData != nil && payload.Page == 2
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := suite.repository.FindStuckMessages(suite.ctx, time.Now(), request.StuckMessageReq{Page: 2, Size: 10})
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	<-result
+
+	suite.mockMongodb.AssertCalled(suite.T(), "FindAllData", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.elastic.co/apm"
+
+	"event-service/internal/modules/outbox"
+	"event-service/internal/modules/outbox/models/entity"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/log"
+
+	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
+)
+
+const (
+	relayBatchSize  = 100
+	maxRetryBackoff = 10 * time.Minute
+	// relayDeliveryTimeout bounds the wait for the delivery reports of a run without a deadline.
+	relayDeliveryTimeout = 5 * time.Second
+)
+
+type commandUsecase struct {
+	outboxRepositoryQuery   outbox.MongodbRepositoryQuery
+	outboxRepositoryCommand outbox.MongodbRepositoryCommand
+	kafkaProducer           kafkaConfluent.Producer
+	logger                  log.Logger
+}
+
+func NewCommandUsecase(orq outbox.MongodbRepositoryQuery, orc outbox.MongodbRepositoryCommand, kp kafkaConfluent.Producer, log log.Logger) outbox.UsecaseCommand {
+	return commandUsecase{
+		outboxRepositoryQuery:   orq,
+		outboxRepositoryCommand: orc,
+		kafkaProducer:           kp,
+		logger:                  log,
+	}
+}
+
+// RelayMessages publishes due outbox messages as one batch and waits for their delivery reports.
+// A failed message is retried later with exponential backoff.
+func (c commandUsecase) RelayMessages(origCtx context.Context) error {
+	domain := "outboxUsecase-RelayMessages"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.outboxRepositoryQuery.FindDueMessages(ctx, time.Now(), relayBatchSize)
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil
+	}
+
+	messages, ok := resp.Data.(*[]entity.Outbox)
+	if !ok {
+		return errors.InternalServerError("cannot parsing data")
+	}
+
+	c.deliver(ctx, *messages)
+
+	return nil
+}
+
+func (c commandUsecase) deliver(ctx context.Context, messages []entity.Outbox) {
+	batch := make([]kafkaConfluent.Message, 0, len(messages))
+	for _, message := range messages {
+		batch = append(batch, kafkaConfluent.Message{
+			Topic: message.Topic,
+			Key:   []byte(message.Key),
+			Value: []byte(message.Payload),
+		})
+	}
+	deliveryCtx, cancel := deliveryContext(ctx)
+	defer cancel()
+	errs := c.kafkaProducer.PublishBatchSync(deliveryCtx, batch)
+
+	for i, message := range messages {
+		now := time.Now()
+		if errs[i] != nil {
+			message.Attempts++
+			message.LastError = errs[i].Error()
+			message.NextAttemptAt = now.Add(retryBackoff(message.Attempts))
+			c.logger.Error(ctx, fmt.Sprintf("Failed relay outbox message, outboxId : %s", message.OutboxId), errs[i].Error())
+		} else {
+			message.Status = constants.OutboxStatusSent
+			message.SentAt = now
+			c.logger.Info(ctx, fmt.Sprintf("Relay outbox message, outboxId : %s", message.OutboxId), message.Topic)
+		}
+		message.UpdatedAt = now
+
+		respUpdate := <-c.outboxRepositoryCommand.UpdateOneOutbox(ctx, message)
+		if respUpdate.Error != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed update outbox message, outboxId : %s", message.OutboxId), respUpdate.Error.Error())
+		}
+	}
+}
+
+// deliveryContext waits for the delivery reports at most half of the time left in the run, the other half records
+// them. A message that is not reported in time counts as failed and is retried.
+func deliveryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithTimeout(ctx, relayDeliveryTimeout)
+	}
+	return context.WithTimeout(ctx, time.Until(deadline)/2)
+}
+
+func retryBackoff(attempts int) time.Duration {
+	if attempts > 10 {
+		return maxRetryBackoff
+	}
+	backoff := time.Duration(1<<uint(attempts)) * time.Second
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,140 @@
+package usecases_test
+
+import (
+	"context"
+	"event-service/internal/modules/outbox"
+	"event-service/internal/modules/outbox/models/entity"
+	uc "event-service/internal/modules/outbox/usecases"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
+	mockcert "event-service/mocks/modules/outbox"
+	mockkafka "event-service/mocks/pkg/kafka"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockOutboxRepositoryQuery   *mockcert.MongodbRepositoryQuery
+	mockOutboxRepositoryCommand *mockcert.MongodbRepositoryCommand
+	mockKafkaProducer           *mockkafka.Producer
+	mockLogger                  *mocklog.Logger
+	usecase                     outbox.UsecaseCommand
+	ctx                         context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockOutboxRepositoryQuery = &mockcert.MongodbRepositoryQuery{}
+	suite.mockOutboxRepositoryCommand = &mockcert.MongodbRepositoryCommand{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockOutboxRepositoryQuery,
+		suite.mockOutboxRepositoryCommand,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestRelayMessages() {
+	mockMessages := helpers.Result{
+		Data: &[]entity.Outbox{
+			{OutboxId: "sent", Topic: "topic", Key: "key", Payload: "{}", Status: constants.OutboxStatusPending},
+			{OutboxId: "failed", Topic: "topic", Key: "key2", Payload: "{}", Status: constants.OutboxStatusPending, Attempts: 2},
+		},
+	}
+
+	suite.mockOutboxRepositoryQuery.On("FindDueMessages", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(mockMessages))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.MatchedBy(func(batch []kafkaConfluent.Message) bool {
+		return len(batch) == 2 && string(batch[0].Key) == "key" && string(batch[1].Key) == "key2"
+	})).Return([]error{nil, errors.InternalServerError("broker down")})
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.RelayMessages(suite.ctx)
+	assert.NoError(suite.T(), err)
+	suite.mockOutboxRepositoryCommand.AssertCalled(suite.T(), "UpdateOneOutbox", mock.Anything, mock.MatchedBy(func(m entity.Outbox) bool {
+		return m.OutboxId == "sent" && m.Status == constants.OutboxStatusSent && !m.SentAt.IsZero()
+	}))
+	suite.mockOutboxRepositoryCommand.AssertCalled(suite.T(), "UpdateOneOutbox", mock.Anything, mock.MatchedBy(func(m entity.Outbox) bool {
+		return m.OutboxId == "failed" && m.Status == constants.OutboxStatusPending && m.Attempts == 3 &&
+			m.LastError != "" && m.NextAttemptAt.After(time.Now().Add(7*time.Second))
+	}))
+}
+
+func (suite *CommandUsecaseTestSuite) TestRelayMessagesDeliveryDeadline() {
+	mockMessages := helpers.Result{
+		Data: &[]entity.Outbox{
+			{OutboxId: "sent", Topic: "topic", Key: "key", Payload: "{}", Status: constants.OutboxStatusPending},
+		},
+	}
+	ctx, cancel := context.WithTimeout(suite.ctx, 4*time.Second)
+	defer cancel()
+	runDeadline, _ := ctx.Deadline()
+
+	suite.mockOutboxRepositoryQuery.On("FindDueMessages", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(mockMessages))
+	// the delivery reports are awaited at most half of the run, the rest is left to record them
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.MatchedBy(func(ctx context.Context) bool {
+		deadline, ok := ctx.Deadline()
+		return ok && deadline.Before(runDeadline.Add(-time.Second))
+	}), mock.Anything).Return([]error{nil})
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.RelayMessages(ctx)
+	assert.NoError(suite.T(), err)
+	suite.mockKafkaProducer.AssertExpectations(suite.T())
+}
+
+func (suite *CommandUsecaseTestSuite) TestRelayMessagesEmpty() {
+	suite.mockOutboxRepositoryQuery.On("FindDueMessages", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+
+	err := suite.usecase.RelayMessages(suite.ctx)
+	assert.NoError(suite.T(), err)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "PublishBatchSync", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRelayMessagesErr() {
+	suite.mockOutboxRepositoryQuery.On("FindDueMessages", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	err := suite.usecase.RelayMessages(suite.ctx)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRelayMessagesErrParse() {
+	suite.mockOutboxRepositoryQuery.On("FindDueMessages", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "wrong"}))
+
+	err := suite.usecase.RelayMessages(suite.ctx)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRelayMessagesErrUpdate() {
+	mockMessages := helpers.Result{
+		Data: &[]entity.Outbox{
+			{OutboxId: "sent", Topic: "topic", Key: "key", Payload: "{}", Status: constants.OutboxStatusPending},
+		},
+	}
+
+	suite.mockOutboxRepositoryQuery.On("FindDueMessages", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(mockMessages))
+	suite.mockKafkaProducer.On("PublishBatchSync", mock.Anything, mock.Anything).Return([]error{nil})
+	suite.mockOutboxRepositoryCommand.On("UpdateOneOutbox", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.RelayMessages(suite.ctx)
+	assert.NoError(suite.T(), err)
+	suite.mockLogger.AssertCalled(suite.T(), "Error", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	"event-service/internal/modules/outbox"
+	"event-service/internal/modules/outbox/models/entity"
+	"event-service/internal/modules/outbox/models/request"
+	"event-service/internal/modules/outbox/models/response"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+	"fmt"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+const defaultStuckAfterMinutes = 5
+
+type queryUsecase struct {
+	outboxRepositoryQuery outbox.MongodbRepositoryQuery
+	logger                log.Logger
+}
+
+func NewQueryUsecase(orq outbox.MongodbRepositoryQuery, log log.Logger) outbox.UsecaseQuery {
+	return queryUsecase{
+		outboxRepositoryQuery: orq,
+		logger:                log,
+	}
+}
+
+func (q queryUsecase) FindStuckMessages(origCtx context.Context, payload request.StuckMessageReq) (*response.OutboxResp, error) {
+	domain := "outboxUsecase-FindStuckMessages"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if payload.UserRole != constants.RoleAdmin {
+		return nil, errors.ForbiddenError("only an admin can list outbox messages")
+	}
+
+	olderThan := payload.OlderThanMinutes
+	if olderThan <= 0 {
+		olderThan = defaultStuckAfterMinutes
+	}
+
+	resp := <-q.outboxRepositoryQuery.FindStuckMessages(ctx, time.Now().Add(-time.Duration(olderThan)*time.Minute), payload)
+	if resp.Error != nil {
+		msg := "Error query outbox"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	collectionData := make([]response.Outbox, 0)
+	var total int64
+	if resp.Data != nil {
+		messages, ok := resp.Data.(*[]entity.Outbox)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+
+		for _, value := range *messages {
+			collectionData = append(collectionData, response.Outbox{
+				OutboxId:      value.OutboxId,
+				Topic:         value.Topic,
+				Key:           value.Key,
+				Payload:       value.Payload,
+				Status:        value.Status,
+				Attempts:      value.Attempts,
+				LastError:     value.LastError,
+				NextAttemptAt: value.NextAttemptAt,
+				CreatedAt:     value.CreatedAt,
+			})
+		}
+		total = int64(len(*messages))
+	}
+
+	return &response.OutboxResp{
+		CollectionData: collectionData,
+		MetaData:       helpers.GenerateMetaData(resp.Count, total, payload.Page, payload.Size),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package usecases_test
+
+import (
+	"context"
+	"event-service/internal/modules/outbox"
+	"event-service/internal/modules/outbox/models/entity"
+	"event-service/internal/modules/outbox/models/request"
+	uc "event-service/internal/modules/outbox/usecases"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	mockcert "event-service/mocks/modules/outbox"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryUsecaseTestSuite struct {
+	suite.Suite
+	mockOutboxRepositoryQuery *mockcert.MongodbRepositoryQuery
+	mockLogger                *mocklog.Logger
+	usecase                   outbox.UsecaseQuery
+	ctx                       context.Context
+}
+
+func (suite *QueryUsecaseTestSuite) SetupTest() {
+	suite.mockOutboxRepositoryQuery = &mockcert.MongodbRepositoryQuery{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewQueryUsecase(
+		suite.mockOutboxRepositoryQuery,
+		suite.mockLogger,
+	)
+}
+
+func TestQueryUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryUsecaseTestSuite))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindStuckMessages() {
+	payload := request.StuckMessageReq{
+		Page:     1,
+		Size:     10,
+		UserRole: constants.RoleAdmin,
+	}
+	mockMessages := helpers.Result{
+		Data: &[]entity.Outbox{
+			{OutboxId: "id", Topic: "topic", Attempts: 4},
+		},
+		Count: 1,
+	}
+
+	suite.mockOutboxRepositoryQuery.On("FindStuckMessages", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		// default threshold is five minutes
+		return before.Before(time.Now().Add(-4 * time.Minute))
+	}), payload).Return(mockChannel(mockMessages))
+
+	result, err := suite.usecase.FindStuckMessages(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.CollectionData, 1)
+	assert.Equal(suite.T(), 4, result.CollectionData[0].Attempts)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindStuckMessagesErrForbidden() {
+	payload := request.StuckMessageReq{
+		Page:     1,
+		Size:     10,
+		UserRole: "fan",
+	}
+
+	_, err := suite.usecase.FindStuckMessages(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+	suite.mockOutboxRepositoryQuery.AssertNotCalled(suite.T(), "FindStuckMessages", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindStuckMessagesErr() {
+	payload := request.StuckMessageReq{
+		Page:     1,
+		Size:     10,
+		UserRole: constants.RoleAdmin,
+	}
+
+	suite.mockOutboxRepositoryQuery.On("FindStuckMessages", mock.Anything, mock.Anything, payload).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.usecase.FindStuckMessages(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindStuckMessagesErrParse() {
+	payload := request.StuckMessageReq{
+		Page:     1,
+		Size:     10,
+		UserRole: constants.RoleAdmin,
+	}
+
+	suite.mockOutboxRepositoryQuery.On("FindStuckMessages", mock.Anything, mock.Anything, payload).Return(mockChannel(helpers.Result{Data: "wrong"}))
+
+	_, err := suite.usecase.FindStuckMessages(suite.ctx, payload)
+	assert.Error(suite.T(), err)
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
@@ -10,22 +10,29 @@ type Country struct {
 }
 
 type Ticket struct {
-	TicketId       string    `json:"ticketId" bson:"ticketId"`
-	EventId        string    `json:"eventId" bson:"eventId"`
-	TicketType     string    `json:"ticketType" bson:"ticketType"`
-	TicketPrice    int       `json:"ticketPrice" bson:"ticketPrice"`
-	TotalQuota     int       `json:"totalQuota" bson:"totalQuota"`
-	TotalRemaining int       `json:"totalRemaining" bson:"totalRemaining"`
-	ContinentName  string    `json:"continentName" bson:"continentName"`
-	ContinentCode  string    `json:"continentCode" bson:"continentCode"`
-	Country        Country   `json:"country" bson:"country"`
-	Tag            string    `json:"tag" bson:"tag"`
-	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+	TicketId           string    `json:"ticketId" bson:"ticketId"`
+	EventId            string    `json:"eventId" bson:"eventId"`
+	TicketType         string    `json:"ticketType" bson:"ticketType"`
+	TicketPrice        int       `json:"ticketPrice" bson:"ticketPrice"`
+	TotalQuota         int       `json:"totalQuota" bson:"totalQuota"`
+	TotalRemaining     int       `json:"totalRemaining" bson:"totalRemaining"`
+	ContinentName      string    `json:"continentName" bson:"continentName"`
+	ContinentCode      string    `json:"continentCode" bson:"continentCode"`
+	Country            Country   `json:"country" bson:"country"`
+	Tag                string    `json:"tag" bson:"tag"`
+	IsSellable         bool      `json:"isSellable" bson:"isSellable"`
+	ProvisioningStatus string    `json:"provisioningStatus" bson:"provisioningStatus"`
+	ProvisioningError  string    `json:"provisioningError,omitempty" bson:"provisioningError,omitempty"`
+	SalesStartAt       time.Time `json:"salesStartAt,omitempty" bson:"salesStartAt,omitempty"`
+	SalesEndAt         time.Time `json:"salesEndAt,omitempty" bson:"salesEndAt,omitempty"`
+	CreatedAt          time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt" bson:"updatedAt"`
 }
 
 type OnlineTicketConfig struct {
 	Tag         string        `json:"tag" bson:"tag"`
+	OrganizerId string        `json:"organizerId" bson:"organizerId,omitempty"`
+	Version     int           `json:"version" bson:"version"`
 	TotalQuota  int           `json:"totalQuota" bson:"totalQuota"`
 	CountryList []CountryList `json:"countryList" bson:"countryList"`
 	CreatedAt   time.Time     `json:"createdAt" bson:"createdAt"`
@@ -34,14 +41,60 @@ type OnlineTicketConfig struct {
 	UpdatedBy   string        `json:"updatedBy" bson:"updatedBy"`
 }
 
+// OnlineTicketConfigHistory is a snapshot of an online ticket config written on every change, so the country
+// percentages can be traced back to who changed them and when.
+type OnlineTicketConfigHistory struct {
+	Tag         string        `json:"tag" bson:"tag"`
+	OrganizerId string        `json:"organizerId" bson:"organizerId,omitempty"`
+	Version     int           `json:"version" bson:"version"`
+	Action      string        `json:"action" bson:"action"`
+	TotalQuota  int           `json:"totalQuota" bson:"totalQuota"`
+	CountryList []CountryList `json:"countryList" bson:"countryList"`
+	CreatedBy   string        `json:"createdBy" bson:"createdBy"`
+	ChangedBy   string        `json:"changedBy" bson:"changedBy"`
+	ChangedAt   time.Time     `json:"changedAt" bson:"changedAt"`
+}
+
+// CountryList is the share of an online ticket config sold to one country. Quota is the materialized part of the
+// config TotalQuota, Sold counts the tickets already sold against it.
 type CountryList struct {
 	CountryNumber int    `json:"countryNumber" bson:"countryNumber"`
 	Percentage    int    `json:"percentage" bson:"percentage"`
 	CountryCode   string `json:"countryCode" bson:"countryCode"`
+	CountryName   string `json:"countryName" bson:"countryName"`
+	Quota         int    `json:"quota" bson:"quota"`
+	Sold          int    `json:"sold" bson:"sold"`
 }
 
 type AggregateTotalTicket struct {
 	Id                   string `json:"_id" bson:"_id"`
 	CountryName          string `json:"countryName" bson:"countryName"`
+	TotalQuota           int    `json:"totalQuota" bson:"totalQuota"`
+	TotalSoldTicket      int    `json:"totalSoldTicket" bson:"totalSoldTicket"`
 	TotalAvailableTicket int    `json:"totalAvailableTicket" bson:"totalAvailableTicket"`
 }
+
+type TicketHold struct {
+	HoldId   string `json:"holdId" bson:"holdId"`
+	TicketId string `json:"ticketId" bson:"ticketId"`
+	EventId  string `json:"eventId" bson:"eventId"`
+	UserId   string `json:"userId" bson:"userId"`
+	Quantity int    `json:"quantity" bson:"quantity"`
+	// CountryCode is the country of the buyer, a sale of an online ticket counts in its quota
+	CountryCode string    `json:"countryCode" bson:"countryCode"`
+	ExpiresAt   time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+type TicketStock struct {
+	TicketId  string `json:"ticketId" bson:"ticketId"`
+	Available int    `json:"available" bson:"available"`
+	Held      int    `json:"held" bson:"held"`
+}
+
+// TicketFilter selects the tickets an event must offer to be listed. Zero prices do not bound the range.
+type TicketFilter struct {
+	TicketType string
+	MinPrice   int
+	MaxPrice   int
+	Available  bool
+}
@@ -7,8 +7,10 @@ import (
 	"event-service/internal/pkg/databases/mongodb"
 	wrapper "event-service/internal/pkg/helpers"
 	"event-service/internal/pkg/log"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type commandMongodbRepository struct {
@@ -42,6 +44,27 @@ func (c commandMongodbRepository) InsertManyTicketCollection(ctx context.Context
 	return output
 }
 
+// FindOnlineTicketConfigByTag reads the config of a tag from the master, so a transaction that writes the config
+// reads it in its own snapshot.
+func (c commandMongodbRepository) FindOnlineTicketConfigByTag(ctx context.Context, tag string) <-chan wrapper.Result {
+	var onlineTicketConfig entity.OnlineTicketConfig
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &onlineTicketConfig,
+			CollectionName: "online-ticket-config",
+			Filter: bson.M{
+				"tag": tag,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
 func (c commandMongodbRepository) UpsertOneOnlineTicketConfig(ctx context.Context, payload entity.OnlineTicketConfig) <-chan wrapper.Result {
 	output := make(chan wrapper.Result)
 
@@ -59,3 +82,251 @@ func (c commandMongodbRepository) UpsertOneOnlineTicketConfig(ctx context.Contex
 
 	return output
 }
+
+// DeleteOneOnlineTicketConfig deletes the config of a tag unless a country already sold tickets. Count is 0 when
+// nothing was deleted.
+func (c commandMongodbRepository) DeleteOneOnlineTicketConfig(ctx context.Context, tag string) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.DeleteOne(mongodb.DeleteOne{
+			CollectionName: "online-ticket-config",
+			Filter: bson.M{
+				"tag":              tag,
+				"countryList.sold": bson.M{"$not": bson.M{"$gt": 0}},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// IncOnlineTicketSold counts quantity more tickets sold to the country of an online ticket config. Data is nil when
+// the config of the tag does not allocate the country.
+func (c commandMongodbRepository) IncOnlineTicketSold(ctx context.Context, tag string, countryCode string, quantity int) <-chan wrapper.Result {
+	var config entity.OnlineTicketConfig
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			CollectionName: "online-ticket-config",
+			Filter: bson.M{
+				"tag":                     tag,
+				"countryList.countryCode": countryCode,
+			},
+			Update: bson.M{
+				"$inc": bson.M{
+					"countryList.$.sold": quantity,
+				},
+			},
+			Result: &config,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) InsertOneOnlineTicketConfigHistory(ctx context.Context, history entity.OnlineTicketConfigHistory) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "online-ticket-config-history",
+			Document:       history,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpdateManyTicketSellable(ctx context.Context, eventId string, isSellable bool) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpdateMany(mongodb.UpdateMany{
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+			Document: bson.M{
+				"isSellable": isSellable,
+				"updatedAt":  time.Now(),
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateManyTicketEventInfo copies the tag and the location of an event to its tickets, the ticket filters read them.
+func (c commandMongodbRepository) UpdateManyTicketEventInfo(ctx context.Context, eventId string, tag string, continentName string,
+	continentCode string, country entity.Country) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpdateMany(mongodb.UpdateMany{
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+			Document: bson.M{
+				"tag":           tag,
+				"continentName": continentName,
+				"continentCode": continentCode,
+				"country":       country,
+				"updatedAt":     time.Now(),
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpdateOneTicketProvisioning(ctx context.Context, ticketId string, status string, reason string) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpdateOne(mongodb.UpdateOne{
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketId": ticketId,
+			},
+			Document: bson.M{
+				"provisioningStatus": status,
+				"provisioningError":  reason,
+				"updatedAt":          time.Now(),
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// InsertOneTicketIfAbsent inserts the ticket unless its event already has a ticket of the same type. Data is nil when
+// the ticket was inserted, otherwise it holds the existing ticket.
+func (c commandMongodbRepository) InsertOneTicketIfAbsent(ctx context.Context, ticket entity.Ticket) <-chan wrapper.Result {
+	var existing entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"eventId":    ticket.EventId,
+				"ticketType": ticket.TicketType,
+			},
+			Update: bson.M{
+				"$setOnInsert": ticket,
+			},
+			Result: &existing,
+			Upsert: true,
+		}, options.Before, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateOneTicketPrice sets the ticket price and returns the updated ticket.
+func (c commandMongodbRepository) UpdateOneTicketPrice(ctx context.Context, ticketId string, ticketPrice int) <-chan wrapper.Result {
+	var ticket entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketId": ticketId,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"ticketPrice": ticketPrice,
+					"updatedAt":   time.Now(),
+				},
+			},
+			Result: &ticket,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateOneTicketQuota moves totalQuota and totalRemaining by delta and returns the updated ticket. The update only
+// applies while totalQuota still equals currentQuota and enough tickets remain, so the quota never drops below the
+// tickets already sold. Data is nil when the condition did not hold.
+func (c commandMongodbRepository) UpdateOneTicketQuota(ctx context.Context, ticketId string, currentQuota int, delta int) <-chan wrapper.Result {
+	var ticket entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		minRemaining := 0
+		if delta < 0 {
+			minRemaining = -delta
+		}
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketId":       ticketId,
+				"totalQuota":     currentQuota,
+				"totalRemaining": bson.M{"$gte": minRemaining},
+			},
+			Update: bson.M{
+				"$inc": bson.M{
+					"totalQuota":     delta,
+					"totalRemaining": delta,
+				},
+				"$set": bson.M{
+					"updatedAt": time.Now(),
+				},
+			},
+			Result: &ticket,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateOneTicketRemaining sets totalRemaining while it still equals currentRemaining and returns the updated ticket.
+// Data is nil when it changed since it was read.
+func (c commandMongodbRepository) UpdateOneTicketRemaining(ctx context.Context, ticketId string, currentRemaining int, totalRemaining int) <-chan wrapper.Result {
+	var ticket entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketId":       ticketId,
+				"totalRemaining": currentRemaining,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"totalRemaining": totalRemaining,
+					"updatedAt":      time.Now(),
+				},
+			},
+			Result: &ticket,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
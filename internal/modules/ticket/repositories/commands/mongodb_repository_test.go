@@ -5,14 +5,20 @@ import (
 	"event-service/internal/modules/ticket"
 	"event-service/internal/modules/ticket/models/entity"
 	mongoRC "event-service/internal/modules/ticket/repositories/commands"
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/errors"
 	"event-service/internal/pkg/helpers"
 	mocks "event-service/mocks/pkg/databases/mongodb"
 	mocklog "event-service/mocks/pkg/log"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type CommandTestSuite struct {
@@ -67,6 +73,33 @@ func (suite *CommandTestSuite) TestInsertManyTicketCollection() {
 	suite.mockMongodb.AssertCalled(suite.T(), "InsertMany", mock.Anything, mock.Anything)
 }
 
+func (suite *CommandTestSuite) TestFindOnlineTicketConfigByTag() {
+
+	// Mock FindOne
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOne", mock.MatchedBy(func(payload mongodb.FindOne) bool {
+		filter, ok := payload.Filter.(bson.M)
+		return ok && payload.CollectionName == "online-ticket-config" && filter["tag"] == "tag"
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindOnlineTicketConfigByTag(suite.ctx, "tag")
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOne
+	suite.mockMongodb.AssertCalled(suite.T(), "FindOne", mock.Anything, mock.Anything)
+}
+
 func (suite *CommandTestSuite) TestUpsertOneOnlineTicketConfig() {
 	payload := entity.OnlineTicketConfig{
 		Tag:        "tag",
@@ -94,3 +127,369 @@ func (suite *CommandTestSuite) TestUpsertOneOnlineTicketConfig() {
 	// Assert UpsertOne
 	suite.mockMongodb.AssertCalled(suite.T(), "UpsertOne", mock.Anything, mock.Anything)
 }
+
+func (suite *CommandTestSuite) TestUpdateManyTicketSellable() {
+
+	// Mock UpdateMany
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("UpdateMany", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.UpdateManyTicketSellable(suite.ctx, "eventId", false)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert UpdateMany
+	suite.mockMongodb.AssertCalled(suite.T(), "UpdateMany", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandTestSuite) TestUpdateManyTicketEventInfo() {
+	expectedResult := make(chan helpers.Result, 1)
+	expectedResult <- helpers.Result{Data: "result not nil"}
+	close(expectedResult)
+	suite.mockMongodb.On("UpdateMany", mock.MatchedBy(func(payload mongodb.UpdateMany) bool {
+		document := payload.Document.(bson.M)
+		return payload.Filter.(bson.M)["eventId"] == "eventId" && document["tag"] == "tag" && document["continentCode"] == "AS" &&
+			document["country"] == entity.Country{Code: "ID", City: "Jakarta"}
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	result := <-suite.repository.UpdateManyTicketEventInfo(suite.ctx, "eventId", "tag", "Asia", "AS",
+		entity.Country{Code: "ID", City: "Jakarta"})
+
+	assert.NoError(suite.T(), result.Error)
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *CommandTestSuite) TestUpdateOneTicketProvisioning() {
+
+	// Mock UpdateOne
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("UpdateOne", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.UpdateOneTicketProvisioning(suite.ctx, "ticketId", "failed", "reason")
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert UpdateOne
+	suite.mockMongodb.AssertCalled(suite.T(), "UpdateOne", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandTestSuite) TestInsertOneTicketIfAbsent() {
+	payload := entity.Ticket{
+		TicketId:   "ticketId",
+		EventId:    "eventId",
+		TicketType: "Gold",
+	}
+
+	// Mock FindOneAndUpdate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOneAndUpdate", mock.MatchedBy(func(payload mongodb.FindOneAndUpdate) bool {
+		filter := payload.Filter.(bson.M)
+		return payload.Upsert && filter["eventId"] == "eventId" && filter["ticketType"] == "Gold"
+	}), options.Before, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.InsertOneTicketIfAbsent(suite.ctx, payload)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: nil, Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOneAndUpdate
+	suite.mockMongodb.AssertNumberOfCalls(suite.T(), "FindOneAndUpdate", 1)
+}
+
+func (suite *CommandTestSuite) TestUpdateOneTicketPrice() {
+
+	// Mock FindOneAndUpdate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOneAndUpdate", mock.MatchedBy(func(payload mongodb.FindOneAndUpdate) bool {
+		update := payload.Update.(bson.M)["$set"].(bson.M)
+		return payload.Filter.(bson.M)["ticketId"] == "ticketId" && update["ticketPrice"] == 100
+	}), options.After, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.UpdateOneTicketPrice(suite.ctx, "ticketId", 100)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: &entity.Ticket{TicketId: "ticketId"}, Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOneAndUpdate
+	suite.mockMongodb.AssertNumberOfCalls(suite.T(), "FindOneAndUpdate", 1)
+}
+
+func (suite *CommandTestSuite) TestUpdateOneTicketQuotaLower() {
+
+	// Mock FindOneAndUpdate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOneAndUpdate", mock.MatchedBy(func(payload mongodb.FindOneAndUpdate) bool {
+		filter := payload.Filter.(bson.M)
+		inc := payload.Update.(bson.M)["$inc"].(bson.M)
+		return filter["totalQuota"] == 10 && filter["totalRemaining"].(bson.M)["$gte"] == 4 &&
+			inc["totalQuota"] == -4 && inc["totalRemaining"] == -4
+	}), options.After, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.UpdateOneTicketQuota(suite.ctx, "ticketId", 10, -4)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: &entity.Ticket{TicketId: "ticketId"}, Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOneAndUpdate
+	suite.mockMongodb.AssertNumberOfCalls(suite.T(), "FindOneAndUpdate", 1)
+}
+
+func (suite *CommandTestSuite) TestUpdateOneTicketQuotaRaise() {
+
+	// Mock FindOneAndUpdate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOneAndUpdate", mock.MatchedBy(func(payload mongodb.FindOneAndUpdate) bool {
+		filter := payload.Filter.(bson.M)
+		return filter["totalQuota"] == 10 && filter["totalRemaining"].(bson.M)["$gte"] == 0
+	}), options.After, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.UpdateOneTicketQuota(suite.ctx, "ticketId", 10, 5)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: &entity.Ticket{TicketId: "ticketId"}, Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOneAndUpdate
+	suite.mockMongodb.AssertNumberOfCalls(suite.T(), "FindOneAndUpdate", 1)
+}
+
+func (suite *CommandTestSuite) TestUpdateOneTicketRemaining() {
+
+	// Mock FindOneAndUpdate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOneAndUpdate", mock.MatchedBy(func(payload mongodb.FindOneAndUpdate) bool {
+		return payload.Filter.(bson.M)["totalRemaining"] == 4 &&
+			payload.Update.(bson.M)["$set"].(bson.M)["totalRemaining"] == 5
+	}), options.After, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.UpdateOneTicketRemaining(suite.ctx, "ticketId", 4, 5)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: &entity.Ticket{TicketId: "ticketId"}, Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOneAndUpdate
+	suite.mockMongodb.AssertNumberOfCalls(suite.T(), "FindOneAndUpdate", 1)
+}
+
+func (suite *CommandTestSuite) TestDeleteOneOnlineTicketConfig() {
+
+	// Mock DeleteOne
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("DeleteOne", mock.MatchedBy(func(payload mongodb.DeleteOne) bool {
+		return payload.Filter.(bson.M)["countryList.sold"] != nil
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.DeleteOneOnlineTicketConfig(suite.ctx, "tag")
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert DeleteOne
+	suite.mockMongodb.AssertCalled(suite.T(), "DeleteOne", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandTestSuite) TestIncOnlineTicketSold() {
+
+	// Mock FindOneAndUpdate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOneAndUpdate", mock.MatchedBy(func(payload mongodb.FindOneAndUpdate) bool {
+		return payload.Filter.(bson.M)["countryList.countryCode"] == "ID" &&
+			payload.Update.(bson.M)["$inc"].(bson.M)["countryList.$.sold"] == 2
+	}), options.After, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.IncOnlineTicketSold(suite.ctx, "tag", "ID", 2)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: &entity.OnlineTicketConfig{Tag: "tag"}, Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOneAndUpdate
+	suite.mockMongodb.AssertNumberOfCalls(suite.T(), "FindOneAndUpdate", 1)
+}
+
+func (suite *CommandTestSuite) TestInsertOneOnlineTicketConfigHistory() {
+
+	// Mock InsertOne
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("InsertOne", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.InsertOneOnlineTicketConfigHistory(suite.ctx, entity.OnlineTicketConfigHistory{Tag: "tag"})
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert InsertOne
+	suite.mockMongodb.AssertCalled(suite.T(), "InsertOne", mock.Anything, mock.Anything)
+}
+
+// TestOnlineTicketConfigTransaction runs the read and the writes of an online ticket config change through a real
+// client session, the way CreateOnlineTicketConfig does.
+func TestOnlineTicketConfigTransaction(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("read and writes in one transaction", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.online-ticket-config", mtest.FirstBatch,
+				bson.D{{Key: "tag", Value: "tag"}, {Key: "version", Value: 1}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+			mtest.CreateSuccessResponse(),
+		)
+		logger := &mocklog.Logger{}
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+		db := mongodb.NewMongoDBLogger(mt.Client, "db", logger)
+		repository := mongoRC.NewCommandMongodbRepository(db, logger)
+
+		var version int
+		err := db.WithTransaction(context.Background(), func(txCtx context.Context) error {
+			configData := <-repository.FindOnlineTicketConfigByTag(txCtx, "tag")
+			if configData.Error != nil {
+				return configData.Error
+			}
+			version = configData.Data.(*entity.OnlineTicketConfig).Version
+
+			resp := <-repository.UpsertOneOnlineTicketConfig(txCtx, entity.OnlineTicketConfig{Tag: "tag", Version: version + 1})
+			if resp.Error != nil {
+				return resp.Error
+			}
+			respHistory := <-repository.InsertOneOnlineTicketConfigHistory(txCtx, entity.OnlineTicketConfigHistory{Tag: "tag"})
+			return respHistory.Error
+		})
+
+		assert.NoError(mt, err)
+		assert.Equal(mt, 1, version)
+
+		find := mt.GetStartedEvent()
+		assert.Equal(mt, "find", find.CommandName)
+		startTransaction, ok := find.Command.Lookup("startTransaction").BooleanOK()
+		assert.True(mt, ok && startTransaction, "the config is read in the transaction")
+		lsid := find.Command.Lookup("lsid")
+		txnNumber := find.Command.Lookup("txnNumber")
+		for _, name := range []string{"update", "insert", "commitTransaction"} {
+			started := mt.GetStartedEvent()
+			assert.Equal(mt, name, started.CommandName)
+			assert.True(mt, lsid.Equal(started.Command.Lookup("lsid")), "%s runs in the session of the read", name)
+			assert.True(mt, txnNumber.Equal(started.Command.Lookup("txnNumber")), "%s runs in the transaction of the read", name)
+		}
+	})
+
+	mt.Run("sale counted meanwhile", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.online-ticket-config", mtest.FirstBatch, bson.D{{Key: "tag", Value: "tag"}}),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    112,
+				Name:    "WriteConflict",
+				Message: "write conflict",
+				Labels:  []string{"TransientTransactionError"},
+			}),
+			mtest.CreateSuccessResponse(),
+		)
+		logger := &mocklog.Logger{}
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+		db := mongodb.NewMongoDBLogger(mt.Client, "db", logger)
+		repository := mongoRC.NewCommandMongodbRepository(db, logger)
+
+		err := db.WithTransaction(context.Background(), func(txCtx context.Context) error {
+			configData := <-repository.FindOnlineTicketConfigByTag(txCtx, "tag")
+			if configData.Error != nil {
+				return configData.Error
+			}
+			resp := <-repository.UpsertOneOnlineTicketConfig(txCtx, entity.OnlineTicketConfig{Tag: "tag"})
+			return resp.Error
+		})
+
+		errString, ok := err.(*errors.ErrorString)
+		assert.True(mt, ok)
+		assert.Equal(mt, http.StatusConflict, errString.Code())
+	})
+}
@@ -0,0 +1,234 @@
+package queries
+
+import (
+	"context"
+	"event-service/internal/modules/ticket"
+	"event-service/internal/modules/ticket/models/entity"
+	"event-service/internal/pkg/databases/mongodb"
+	wrapper "event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) ticket.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindTicketsByEventId(ctx context.Context, eventId string) <-chan wrapper.Result {
+	var tickets []entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &tickets,
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "ticketPrice",
+				By:        mongodb.SortDescending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindOnlineTicketConfigByTag(ctx context.Context, tag string) <-chan wrapper.Result {
+	var onlineTicketConfig entity.OnlineTicketConfig
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &onlineTicketConfig,
+			CollectionName: "online-ticket-config",
+			Filter: bson.M{
+				"tag": tag,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindTicketById(ctx context.Context, ticketId string) <-chan wrapper.Result {
+	var ticket entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &ticket,
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketId": ticketId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindEventIdsByTicket returns one ticket per event, holding only its eventId, for the events offering a matching ticket.
+func (q queryMongodbRepository) FindEventIdsByTicket(ctx context.Context, filter entity.TicketFilter) <-chan wrapper.Result {
+	var tickets []entity.Ticket
+	output := make(chan wrapper.Result)
+
+	match := bson.M{}
+	if filter.TicketType != "" {
+		match["ticketType"] = filter.TicketType
+	}
+	price := bson.M{}
+	if filter.MinPrice > 0 {
+		price["$gte"] = filter.MinPrice
+	}
+	if filter.MaxPrice > 0 {
+		price["$lte"] = filter.MaxPrice
+	}
+	if len(price) > 0 {
+		match["ticketPrice"] = price
+	}
+	if filter.Available {
+		match["isSellable"] = true
+		match["totalRemaining"] = bson.M{"$gt": 0}
+	}
+
+	go func() {
+		resp := <-q.mongoDb.Aggregate(mongodb.Aggregate{
+			Result:         &tickets,
+			CollectionName: "ticket-detail",
+			Filter: []bson.M{
+				{"$match": match},
+				{"$group": bson.M{"_id": "$eventId"}},
+				{"$project": bson.M{"_id": 0, "eventId": "$_id"}},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindAllOnlineTicketConfig(ctx context.Context, createdBy string, page int64, size int64) <-chan wrapper.Result {
+	var onlineTicketConfigs []entity.OnlineTicketConfig
+	var countData int64
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindAllData(mongodb.FindAllData{
+			Result:         &onlineTicketConfigs,
+			CountData:      &countData,
+			CollectionName: "online-ticket-config",
+			Filter: bson.M{
+				"createdBy": createdBy,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "tag",
+				By:        mongodb.SortAscending,
+			},
+			Page: page,
+			Size: size,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindAllOnlineTicketConfigByOrganizer(ctx context.Context, organizerId string, page int64, size int64) <-chan wrapper.Result {
+	var onlineTicketConfigs []entity.OnlineTicketConfig
+	var countData int64
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindAllData(mongodb.FindAllData{
+			Result:         &onlineTicketConfigs,
+			CountData:      &countData,
+			CollectionName: "online-ticket-config",
+			Filter: bson.M{
+				"organizerId": organizerId,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "tag",
+				By:        mongodb.SortAscending,
+			},
+			Page: page,
+			Size: size,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindOnlineTicketConfigHistory(ctx context.Context, tag string) <-chan wrapper.Result {
+	var history []entity.OnlineTicketConfigHistory
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &history,
+			CollectionName: "online-ticket-config-history",
+			Filter: bson.M{
+				"tag": tag,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "changedAt",
+				By:        mongodb.SortDescending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// AggregateOnlineTicketAllocation totals the allocated, sold and available tickets of a tag per country.
+func (q queryMongodbRepository) AggregateOnlineTicketAllocation(ctx context.Context, tag string) <-chan wrapper.Result {
+	var allocation []entity.AggregateTotalTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.Aggregate(mongodb.Aggregate{
+			Result:         &allocation,
+			CollectionName: "online-ticket-config",
+			Filter: []bson.M{
+				{"$match": bson.M{"tag": tag}},
+				{"$unwind": "$countryList"},
+				{"$group": bson.M{
+					"_id":             "$countryList.countryCode",
+					"countryName":     bson.M{"$first": "$countryList.countryName"},
+					"totalQuota":      bson.M{"$sum": "$countryList.quota"},
+					"totalSoldTicket": bson.M{"$sum": "$countryList.sold"},
+				}},
+				{"$addFields": bson.M{
+					"totalAvailableTicket": bson.M{"$subtract": bson.A{"$totalQuota", "$totalSoldTicket"}},
+				}},
+				{"$sort": bson.M{"_id": 1}},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,247 @@
+package queries_test
+
+import (
+	"context"
+	"event-service/internal/modules/ticket"
+	"event-service/internal/modules/ticket/models/entity"
+	mongoRQ "event-service/internal/modules/ticket/repositories/queries"
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/helpers"
+	mocks "event-service/mocks/pkg/databases/mongodb"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type QueryTestSuite struct {
+	suite.Suite
+	mockMongodb *mocks.Collections
+	mockLogger  *mocklog.Logger
+	repository  ticket.MongodbRepositoryQuery
+	ctx         context.Context
+}
+
+func (suite *QueryTestSuite) SetupTest() {
+	suite.mockMongodb = new(mocks.Collections)
+	suite.mockLogger = &mocklog.Logger{}
+	suite.repository = mongoRQ.NewQueryMongodbRepository(
+		suite.mockMongodb,
+		suite.mockLogger,
+	)
+	suite.ctx = context.Background()
+}
+
+func TestQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryTestSuite))
+}
+
+func (suite *QueryTestSuite) TestFindTicketsByEventId() {
+
+	// Mock FindMany
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindMany", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindTicketsByEventId(suite.ctx, "eventId")
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindMany
+	suite.mockMongodb.AssertCalled(suite.T(), "FindMany", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryTestSuite) TestFindOnlineTicketConfigByTag() {
+
+	// Mock FindOne
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOne", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindOnlineTicketConfigByTag(suite.ctx, "tag")
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOne
+	suite.mockMongodb.AssertCalled(suite.T(), "FindOne", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryTestSuite) TestFindTicketById() {
+
+	// Mock FindOne
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindOne", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindTicketById(suite.ctx, "ticketId")
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindOne
+	suite.mockMongodb.AssertCalled(suite.T(), "FindOne", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryTestSuite) TestAggregateOnlineTicketAllocation() {
+
+	// Mock Aggregate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("Aggregate", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.AggregateOnlineTicketAllocation(suite.ctx, "tag")
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert Aggregate
+	suite.mockMongodb.AssertCalled(suite.T(), "Aggregate", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryTestSuite) TestFindEventIdsByTicket() {
+
+	// Mock Aggregate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("Aggregate", mock.MatchedBy(func(payload mongodb.Aggregate) bool {
+		pipeline := payload.Filter.([]bson.M)
+		return assert.ObjectsAreEqual(bson.M{
+			"ticketType":     "Gold",
+			"ticketPrice":    bson.M{"$gte": 100, "$lte": 500},
+			"isSellable":     true,
+			"totalRemaining": bson.M{"$gt": 0},
+		}, pipeline[0]["$match"])
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindEventIdsByTicket(suite.ctx, entity.TicketFilter{
+		TicketType: "Gold",
+		MinPrice:   100,
+		MaxPrice:   500,
+		Available:  true,
+	})
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert Aggregate
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *QueryTestSuite) TestFindAllOnlineTicketConfig() {
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllOnlineTicketConfig(suite.ctx, "userId", 1, 10)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertCalled(suite.T(), "FindAllData", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryTestSuite) TestFindAllOnlineTicketConfigByOrganizer() {
+
+	// Mock FindAllData
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		return payload.Filter.(bson.M)["organizerId"] == "organizerId"
+	}), mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindAllOnlineTicketConfigByOrganizer(suite.ctx, "organizerId", 1, 10)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindAllData
+	suite.mockMongodb.AssertCalled(suite.T(), "FindAllData", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryTestSuite) TestFindOnlineTicketConfigHistory() {
+
+	// Mock FindMany
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("FindMany", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	// Act
+	result := suite.repository.FindOnlineTicketConfigHistory(suite.ctx, "tag")
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert FindMany
+	suite.mockMongodb.AssertCalled(suite.T(), "FindMany", mock.Anything, mock.Anything)
+}
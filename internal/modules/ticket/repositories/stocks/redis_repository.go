@@ -0,0 +1,365 @@
+package stocks
+
+import (
+	"context"
+	"event-service/internal/modules/ticket"
+	"event-service/internal/modules/ticket/models/entity"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	wrapper "event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+	"event-service/internal/pkg/redis"
+	"fmt"
+	"time"
+)
+
+// script result codes, real results are never negative
+const (
+	codeSoldOut        = -1
+	codeNotInitialized = -2
+	codeHoldNotFound   = -3
+	codeHoldForbidden  = -4
+	codeCountrySoldOut = -5
+)
+
+// sweepExpiredHolds gives the units of expired holds back to the stock. Every script starts with it, so a hold is
+// released on the first script run after its TTL. Holds are stored in a hash as 'userId:countryCode:quantity' and
+// indexed by expiry time in a sorted set. Holds written before the country was stored read as 'userId:quantity'.
+const sweepExpiredHolds = `
+local function quantity(hold)
+	return tonumber(string.match(hold, ':(%d+)$'))
+end
+
+local function owner(hold)
+	return string.match(hold, '^([^:]*):')
+end
+
+local function country(hold)
+	return string.match(hold, '^[^:]*:([^:]*):%d+$') or ''
+end
+
+local function sweep(stockKey, holdKey, expiryKey, now)
+	local expired = redis.call('ZRANGEBYSCORE', expiryKey, '-inf', now)
+	for _, holdId in ipairs(expired) do
+		local hold = redis.call('HGET', holdKey, holdId)
+		if hold and redis.call('EXISTS', stockKey) == 1 then
+			redis.call('INCRBY', stockKey, quantity(hold))
+		end
+		redis.call('HDEL', holdKey, holdId)
+		redis.call('ZREM', expiryKey, holdId)
+	end
+end
+`
+
+// KEYS: stock, holds, expiry. ARGV: holdId, userId, quantity, now, expireAt, countryCode, countryLeft. Returns the
+// units left. The open holds of the country count against countryLeft unless it is negative.
+var holdScript = redis.NewScript(sweepExpiredHolds + `
+sweep(KEYS[1], KEYS[2], KEYS[3], ARGV[4])
+local stock = redis.call('GET', KEYS[1])
+if not stock then
+	return -2
+end
+if tonumber(ARGV[7]) >= 0 then
+	local held = 0
+	for _, hold in ipairs(redis.call('HVALS', KEYS[2])) do
+		if country(hold) == ARGV[6] then
+			held = held + quantity(hold)
+		end
+	end
+	if held + tonumber(ARGV[3]) > tonumber(ARGV[7]) then
+		return -5
+	end
+end
+if tonumber(stock) < tonumber(ARGV[3]) then
+	return -1
+end
+local left = redis.call('DECRBY', KEYS[1], ARGV[3])
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[2] .. ':' .. ARGV[6] .. ':' .. ARGV[3])
+redis.call('ZADD', KEYS[3], ARGV[5], ARGV[1])
+return left
+`)
+
+// KEYS: stock, holds, expiry. ARGV: holdId, userId, now. Returns the held quantity and country, the units stay taken.
+var confirmScript = redis.NewScript(sweepExpiredHolds + `
+sweep(KEYS[1], KEYS[2], KEYS[3], ARGV[3])
+local hold = redis.call('HGET', KEYS[2], ARGV[1])
+if not hold then
+	return -3
+end
+if owner(hold) ~= ARGV[2] then
+	return -4
+end
+redis.call('HDEL', KEYS[2], ARGV[1])
+redis.call('ZREM', KEYS[3], ARGV[1])
+return {quantity(hold), country(hold)}
+`)
+
+// KEYS: stock, holds, expiry. ARGV: holdId, userId, now. Returns the released quantity and country.
+var releaseScript = redis.NewScript(sweepExpiredHolds + `
+sweep(KEYS[1], KEYS[2], KEYS[3], ARGV[3])
+local hold = redis.call('HGET', KEYS[2], ARGV[1])
+if not hold then
+	return -3
+end
+if owner(hold) ~= ARGV[2] then
+	return -4
+end
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	redis.call('INCRBY', KEYS[1], quantity(hold))
+end
+redis.call('HDEL', KEYS[2], ARGV[1])
+redis.call('ZREM', KEYS[3], ARGV[1])
+return {quantity(hold), country(hold)}
+`)
+
+// KEYS: stock. ARGV: delta. Returns the units left.
+var adjustScript = redis.NewScript(`
+local stock = redis.call('GET', KEYS[1])
+if not stock then
+	return -2
+end
+if tonumber(stock) + tonumber(ARGV[1]) < 0 then
+	return -1
+end
+return redis.call('INCRBY', KEYS[1], ARGV[1])
+`)
+
+// KEYS: stock, holds, expiry. ARGV: now. Returns {available, held}, or an empty list when the stock is missing.
+var syncScript = redis.NewScript(sweepExpiredHolds + `
+sweep(KEYS[1], KEYS[2], KEYS[3], ARGV[1])
+local stock = redis.call('GET', KEYS[1])
+if not stock then
+	return {}
+end
+local held = 0
+for _, hold in ipairs(redis.call('HVALS', KEYS[2])) do
+	held = held + quantity(hold)
+end
+return {tonumber(stock), held}
+`)
+
+type stockRedisRepository struct {
+	redisClient redis.Collections
+	logger      log.Logger
+}
+
+func NewStockRedisRepository(redisClient redis.Collections, log log.Logger) ticket.RedisRepositoryStock {
+	return &stockRedisRepository{
+		redisClient: redisClient,
+		logger:      log,
+	}
+}
+
+// the ticket id is the hash tag, so every key of a ticket lives in the same cluster slot as the scripts require
+func stockKeys(ticketId string) []string {
+	return []string{
+		redis.HashTagKey(constants.RedisKeyTicketStock, ticketId),
+		redis.HashTagKey(constants.RedisKeyTicketHold, ticketId),
+		redis.HashTagKey(constants.RedisKeyTicketHoldExpiry, ticketId),
+	}
+}
+
+func (s stockRedisRepository) redisError(ctx context.Context, err error, payload interface{}) wrapper.Result {
+	s.logger.Error(ctx, fmt.Sprintf("Error Redis: %s", err.Error()), fmt.Sprintf("%+v", payload))
+	return wrapper.Result{
+		Error: errors.InternalServerError("Error redis"),
+	}
+}
+
+// InitStock seeds the stock counter of a ticket unless it already exists. Data is true when the counter was created.
+func (s stockRedisRepository) InitStock(ctx context.Context, ticketId string, totalRemaining int) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		created, err := s.redisClient.SetNX(ctx, stockKeys(ticketId)[0], totalRemaining, 0).Result()
+		if err != nil {
+			output <- s.redisError(ctx, err, ticketId)
+			return
+		}
+
+		if err := s.redisClient.SAdd(ctx, constants.RedisKeyTicketStockIndex, ticketId).Err(); err != nil {
+			output <- s.redisError(ctx, err, ticketId)
+			return
+		}
+		output <- wrapper.Result{Data: created}
+	}()
+
+	return output
+}
+
+// HoldStock takes the held units from the stock. countryLeft is how many units the country of the buyer may still
+// hold, the open holds of the country included, a negative countryLeft does not limit the country. Data is the number
+// of units left, or nil when the stock of the ticket has not been initialized yet.
+func (s stockRedisRepository) HoldStock(ctx context.Context, hold entity.TicketHold, countryLeft int, now time.Time) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		left, err := holdScript.Run(ctx, s.redisClient, stockKeys(hold.TicketId), hold.HoldId, hold.UserId, hold.Quantity,
+			now.UnixMilli(), hold.ExpiresAt.UnixMilli(), hold.CountryCode, countryLeft).Int64()
+		if err != nil {
+			output <- s.redisError(ctx, err, hold)
+			return
+		}
+
+		switch left {
+		case codeNotInitialized:
+			output <- wrapper.Result{}
+		case codeSoldOut:
+			output <- wrapper.Result{Error: errors.Conflict("not enough tickets left")}
+		case codeCountrySoldOut:
+			output <- wrapper.Result{Error: errors.Conflict("not enough tickets left for your country")}
+		default:
+			output <- wrapper.Result{Data: left}
+		}
+	}()
+
+	return output
+}
+
+// ConfirmHold turns a hold into a sale, the units stay taken. Data is the confirmed *entity.TicketHold.
+func (s stockRedisRepository) ConfirmHold(ctx context.Context, ticketId string, holdId string, userId string, now time.Time) <-chan wrapper.Result {
+	return s.runHoldScript(ctx, confirmScript, ticketId, holdId, userId, now)
+}
+
+// ReleaseHold gives the held units back to the stock. Data is the released *entity.TicketHold.
+func (s stockRedisRepository) ReleaseHold(ctx context.Context, ticketId string, holdId string, userId string, now time.Time) <-chan wrapper.Result {
+	return s.runHoldScript(ctx, releaseScript, ticketId, holdId, userId, now)
+}
+
+func (s stockRedisRepository) runHoldScript(ctx context.Context, script *redis.Script, ticketId string, holdId string, userId string,
+	now time.Time) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		result, err := script.Run(ctx, s.redisClient, stockKeys(ticketId), holdId, userId, now.UnixMilli()).Result()
+		if err != nil {
+			output <- s.redisError(ctx, err, holdId)
+			return
+		}
+
+		switch result {
+		case int64(codeHoldNotFound):
+			output <- wrapper.Result{Error: errors.NotFound("hold not found or expired")}
+			return
+		case int64(codeHoldForbidden):
+			output <- wrapper.Result{Error: errors.ForbiddenError("hold belongs to another user")}
+			return
+		}
+
+		values, ok := result.([]interface{})
+		if !ok || len(values) != 2 {
+			output <- s.redisError(ctx, fmt.Errorf("unexpected hold script result %v", result), holdId)
+			return
+		}
+		quantity, _ := values[0].(int64)
+		countryCode, _ := values[1].(string)
+		output <- wrapper.Result{
+			Data: &entity.TicketHold{
+				HoldId:      holdId,
+				TicketId:    ticketId,
+				UserId:      userId,
+				Quantity:    int(quantity),
+				CountryCode: countryCode,
+			},
+		}
+	}()
+
+	return output
+}
+
+// AdjustStock moves the stock counter by delta. Data is the number of units left, or nil when there is no counter
+// to adjust.
+func (s stockRedisRepository) AdjustStock(ctx context.Context, ticketId string, delta int) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		left, err := adjustScript.Run(ctx, s.redisClient, stockKeys(ticketId)[:1], delta).Int64()
+		if err != nil {
+			output <- s.redisError(ctx, err, ticketId)
+			return
+		}
+
+		switch left {
+		case codeNotInitialized:
+			output <- wrapper.Result{}
+		case codeSoldOut:
+			output <- wrapper.Result{Error: errors.Conflict("not enough tickets left to lower the quota")}
+		default:
+			output <- wrapper.Result{Data: left}
+		}
+	}()
+
+	return output
+}
+
+// SyncStock releases expired holds and reads the stock of a ticket. Data is an *entity.TicketStock, or nil when the
+// counter does not exist.
+func (s stockRedisRepository) SyncStock(ctx context.Context, ticketId string, now time.Time) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		values, err := syncScript.Run(ctx, s.redisClient, stockKeys(ticketId), now.UnixMilli()).Int64Slice()
+		if err != nil {
+			output <- s.redisError(ctx, err, ticketId)
+			return
+		}
+
+		if len(values) != 2 {
+			output <- wrapper.Result{}
+			return
+		}
+		output <- wrapper.Result{
+			Data: &entity.TicketStock{
+				TicketId:  ticketId,
+				Available: int(values[0]),
+				Held:      int(values[1]),
+			},
+		}
+	}()
+
+	return output
+}
+
+// FindStockTicketIds lists the tickets that have a stock counter. Data is a []string.
+func (s stockRedisRepository) FindStockTicketIds(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		ticketIds, err := s.redisClient.SMembers(ctx, constants.RedisKeyTicketStockIndex).Result()
+		if err != nil {
+			output <- s.redisError(ctx, err, constants.RedisKeyTicketStockIndex)
+			return
+		}
+		output <- wrapper.Result{Data: ticketIds}
+	}()
+
+	return output
+}
+
+func (s stockRedisRepository) RemoveStockTicketId(ctx context.Context, ticketId string) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		if err := s.redisClient.SRem(ctx, constants.RedisKeyTicketStockIndex, ticketId).Err(); err != nil {
+			output <- s.redisError(ctx, err, ticketId)
+			return
+		}
+		output <- wrapper.Result{}
+	}()
+
+	return output
+}
@@ -0,0 +1,222 @@
+package stocks_test
+
+import (
+	"context"
+	"event-service/internal/modules/ticket"
+	"event-service/internal/modules/ticket/models/entity"
+	"event-service/internal/modules/ticket/repositories/stocks"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	pkgRedis "event-service/internal/pkg/redis"
+	mocklog "event-service/mocks/pkg/log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StockTestSuite struct {
+	suite.Suite
+	miniRedis  *miniredis.Miniredis
+	mockLogger *mocklog.Logger
+	repository ticket.RedisRepositoryStock
+	ctx        context.Context
+	now        time.Time
+}
+
+func (suite *StockTestSuite) SetupTest() {
+	suite.miniRedis = miniredis.RunT(suite.T())
+	suite.mockLogger = &mocklog.Logger{}
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	suite.repository = stocks.NewStockRedisRepository(
+		&pkgRedis.RedisClient{Client: redis.NewClient(&redis.Options{Addr: suite.miniRedis.Addr()})},
+		suite.mockLogger,
+	)
+	suite.ctx = context.Background()
+	suite.now = time.Now()
+}
+
+func TestStockTestSuite(t *testing.T) {
+	suite.Run(t, new(StockTestSuite))
+}
+
+func (suite *StockTestSuite) hold(holdId string, userId string, quantity int, ttl time.Duration) (interface{}, error) {
+	return suite.holdCountry(holdId, userId, quantity, ttl, "", -1)
+}
+
+func (suite *StockTestSuite) holdCountry(holdId string, userId string, quantity int, ttl time.Duration, countryCode string,
+	countryLeft int) (interface{}, error) {
+	result := <-suite.repository.HoldStock(suite.ctx, entity.TicketHold{
+		HoldId:      holdId,
+		TicketId:    "ticket1",
+		UserId:      userId,
+		Quantity:    quantity,
+		CountryCode: countryCode,
+		ExpiresAt:   suite.now.Add(ttl),
+	}, countryLeft, suite.now)
+	return result.Data, result.Error
+}
+
+func (suite *StockTestSuite) stock() *entity.TicketStock {
+	result := <-suite.repository.SyncStock(suite.ctx, "ticket1", suite.now)
+	assert.NoError(suite.T(), result.Error)
+	if result.Data == nil {
+		return nil
+	}
+	return result.Data.(*entity.TicketStock)
+}
+
+func assertHttpCode(t *testing.T, code int, err error) {
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(t, ok)
+	assert.Equal(t, code, errString.Code())
+}
+
+func (suite *StockTestSuite) TestInitStock() {
+	result := <-suite.repository.InitStock(suite.ctx, "ticket1", 10)
+	assert.NoError(suite.T(), result.Error)
+	assert.Equal(suite.T(), true, result.Data)
+
+	// a second init never overwrites a live counter
+	result = <-suite.repository.InitStock(suite.ctx, "ticket1", 99)
+	assert.Equal(suite.T(), false, result.Data)
+	assert.Equal(suite.T(), &entity.TicketStock{TicketId: "ticket1", Available: 10}, suite.stock())
+
+	ids := <-suite.repository.FindStockTicketIds(suite.ctx)
+	assert.Equal(suite.T(), []string{"ticket1"}, ids.Data)
+}
+
+func (suite *StockTestSuite) TestHoldStockNotInitialized() {
+	left, err := suite.hold("hold1", "user1", 1, time.Minute)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), left)
+}
+
+func (suite *StockTestSuite) TestHoldStock() {
+	<-suite.repository.InitStock(suite.ctx, "ticket1", 5)
+
+	left, err := suite.hold("hold1", "user1", 3, time.Minute)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), left)
+
+	_, err = suite.hold("hold2", "user2", 3, time.Minute)
+	assertHttpCode(suite.T(), http.StatusConflict, err)
+
+	assert.Equal(suite.T(), &entity.TicketStock{TicketId: "ticket1", Available: 2, Held: 3}, suite.stock())
+}
+
+func (suite *StockTestSuite) TestHoldStockExpired() {
+	<-suite.repository.InitStock(suite.ctx, "ticket1", 5)
+	_, err := suite.hold("hold1", "user1", 5, time.Minute)
+	assert.NoError(suite.T(), err)
+
+	// once the hold expired the next script run gives its units back
+	suite.now = suite.now.Add(2 * time.Minute)
+	left, err := suite.hold("hold2", "user2", 4, time.Minute)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), left)
+
+	result := <-suite.repository.ConfirmHold(suite.ctx, "ticket1", "hold1", "user1", suite.now)
+	assertHttpCode(suite.T(), http.StatusNotFound, result.Error)
+}
+
+func (suite *StockTestSuite) TestConfirmHold() {
+	<-suite.repository.InitStock(suite.ctx, "ticket1", 5)
+	_, _ = suite.hold("hold1", "user1", 2, time.Minute)
+
+	result := <-suite.repository.ConfirmHold(suite.ctx, "ticket1", "hold1", "user2", suite.now)
+	assertHttpCode(suite.T(), http.StatusForbidden, result.Error)
+
+	result = <-suite.repository.ConfirmHold(suite.ctx, "ticket1", "hold1", "user1", suite.now)
+	assert.NoError(suite.T(), result.Error)
+	assert.Equal(suite.T(), &entity.TicketHold{HoldId: "hold1", TicketId: "ticket1", UserId: "user1", Quantity: 2}, result.Data)
+
+	// sold units stay taken, even after the TTL
+	suite.now = suite.now.Add(2 * time.Minute)
+	assert.Equal(suite.T(), &entity.TicketStock{TicketId: "ticket1", Available: 3}, suite.stock())
+}
+
+func (suite *StockTestSuite) TestHoldStockCountryQuota() {
+	<-suite.repository.InitStock(suite.ctx, "ticket1", 10)
+
+	_, err := suite.holdCountry("hold1", "user1", 2, time.Minute, "ID", 3)
+	assert.NoError(suite.T(), err)
+
+	// the open hold of the country counts against what the country has left
+	_, err = suite.holdCountry("hold2", "user2", 2, time.Minute, "ID", 3)
+	assertHttpCode(suite.T(), http.StatusConflict, err)
+
+	left, err := suite.holdCountry("hold3", "user3", 2, time.Minute, "SG", 3)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(6), left)
+
+	result := <-suite.repository.ConfirmHold(suite.ctx, "ticket1", "hold1", "user1", suite.now)
+	assert.NoError(suite.T(), result.Error)
+	assert.Equal(suite.T(), &entity.TicketHold{HoldId: "hold1", TicketId: "ticket1", UserId: "user1", Quantity: 2, CountryCode: "ID"},
+		result.Data)
+}
+
+func (suite *StockTestSuite) TestConfirmHoldWithoutCountry() {
+	<-suite.repository.InitStock(suite.ctx, "ticket1", 5)
+	// a hold written before the country was stored
+	suite.miniRedis.HSet(pkgRedis.HashTagKey(constants.RedisKeyTicketHold, "ticket1"), "hold1", "user1:2")
+
+	result := <-suite.repository.ConfirmHold(suite.ctx, "ticket1", "hold1", "user1", suite.now)
+	assert.NoError(suite.T(), result.Error)
+	assert.Equal(suite.T(), &entity.TicketHold{HoldId: "hold1", TicketId: "ticket1", UserId: "user1", Quantity: 2}, result.Data)
+}
+
+func (suite *StockTestSuite) TestReleaseHold() {
+	<-suite.repository.InitStock(suite.ctx, "ticket1", 5)
+	_, _ = suite.hold("hold1", "user1", 2, time.Minute)
+
+	result := <-suite.repository.ReleaseHold(suite.ctx, "ticket1", "hold1", "user1", suite.now)
+	assert.NoError(suite.T(), result.Error)
+	assert.Equal(suite.T(), &entity.TicketHold{HoldId: "hold1", TicketId: "ticket1", UserId: "user1", Quantity: 2}, result.Data)
+	assert.Equal(suite.T(), &entity.TicketStock{TicketId: "ticket1", Available: 5}, suite.stock())
+
+	result = <-suite.repository.ReleaseHold(suite.ctx, "ticket1", "hold1", "user1", suite.now)
+	assertHttpCode(suite.T(), http.StatusNotFound, result.Error)
+}
+
+func (suite *StockTestSuite) TestAdjustStock() {
+	result := <-suite.repository.AdjustStock(suite.ctx, "ticket1", 5)
+	assert.NoError(suite.T(), result.Error)
+	assert.Nil(suite.T(), result.Data)
+
+	<-suite.repository.InitStock(suite.ctx, "ticket1", 5)
+	result = <-suite.repository.AdjustStock(suite.ctx, "ticket1", 5)
+	assert.Equal(suite.T(), int64(10), result.Data)
+
+	result = <-suite.repository.AdjustStock(suite.ctx, "ticket1", -11)
+	assertHttpCode(suite.T(), http.StatusConflict, result.Error)
+}
+
+func (suite *StockTestSuite) TestSyncStockMissing() {
+	assert.Nil(suite.T(), suite.stock())
+}
+
+func (suite *StockTestSuite) TestRemoveStockTicketId() {
+	<-suite.repository.InitStock(suite.ctx, "ticket1", 5)
+
+	result := <-suite.repository.RemoveStockTicketId(suite.ctx, "ticket1")
+	assert.NoError(suite.T(), result.Error)
+
+	ids := <-suite.repository.FindStockTicketIds(suite.ctx)
+	assert.Empty(suite.T(), ids.Data)
+}
+
+func (suite *StockTestSuite) TestErrRedis() {
+	suite.miniRedis.Close()
+
+	result := <-suite.repository.InitStock(suite.ctx, "ticket1", 5)
+	assertHttpCode(suite.T(), http.StatusInternalServerError, result.Error)
+
+	_, err := suite.hold("hold1", "user1", 1, time.Minute)
+	assertHttpCode(suite.T(), http.StatusInternalServerError, err)
+}
@@ -2,11 +2,47 @@ package ticket
 
 import (
 	"context"
+	"time"
+
 	"event-service/internal/modules/ticket/models/entity"
 	wrapper "event-service/internal/pkg/helpers"
 )
 
+type MongodbRepositoryQuery interface {
+	FindTicketsByEventId(ctx context.Context, eventId string) <-chan wrapper.Result
+	FindTicketById(ctx context.Context, ticketId string) <-chan wrapper.Result
+	FindOnlineTicketConfigByTag(ctx context.Context, tag string) <-chan wrapper.Result
+	AggregateOnlineTicketAllocation(ctx context.Context, tag string) <-chan wrapper.Result
+	FindEventIdsByTicket(ctx context.Context, filter entity.TicketFilter) <-chan wrapper.Result
+	FindAllOnlineTicketConfig(ctx context.Context, createdBy string, page int64, size int64) <-chan wrapper.Result
+	FindAllOnlineTicketConfigByOrganizer(ctx context.Context, organizerId string, page int64, size int64) <-chan wrapper.Result
+	FindOnlineTicketConfigHistory(ctx context.Context, tag string) <-chan wrapper.Result
+}
+
 type MongodbRepositoryCommand interface {
 	InsertManyTicketCollection(ctx context.Context, ticket []entity.Ticket) <-chan wrapper.Result
+	FindOnlineTicketConfigByTag(ctx context.Context, tag string) <-chan wrapper.Result
 	UpsertOneOnlineTicketConfig(ctx context.Context, payload entity.OnlineTicketConfig) <-chan wrapper.Result
+	DeleteOneOnlineTicketConfig(ctx context.Context, tag string) <-chan wrapper.Result
+	IncOnlineTicketSold(ctx context.Context, tag string, countryCode string, quantity int) <-chan wrapper.Result
+	InsertOneOnlineTicketConfigHistory(ctx context.Context, history entity.OnlineTicketConfigHistory) <-chan wrapper.Result
+	UpdateManyTicketSellable(ctx context.Context, eventId string, isSellable bool) <-chan wrapper.Result
+	UpdateManyTicketEventInfo(ctx context.Context, eventId string, tag string, continentName string, continentCode string,
+		country entity.Country) <-chan wrapper.Result
+	UpdateOneTicketProvisioning(ctx context.Context, ticketId string, status string, reason string) <-chan wrapper.Result
+	InsertOneTicketIfAbsent(ctx context.Context, ticket entity.Ticket) <-chan wrapper.Result
+	UpdateOneTicketPrice(ctx context.Context, ticketId string, ticketPrice int) <-chan wrapper.Result
+	UpdateOneTicketQuota(ctx context.Context, ticketId string, currentQuota int, delta int) <-chan wrapper.Result
+	UpdateOneTicketRemaining(ctx context.Context, ticketId string, currentRemaining int, totalRemaining int) <-chan wrapper.Result
+}
+
+type RedisRepositoryStock interface {
+	InitStock(ctx context.Context, ticketId string, totalRemaining int) <-chan wrapper.Result
+	HoldStock(ctx context.Context, hold entity.TicketHold, countryLeft int, now time.Time) <-chan wrapper.Result
+	ConfirmHold(ctx context.Context, ticketId string, holdId string, userId string, now time.Time) <-chan wrapper.Result
+	ReleaseHold(ctx context.Context, ticketId string, holdId string, userId string, now time.Time) <-chan wrapper.Result
+	AdjustStock(ctx context.Context, ticketId string, delta int) <-chan wrapper.Result
+	SyncStock(ctx context.Context, ticketId string, now time.Time) <-chan wrapper.Result
+	FindStockTicketIds(ctx context.Context) <-chan wrapper.Result
+	RemoveStockTicketId(ctx context.Context, ticketId string) <-chan wrapper.Result
 }
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"event-service/internal/modules/user"
+	"event-service/internal/modules/user/models/request"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/log"
+
+	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UserKafkaHandler struct {
+	UserUsecaseCommand user.UsecaseCommand
+	Logger             log.Logger
+	Validator          *validator.Validate
+}
+
+func InitUserKafkaHandler(router *kafkaConfluent.Router, uuc user.UsecaseCommand, log log.Logger) {
+	handler := &UserKafkaHandler{
+		UserUsecaseCommand: uuc,
+		Logger:             log,
+		Validator:          validator.New(),
+	}
+
+	router.Handle(constants.TopicUserUpdated, handler.UserChanged)
+	router.Handle(constants.TopicUserDeleted, handler.UserChanged)
+}
+
+// UserChanged handles both topics, an updated and a deleted user are read again from mongodb by the next request.
+func (u UserKafkaHandler) UserChanged(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+	req := new(request.UserChangedReq)
+	if err := json.Unmarshal(message.Value, req); err != nil {
+		return kafkaConfluent.Permanent(errors.BadRequest("bad request"))
+	}
+
+	if err := u.Validator.Struct(req); err != nil {
+		return kafkaConfluent.Permanent(errors.BadRequest(err.Error()))
+	}
+	return u.UserUsecaseCommand.InvalidateProfile(ctx, *req)
+}
@@ -0,0 +1,73 @@
+package handlers_test
+
+import (
+	"context"
+	"event-service/internal/modules/user/handlers"
+	"event-service/internal/modules/user/models/request"
+	"event-service/internal/pkg/constants"
+	mockcert "event-service/mocks/modules/user"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+
+	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
+	mockkafka "event-service/mocks/pkg/kafka"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserKafkaHandlerTestSuite struct {
+	suite.Suite
+
+	cUC     *mockcert.UsecaseCommand
+	cLog    *mocklog.Logger
+	handler *handlers.UserKafkaHandler
+	ctx     context.Context
+}
+
+func (suite *UserKafkaHandlerTestSuite) SetupTest() {
+	suite.cUC = new(mockcert.UsecaseCommand)
+	suite.cLog = new(mocklog.Logger)
+	suite.handler = &handlers.UserKafkaHandler{
+		UserUsecaseCommand: suite.cUC,
+		Logger:             suite.cLog,
+		Validator:          validator.New(),
+	}
+	suite.ctx = context.Background()
+}
+
+func TestUserKafkaHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(UserKafkaHandlerTestSuite))
+}
+
+func (suite *UserKafkaHandlerTestSuite) TestInitUserKafkaHandler() {
+	router := kafkaConfluent.NewRouter(kafkaConfluent.RouterConfig{}, new(mockkafka.Producer), suite.cLog)
+
+	handlers.InitUserKafkaHandler(router, suite.cUC, suite.cLog)
+
+	assert.ElementsMatch(suite.T(), []string{constants.TopicUserUpdated, constants.TopicUserDeleted}, router.Topics())
+}
+
+func (suite *UserKafkaHandlerTestSuite) TestUserChanged() {
+	suite.cUC.On("InvalidateProfile", mock.Anything, request.UserChangedReq{UserId: "userId"}).Return(nil)
+
+	err := suite.handler.UserChanged(suite.ctx, kafkaConfluent.ConsumedMessage{
+		Topic: constants.TopicUserDeleted,
+		Value: []byte(`{"userId":"userId"}`),
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *UserKafkaHandlerTestSuite) TestUserChangedErrParse() {
+	err := suite.handler.UserChanged(suite.ctx, kafkaConfluent.ConsumedMessage{Value: []byte(`not json`)})
+	assert.True(suite.T(), kafkaConfluent.IsPermanent(err))
+	suite.cUC.AssertNotCalled(suite.T(), "InvalidateProfile", mock.Anything, mock.Anything)
+}
+
+func (suite *UserKafkaHandlerTestSuite) TestUserChangedErrValidation() {
+	err := suite.handler.UserChanged(suite.ctx, kafkaConfluent.ConsumedMessage{Value: []byte(`{}`)})
+	assert.True(suite.T(), kafkaConfluent.IsPermanent(err))
+	suite.cUC.AssertNotCalled(suite.T(), "InvalidateProfile", mock.Anything, mock.Anything)
+}
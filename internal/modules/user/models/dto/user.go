@@ -3,12 +3,15 @@ package dto
 import "time"
 
 type UserResp struct {
-	UserId    string    `json:"user_id" bson:"userId"`
-	FullName  string    `json:"full_name" bson:"fullName"`
-	Email     string    `json:"email" bson:"email"`
-	Role      string    `json:"role" bson:"role"`
-	CreatedAt time.Time `json:"created_at" bson:"createdAt"`
-	UpdatedAt time.Time `json:"updated_at" bson:"updatedAt"`
+	UserId   string `json:"user_id" bson:"userId"`
+	FullName string `json:"full_name" bson:"fullName"`
+	Email    string `json:"email" bson:"email"`
+	Role     string `json:"role" bson:"role"`
+	Status   string `json:"status" bson:"status"`
+	// CountryCode is the country of the profile, the online ticket quotas of a buyer are counted in it
+	CountryCode string    `json:"country_code" bson:"countryCode"`
+	CreatedAt   time.Time `json:"created_at" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updated_at" bson:"updatedAt"`
 }
 
 type UserData struct {
@@ -0,0 +1,6 @@
+package request
+
+// UserChangedReq is the message of the user service when a user is updated or deleted.
+type UserChangedReq struct {
+	UserId string `json:"userId" validate:"required"`
+}
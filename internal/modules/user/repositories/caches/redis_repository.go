@@ -0,0 +1,150 @@
+package caches
+
+import (
+	"context"
+	"encoding/json"
+	"event-service/internal/modules/user"
+	"event-service/internal/modules/user/models/dto"
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/errors"
+	wrapper "event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/log"
+	"event-service/internal/pkg/redis"
+	"fmt"
+	"time"
+)
+
+// profileTTL bounds how long a change missed by the consumer stays visible.
+const profileTTL = 10 * time.Minute
+
+// profileTombstoneTTL is how long a dropped profile is not cached again. A request that read the user before the
+// change must not write it back, its read ends well within this time.
+const profileTombstoneTTL = 30 * time.Second
+
+type cacheRedisRepository struct {
+	redisClient redis.Collections
+	logger      log.Logger
+}
+
+func NewCacheRedisRepository(redisClient redis.Collections, log log.Logger) user.RedisRepositoryCache {
+	return &cacheRedisRepository{
+		redisClient: redisClient,
+		logger:      log,
+	}
+}
+
+func profileKey(userId string) string {
+	return fmt.Sprintf("%s:%s", constants.RedisKeyGetProfileUser, userId)
+}
+
+func tombstoneKey(userId string) string {
+	return fmt.Sprintf("%s:%s", constants.RedisKeyProfileTombstone, userId)
+}
+
+func (c cacheRedisRepository) tombstoned(ctx context.Context, userId string) (bool, error) {
+	err := c.redisClient.Get(ctx, tombstoneKey(userId)).Err()
+	if err == redis.Nil {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// FindProfile returns the cached *dto.UserResp, or nil Data when the user is not cached.
+func (c cacheRedisRepository) FindProfile(ctx context.Context, userId string) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		cached, err := c.redisClient.Get(ctx, profileKey(userId)).Bytes()
+		if err == redis.Nil {
+			output <- wrapper.Result{}
+			return
+		}
+		if err != nil {
+			output <- c.redisError(ctx, err, userId)
+			return
+		}
+
+		var profile dto.UserData
+		if err := json.Unmarshal(cached, &profile); err != nil {
+			c.logger.Error(ctx, "Invalid cached profile", fmt.Sprintf("%s: %s", userId, err.Error()))
+			output <- wrapper.Result{}
+			return
+		}
+		output <- wrapper.Result{Data: &profile.Data}
+	}()
+
+	return output
+}
+
+// SetProfile caches the profile unless it was dropped within profileTombstoneTTL, then Data is nil. The tombstone is
+// checked again after the write, a profile written while the consumer drops it is deleted.
+func (c cacheRedisRepository) SetProfile(ctx context.Context, profile dto.UserResp) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		tombstoned, err := c.tombstoned(ctx, profile.UserId)
+		if err != nil {
+			output <- c.redisError(ctx, err, profile.UserId)
+			return
+		}
+		if tombstoned {
+			output <- wrapper.Result{}
+			return
+		}
+
+		cached, _ := json.Marshal(dto.UserData{Data: profile})
+		if err := c.redisClient.Set(ctx, profileKey(profile.UserId), cached, profileTTL).Err(); err != nil {
+			output <- c.redisError(ctx, err, profile.UserId)
+			return
+		}
+
+		tombstoned, err = c.tombstoned(ctx, profile.UserId)
+		if err == nil && tombstoned {
+			err = c.redisClient.Del(ctx, profileKey(profile.UserId)).Err()
+		}
+		if err != nil {
+			output <- c.redisError(ctx, err, profile.UserId)
+			return
+		}
+		if tombstoned {
+			output <- wrapper.Result{}
+			return
+		}
+		output <- wrapper.Result{Data: profile.UserId}
+	}()
+
+	return output
+}
+
+// DeleteProfile drops the profile and leaves a tombstone first, so a request racing the delete cannot cache the
+// profile it read before the change.
+func (c cacheRedisRepository) DeleteProfile(ctx context.Context, userId string) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		if err := c.redisClient.Set(ctx, tombstoneKey(userId), 1, profileTombstoneTTL).Err(); err != nil {
+			output <- c.redisError(ctx, err, userId)
+			return
+		}
+		if err := c.redisClient.Del(ctx, profileKey(userId)).Err(); err != nil {
+			output <- c.redisError(ctx, err, userId)
+			return
+		}
+		output <- wrapper.Result{Data: userId}
+	}()
+
+	return output
+}
+
+func (c cacheRedisRepository) redisError(ctx context.Context, err error, payload interface{}) wrapper.Result {
+	c.logger.Error(ctx, fmt.Sprintf("Error Redis: %s", err.Error()), fmt.Sprintf("%+v", payload))
+	return wrapper.Result{
+		Error: errors.InternalServerError("Error redis"),
+	}
+}
@@ -0,0 +1,117 @@
+package caches_test
+
+import (
+	"context"
+	"event-service/internal/modules/user"
+	"event-service/internal/modules/user/models/dto"
+	"event-service/internal/modules/user/repositories/caches"
+	pkgRedis "event-service/internal/pkg/redis"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+	miniRedis  *miniredis.Miniredis
+	mockLogger *mocklog.Logger
+	repository user.RedisRepositoryCache
+	ctx        context.Context
+}
+
+func (suite *CacheTestSuite) SetupTest() {
+	suite.miniRedis = miniredis.RunT(suite.T())
+	suite.mockLogger = &mocklog.Logger{}
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	suite.repository = caches.NewCacheRedisRepository(
+		&pkgRedis.RedisClient{Client: redis.NewClient(&redis.Options{Addr: suite.miniRedis.Addr()})},
+		suite.mockLogger,
+	)
+	suite.ctx = context.Background()
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
+
+func (suite *CacheTestSuite) TestSetAndFindProfile() {
+	result := <-suite.repository.SetProfile(suite.ctx, dto.UserResp{UserId: "userId", Role: "fan", Status: "active"})
+	assert.NoError(suite.T(), result.Error)
+	assert.Equal(suite.T(), 10*time.Minute, suite.miniRedis.TTL("GET-PROFILE-USER:userId"))
+
+	result = <-suite.repository.FindProfile(suite.ctx, "userId")
+	assert.NoError(suite.T(), result.Error)
+	assert.Equal(suite.T(), &dto.UserResp{UserId: "userId", Role: "fan", Status: "active"}, result.Data)
+}
+
+func (suite *CacheTestSuite) TestFindProfileMiss() {
+	result := <-suite.repository.FindProfile(suite.ctx, "userId")
+
+	assert.NoError(suite.T(), result.Error)
+	assert.Nil(suite.T(), result.Data)
+}
+
+func (suite *CacheTestSuite) TestFindProfileInvalid() {
+	suite.NoError(suite.miniRedis.Set("GET-PROFILE-USER:userId", "not json"))
+
+	result := <-suite.repository.FindProfile(suite.ctx, "userId")
+
+	assert.NoError(suite.T(), result.Error)
+	assert.Nil(suite.T(), result.Data)
+}
+
+func (suite *CacheTestSuite) TestFindProfileErrRedis() {
+	suite.miniRedis.Close()
+
+	result := <-suite.repository.FindProfile(suite.ctx, "userId")
+
+	assert.Error(suite.T(), result.Error)
+}
+
+func (suite *CacheTestSuite) TestDeleteProfile() {
+	suite.NoError(suite.miniRedis.Set("GET-PROFILE-USER:userId", `{"data":{"user_id":"userId"}}`))
+
+	result := <-suite.repository.DeleteProfile(suite.ctx, "userId")
+
+	assert.NoError(suite.T(), result.Error)
+	assert.False(suite.T(), suite.miniRedis.Exists("GET-PROFILE-USER:userId"))
+	assert.Equal(suite.T(), 30*time.Second, suite.miniRedis.TTL("PROFILE-USER-TOMBSTONE:userId"))
+}
+
+func (suite *CacheTestSuite) TestSetProfileAfterDelete() {
+	// a request that read the user before the change does not write it back
+	<-suite.repository.DeleteProfile(suite.ctx, "userId")
+	result := <-suite.repository.SetProfile(suite.ctx, dto.UserResp{UserId: "userId", Status: "active"})
+
+	assert.NoError(suite.T(), result.Error)
+	assert.Nil(suite.T(), result.Data)
+	assert.False(suite.T(), suite.miniRedis.Exists("GET-PROFILE-USER:userId"))
+
+	suite.miniRedis.FastForward(30 * time.Second)
+	result = <-suite.repository.SetProfile(suite.ctx, dto.UserResp{UserId: "userId", Status: "blocked"})
+
+	assert.NoError(suite.T(), result.Error)
+	assert.True(suite.T(), suite.miniRedis.Exists("GET-PROFILE-USER:userId"))
+}
+
+func (suite *CacheTestSuite) TestSetProfileErrRedis() {
+	suite.miniRedis.Close()
+
+	result := <-suite.repository.SetProfile(suite.ctx, dto.UserResp{UserId: "userId"})
+
+	assert.Error(suite.T(), result.Error)
+}
+
+func (suite *CacheTestSuite) TestDeleteProfileErrRedis() {
+	suite.miniRedis.Close()
+
+	result := <-suite.repository.DeleteProfile(suite.ctx, "userId")
+
+	assert.Error(suite.T(), result.Error)
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.elastic.co/apm"
+
+	"event-service/internal/modules/user"
+	"event-service/internal/modules/user/models/request"
+	"event-service/internal/pkg/log"
+)
+
+type commandUsecase struct {
+	userRepositoryCache user.RedisRepositoryCache
+	logger              log.Logger
+}
+
+func NewCommandUsecase(urc user.RedisRepositoryCache, log log.Logger) user.UsecaseCommand {
+	return commandUsecase{
+		userRepositoryCache: urc,
+		logger:              log,
+	}
+}
+
+// InvalidateProfile drops the cached profile of a changed user, the next request reads its role and status again.
+func (c commandUsecase) InvalidateProfile(origCtx context.Context, payload request.UserChangedReq) error {
+	domain := "userUsecase-InvalidateProfile"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.userRepositoryCache.DeleteProfile(ctx, payload.UserId)
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	c.logger.Info(ctx, fmt.Sprintf("Invalidate cached profile, userId : %s", payload.UserId), fmt.Sprintf("%+v", payload))
+	return nil
+}
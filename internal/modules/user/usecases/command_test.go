@@ -0,0 +1,69 @@
+package usecases_test
+
+import (
+	"context"
+	"event-service/internal/modules/user"
+	"event-service/internal/modules/user/models/request"
+	uc "event-service/internal/modules/user/usecases"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	mockcert "event-service/mocks/modules/user"
+	mocklog "event-service/mocks/pkg/log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockUserRepositoryCache *mockcert.RedisRepositoryCache
+	mockLogger              *mocklog.Logger
+	usecase                 user.UsecaseCommand
+	ctx                     context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockUserRepositoryCache = &mockcert.RedisRepositoryCache{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockUserRepositoryCache,
+		suite.mockLogger,
+	)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
+
+func (suite *CommandUsecaseTestSuite) TestInvalidateProfile() {
+	suite.mockUserRepositoryCache.On("DeleteProfile", mock.Anything, "userId").Return(mockChannel(helpers.Result{Data: "userId"}))
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	err := suite.usecase.InvalidateProfile(suite.ctx, request.UserChangedReq{UserId: "userId"})
+
+	assert.NoError(suite.T(), err)
+	suite.mockUserRepositoryCache.AssertExpectations(suite.T())
+}
+
+func (suite *CommandUsecaseTestSuite) TestInvalidateProfileErr() {
+	suite.mockUserRepositoryCache.On("DeleteProfile", mock.Anything, "userId").
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error redis")}))
+
+	err := suite.usecase.InvalidateProfile(suite.ctx, request.UserChangedReq{UserId: "userId"})
+
+	assert.Error(suite.T(), err)
+}
@@ -2,9 +2,22 @@ package user
 
 import (
 	"context"
+	"event-service/internal/modules/user/models/dto"
+	"event-service/internal/modules/user/models/request"
 	wrapper "event-service/internal/pkg/helpers"
 )
 
+type UsecaseCommand interface {
+	InvalidateProfile(origCtx context.Context, payload request.UserChangedReq) error
+}
+
 type MongodbRepositoryQuery interface {
 	FindOneUserId(ctx context.Context, userId string) <-chan wrapper.Result
 }
+
+// RedisRepositoryCache holds the profiles read by the bearer middleware, the user service owns the users themselves.
+type RedisRepositoryCache interface {
+	FindProfile(ctx context.Context, userId string) <-chan wrapper.Result
+	SetProfile(ctx context.Context, profile dto.UserResp) <-chan wrapper.Result
+	DeleteProfile(ctx context.Context, userId string) <-chan wrapper.Result
+}
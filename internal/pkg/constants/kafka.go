@@ -0,0 +1,9 @@
+package constants
+
+// consumed kafka topic
+const (
+	TopicBankTicketCreated = `concert-bank-ticket-created`
+	TopicOrderAck          = `concert-order-ack`
+	TopicUserUpdated       = `concert-user-updated`
+	TopicUserDeleted       = `concert-user-deleted`
+)
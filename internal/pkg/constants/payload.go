@@ -1,10 +1,11 @@
 package constants
 
 type MetaData struct {
-	Page      int64 `json:"page"`
-	Count     int64 `json:"count"`
-	TotalPage int64 `json:"totalPage"`
-	TotalData int64 `json:"totalData"`
+	Page       int64  `json:"page"`
+	Count      int64  `json:"count"`
+	TotalPage  int64  `json:"totalPage"`
+	TotalData  int64  `json:"totalData"`
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 const (
@@ -14,3 +15,85 @@ const (
 	Bronze = "Bronze"
 	Wood   = "Wood"
 )
+
+// event status
+const (
+	EventStatusDraft       = `draft`
+	EventStatusScheduled   = `scheduled`
+	EventStatusPublished   = `published`
+	EventStatusSalesClosed = `sales-closed`
+	EventStatusFinished    = `finished`
+	EventStatusCancelled   = `cancelled`
+)
+
+// event list sort
+const (
+	EventSortName   = `name`
+	EventSortDate   = `date`
+	EventSortNewest = `newest`
+)
+
+// EventTextIndexName is the text index the event list search runs on
+const EventTextIndexName = `event_text_search`
+
+// outbox status
+const (
+	OutboxStatusPending = `pending`
+	OutboxStatusSent    = `sent`
+)
+
+// order status acknowledged by the order service
+const (
+	OrderStatusPaid      = `paid`
+	OrderStatusCancelled = `cancelled`
+	OrderStatusExpired   = `expired`
+)
+
+// ticket provisioning status
+const (
+	TicketProvisioningPending     = `pending`
+	TicketProvisioningProvisioned = `provisioned`
+	TicketProvisioningFailed      = `failed`
+)
+
+// online ticket config history action
+const (
+	OnlineTicketConfigUpserted = `upserted`
+	OnlineTicketConfigDeleted  = `deleted`
+)
+
+// user role
+const (
+	RoleAdmin     = `admin`
+	RoleOrganizer = `organizer`
+	RoleFan       = `fan`
+	RoleSupport   = `support`
+)
+
+// user status, only the active users are let through
+const (
+	UserStatusActive = `active`
+)
+
+// route permission, the roles holding each of them come from the permission matrix
+const (
+	PermissionEventCreate              = `event:create`
+	PermissionEventRead                = `event:read`
+	PermissionEventUpdate              = `event:update`
+	PermissionEventPublish             = `event:publish`
+	PermissionEventCancel              = `event:cancel`
+	PermissionTicketManage             = `ticket:manage`
+	PermissionTicketHold               = `ticket:hold`
+	PermissionOnlineTicketConfigManage = `online-ticket-config:manage`
+	PermissionOnlineTicketConfigRead   = `online-ticket-config:read`
+	PermissionOutboxRead               = `outbox:read`
+	PermissionOrganizerManage          = `organizer:manage`
+	PermissionOrganizerRead            = `organizer:read`
+)
+
+// organizer member role, owners manage the team, editors manage its events and viewers only read them
+const (
+	OrganizerRoleOwner  = `owner`
+	OrganizerRoleEditor = `editor`
+	OrganizerRoleViewer = `viewer`
+)
@@ -3,10 +3,24 @@ package constants
 // key redis
 const (
 	RedisKeyGetProfileUser      = `GET-PROFILE-USER`
+	RedisKeyProfileTombstone    = `PROFILE-USER-TOMBSTONE`
 	RedisKeyUserJwt             = `USER-JWT`
 	RedisKeyBlockListJwt        = `BLOCKLIST-JWT`
 	RedisKeyBlockListRefreshJwt = `BLOCKLIST-REFRESH-JWT`
 	RedisKeyLoginAttempt        = `LOGIN-ATTEMPT`
 	RedisKeyOtpRegister         = `OTP-REGISTER`
 	RedisKeyOtpLogin            = `OTP-LOGIN`
+	RedisKeyEventSchedulerLock  = `EVENT-SCHEDULER-LOCK`
+	RedisKeyOutboxRelayLock     = `OUTBOX-RELAY-LOCK`
+	RedisKeyTicketStock         = `TICKET-STOCK`
+	RedisKeyTicketHold          = `TICKET-HOLD`
+	RedisKeyTicketHoldExpiry    = `TICKET-HOLD-EXPIRY`
+	RedisKeyTicketStockIndex    = `TICKET-STOCK-INDEX`
+	RedisKeyStockReconcileLock  = `STOCK-RECONCILE-LOCK`
+	RedisKeyEventListCache      = `EVENT-LIST-CACHE`
+	RedisKeyEventListVersion    = `EVENT-LIST-VERSION`
+	RedisKeyEventListSettling   = `EVENT-LIST-SETTLING`
+	RedisKeyEventDetailCache    = `EVENT-DETAIL-CACHE`
+	RedisKeyEventDetailVersion  = `EVENT-DETAIL-VERSION`
+	RedisKeyEventCacheLock      = `EVENT-CACHE-LOCK`
 )
@@ -0,0 +1,129 @@
+package mongodb
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"event-service/internal/pkg/errors"
+)
+
+// keysetCursor is the decoded form of an opaque cursor, it holds the sort key values of the last document of a page.
+type keysetCursor struct {
+	Keys   []string      `bson:"k"`
+	Values []interface{} `bson:"v"`
+}
+
+func (s Sort) keys() []Sort {
+	keys := []Sort{{FieldName: s.FieldName, By: s.By, Meta: s.Meta}}
+	for _, then := range s.ThenBy {
+		keys = append(keys, then.keys()...)
+	}
+	return keys
+}
+
+func (s Sort) hasMeta() bool {
+	for _, key := range s.keys() {
+		if key.Meta != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func sortFieldNames(keys []Sort) []string {
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.FieldName)
+	}
+	return names
+}
+
+// encodeCursor builds the cursor pointing after doc. The sort must end on a unique key for the cursor to be exact.
+func encodeCursor(sort Sort, doc bson.Raw) (string, error) {
+	keys := sort.keys()
+	cursor := keysetCursor{Keys: sortFieldNames(keys), Values: make([]interface{}, 0, len(keys))}
+	for _, key := range keys {
+		value, err := doc.LookupErr(strings.Split(key.FieldName, ".")...)
+		if err != nil {
+			return "", err
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+
+	raw, err := bson.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// isScalar reports whether a decoded cursor value can only be compared. Documents, arrays, regular expressions and
+// code could carry query operators into the keyset filter.
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case nil, string, bool, int32, int64, float64, primitive.DateTime, primitive.Timestamp, primitive.ObjectID,
+		primitive.Decimal128:
+		return true
+	}
+	return false
+}
+
+// decodeCursor rejects cursors that are malformed, were issued for a different sort or hold a value that is not a
+// scalar. The cursor is not signed, a client can write any value into it.
+func decodeCursor(sort Sort, value string) (keysetCursor, error) {
+	var cursor keysetCursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, errors.BadRequest("invalid cursor")
+	}
+	if err := bson.Unmarshal(raw, &cursor); err != nil {
+		return cursor, errors.BadRequest("invalid cursor")
+	}
+	if !reflect.DeepEqual(cursor.Keys, sortFieldNames(sort.keys())) || len(cursor.Values) != len(cursor.Keys) {
+		return cursor, errors.BadRequest("invalid cursor")
+	}
+	for _, value := range cursor.Values {
+		if !isScalar(value) {
+			return cursor, errors.BadRequest("invalid cursor")
+		}
+	}
+	return cursor, nil
+}
+
+// buildKeysetFilter matches the documents sorted strictly after the cursor:
+// {$or: [{k1 > v1}, {k1 = v1, k2 > v2}, ...]} with < for descending keys.
+func buildKeysetFilter(sort Sort, cursor keysetCursor) bson.M {
+	keys := sort.keys()
+	or := make(bson.A, 0, len(keys))
+	for i, key := range keys {
+		clause := bson.M{}
+		for j := 0; j < i; j++ {
+			clause[keys[j].FieldName] = cursor.Values[j]
+		}
+		operator := "$gt"
+		if key.By == SortDescending {
+			operator = "$lt"
+		}
+		clause[key.FieldName] = bson.M{operator: cursor.Values[i]}
+		or = append(or, clause)
+	}
+	return bson.M{"$or": or}
+}
+
+// decodeRaws unmarshals each document into a new element of the slice result points to.
+func decodeRaws(raws []bson.Raw, result interface{}) error {
+	slice := reflect.ValueOf(result).Elem()
+	slice.Set(reflect.MakeSlice(slice.Type(), 0, len(raws)))
+	for _, raw := range raws {
+		item := reflect.New(slice.Type().Elem())
+		if err := bson.Unmarshal(raw, item.Interface()); err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, item.Elem()))
+	}
+	return nil
+}
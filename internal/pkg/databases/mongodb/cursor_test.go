@@ -0,0 +1,115 @@
+package mongodb
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"event-service/internal/pkg/errors"
+)
+
+var cursorSort = Sort{
+	FieldName: "dateTime",
+	By:        SortDescending,
+	ThenBy:    []Sort{{FieldName: "eventId", By: SortAscending}},
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	dateTime := time.Date(2024, 6, 1, 19, 0, 0, 0, time.UTC)
+	doc, err := bson.Marshal(bson.M{"eventId": "id", "name": "name", "dateTime": dateTime})
+	assert.NoError(t, err)
+
+	value, err := encodeCursor(cursorSort, doc)
+	assert.NoError(t, err)
+	assert.NotContains(t, value, "=")
+
+	cursor, err := decodeCursor(cursorSort, value)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dateTime", "eventId"}, cursor.Keys)
+	assert.Equal(t, []interface{}{primitive.NewDateTimeFromTime(dateTime), "id"}, cursor.Values)
+}
+
+func TestCursorNestedKey(t *testing.T) {
+	sort := Sort{FieldName: "country.code", ThenBy: []Sort{{FieldName: "eventId"}}}
+	doc, err := bson.Marshal(bson.M{"eventId": "id", "country": bson.M{"code": "ID"}})
+	assert.NoError(t, err)
+
+	value, err := encodeCursor(sort, doc)
+	assert.NoError(t, err)
+
+	cursor, err := decodeCursor(sort, value)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"ID", "id"}, cursor.Values)
+}
+
+func TestEncodeCursorMissingKey(t *testing.T) {
+	doc, err := bson.Marshal(bson.M{"eventId": "id"})
+	assert.NoError(t, err)
+
+	_, err = encodeCursor(cursorSort, doc)
+	assert.Error(t, err)
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	doc, err := bson.Marshal(bson.M{"eventId": "id", "name": "name"})
+	assert.NoError(t, err)
+	otherSort, err := encodeCursor(Sort{FieldName: "name", ThenBy: []Sort{{FieldName: "eventId"}}}, doc)
+	assert.NoError(t, err)
+
+	for _, value := range []string{"not base64 !", "bm90IGJzb24", otherSort} {
+		_, err := decodeCursor(cursorSort, value)
+		assert.Error(t, err)
+		errString, ok := err.(*errors.ErrorString)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, errString.Code())
+	}
+}
+
+func TestDecodeCursorOperator(t *testing.T) {
+	keys := []string{"dateTime", "eventId"}
+	for _, value := range []interface{}{
+		bson.M{"$ne": nil},
+		bson.A{"id"},
+		primitive.Regex{Pattern: ".*"},
+		primitive.JavaScript("return true"),
+	} {
+		raw, err := bson.Marshal(bson.M{"k": keys, "v": bson.A{primitive.NewDateTimeFromTime(time.Now()), value}})
+		assert.NoError(t, err)
+
+		_, err = decodeCursor(cursorSort, base64.RawURLEncoding.EncodeToString(raw))
+		errString, ok := err.(*errors.ErrorString)
+		assert.True(t, ok, "%v", value)
+		assert.Equal(t, http.StatusBadRequest, errString.Code())
+	}
+}
+
+func TestBuildKeysetFilter(t *testing.T) {
+	dateTime := primitive.NewDateTimeFromTime(time.Date(2024, 6, 1, 19, 0, 0, 0, time.UTC))
+	filter := buildKeysetFilter(cursorSort, keysetCursor{
+		Keys:   []string{"dateTime", "eventId"},
+		Values: []interface{}{dateTime, "id"},
+	})
+
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"dateTime": bson.M{"$lt": dateTime}},
+		bson.M{"dateTime": dateTime, "eventId": bson.M{"$gt": "id"}},
+	}}, filter)
+}
+
+func TestDecodeRaws(t *testing.T) {
+	type item struct {
+		EventId string `bson:"eventId"`
+	}
+	first, _ := bson.Marshal(bson.M{"eventId": "first"})
+	second, _ := bson.Marshal(bson.M{"eventId": "second"})
+
+	var result []item
+	err := decodeRaws([]bson.Raw{first, second}, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, []item{{EventId: "first"}, {EventId: "second"}}, result)
+}
@@ -37,9 +37,19 @@ const (
 	SortDescending = `desc`
 )
 
+// server error codes
+const (
+	codeNamespaceNotFound = 26
+	codeIndexNotFound     = 27
+)
+
+// Sort orders by FieldName, documents with an equal FieldName are ordered by the keys of ThenBy in turn.
+// A Meta key such as textScore sorts by that $meta value instead, its By is ignored.
 type Sort struct {
 	FieldName string
 	By        string
+	Meta      string
+	ThenBy    []Sort
 }
 
 func (s Sort) buildSortBy() int {
@@ -50,14 +60,31 @@ func (s Sort) buildSortBy() int {
 	return 1
 }
 
+func (s Sort) buildSort() bson.D {
+	var value interface{} = s.buildSortBy()
+	if s.Meta != "" {
+		value = bson.M{"$meta": s.Meta}
+	}
+	sort := bson.D{{Key: s.FieldName, Value: value}}
+	for _, then := range s.ThenBy {
+		sort = append(sort, then.buildSort()...)
+	}
+	return sort
+}
+
 type FindAllData struct {
 	Result         interface{}
 	CountData      *int64
 	CollectionName string
 	Filter         interface{}
+	Projection     interface{}
 	Sort           *Sort
 	Page           int64
 	Size           int64
+	// Keyset pages with Cursor instead of Page, it requires a Sort ending on a unique key.
+	// An empty Cursor starts at the first page, the cursor of the next page is returned in NextCursor.
+	Keyset bool
+	Cursor string
 }
 
 func (f FindAllData) generateOptionSkip() *int64 {
@@ -78,13 +105,42 @@ func (m MongoDBLogger) FindAllData(payload FindAllData, ctx context.Context) <-c
 		findOption := options.Find()
 
 		if payload.Sort != nil {
-			findOption.SetSort(bson.D{{Key: payload.Sort.FieldName, Value: payload.Sort.buildSortBy()}})
+			findOption.SetSort(payload.Sort.buildSort())
+		}
+		if payload.Projection != nil {
+			findOption.SetProjection(payload.Projection)
 		}
 
-		findOption.Limit = &payload.Size
-		findOption.Skip = payload.generateOptionSkip()
+		filter := payload.Filter
+		if payload.Keyset {
+			if payload.Sort == nil || payload.Sort.hasMeta() {
+				output <- wrapper.Result{
+					Error: errors.InternalServerError("keyset pagination requires a sort on document fields"),
+				}
+				return
+			}
+			if payload.Cursor != "" {
+				keyset, err := decodeCursor(*payload.Sort, payload.Cursor)
+				if err != nil {
+					output <- wrapper.Result{
+						Error: err,
+					}
+					return
+				}
+				filter = buildKeysetFilter(*payload.Sort, keyset)
+				if payload.Filter != nil {
+					filter = bson.M{"$and": bson.A{payload.Filter, filter}}
+				}
+			}
+			// one extra document tells whether there is a next page
+			limit := payload.Size + 1
+			findOption.Limit = &limit
+		} else {
+			findOption.Limit = &payload.Size
+			findOption.Skip = payload.generateOptionSkip()
+		}
 
-		cursor, err := collection.Find(ctx, payload.Filter, findOption)
+		cursor, err := collection.Find(ctx, filter, findOption)
 
 		if err != nil {
 			msg := fmt.Sprintf("Error Mongodb Connection : %s", err.Error())
@@ -92,11 +148,25 @@ func (m MongoDBLogger) FindAllData(payload FindAllData, ctx context.Context) <-c
 			output <- wrapper.Result{
 				Error: errors.InternalServerError(msg),
 			}
+			return
 		}
 
 		defer cursor.Close(ctx)
 
-		err = cursor.All(ctx, payload.Result)
+		var nextCursor string
+		if payload.Keyset {
+			var raws []bson.Raw
+			err = cursor.All(ctx, &raws)
+			if err == nil && payload.Size > 0 && int64(len(raws)) > payload.Size {
+				raws = raws[:payload.Size]
+				nextCursor, err = encodeCursor(*payload.Sort, raws[len(raws)-1])
+			}
+			if err == nil {
+				err = decodeRaws(raws, payload.Result)
+			}
+		} else {
+			err = cursor.All(ctx, payload.Result)
+		}
 
 		if err != nil {
 			msg := "cannot unmarshal result"
@@ -104,6 +174,7 @@ func (m MongoDBLogger) FindAllData(payload FindAllData, ctx context.Context) <-c
 			output <- wrapper.Result{
 				Error: errors.InternalServerError(msg),
 			}
+			return
 		}
 
 		finish := time.Now()
@@ -125,14 +196,17 @@ func (m MongoDBLogger) FindAllData(payload FindAllData, ctx context.Context) <-c
 				output <- wrapper.Result{
 					Error: errors.InternalServerError("Error Mongodb Connection"),
 				}
+				return
 			}
 			output <- wrapper.Result{
-				Data:  payload.Result,
-				Count: resp.Count,
+				Data:       payload.Result,
+				Count:      resp.Count,
+				NextCursor: nextCursor,
 			}
 		} else {
 			output <- wrapper.Result{
-				Data: payload.Result,
+				Data:       payload.Result,
+				NextCursor: nextCursor,
 			}
 		}
 
@@ -162,6 +236,7 @@ func (m MongoDBLogger) FindOne(payload FindOne, ctx context.Context) <-chan wrap
 				output <- wrapper.Result{
 					Data: nil,
 				}
+				return
 			}
 
 			msg := fmt.Sprintf("Error Mongodb Connection %s", documentReturned.Err())
@@ -169,6 +244,7 @@ func (m MongoDBLogger) FindOne(payload FindOne, ctx context.Context) <-chan wrap
 			output <- wrapper.Result{
 				Error: errors.InternalServerError(msg),
 			}
+			return
 		}
 
 		if err := documentReturned.Decode(payload.Result); err != nil {
@@ -177,6 +253,7 @@ func (m MongoDBLogger) FindOne(payload FindOne, ctx context.Context) <-chan wrap
 			output <- wrapper.Result{
 				Error: errors.InternalServerError(msg),
 			}
+			return
 		}
 		output <- wrapper.Result{
 			Data: payload.Result,
@@ -211,7 +288,7 @@ func (m MongoDBLogger) FindMany(payload FindMany, ctx context.Context) <-chan wr
 		findOption := options.Find()
 
 		if payload.Sort != nil {
-			findOption.SetSort(bson.D{{Key: payload.Sort.FieldName, Value: payload.Sort.buildSortBy()}})
+			findOption.SetSort(payload.Sort.buildSort())
 		}
 
 		cursor, err := collection.Find(ctx, payload.Filter, findOption)
@@ -222,6 +299,7 @@ func (m MongoDBLogger) FindMany(payload FindMany, ctx context.Context) <-chan wr
 			output <- wrapper.Result{
 				Error: errors.InternalServerError(msg),
 			}
+			return
 		}
 
 		defer cursor.Close(ctx)
@@ -232,6 +310,7 @@ func (m MongoDBLogger) FindMany(payload FindMany, ctx context.Context) <-chan wr
 			output <- wrapper.Result{
 				Error: errors.InternalServerError(msg),
 			}
+			return
 		}
 		output <- wrapper.Result{
 			Data: payload.Result,
@@ -332,6 +411,7 @@ func (m MongoDBLogger) UpsertOne(payload UpdateOne, ctx context.Context) <-chan
 		doc := bson.D{{Key: "$set", Value: update}}
 		opts := options.Update().SetUpsert(true)
 
+		var writeErr error
 		callback := func(sessCtx mongo.SessionContext) (interface{}, error) {
 			// Important: You must pass sessCtx as the Context parameter to the operations for them to be executed in the
 			// transaction.
@@ -340,23 +420,18 @@ func (m MongoDBLogger) UpsertOne(payload UpdateOne, ctx context.Context) <-chan
 			if err != nil {
 				msg := fmt.Sprintf("Error Mongodb Connection : %s", err.Error())
 				m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
-				return nil, errors.InternalServerError("Error mongodb connection")
+				writeErr = writeError(err)
+				return nil, writeErr
 			}
 			return nil, nil
 		}
 
-		session, err := m.mongoClient.StartSession()
-		if err != nil {
-			msg := fmt.Sprintf("Error Mongodb Session : %s", err.Error())
-			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+		_, err = m.runTransaction(ctx, callback, txnOpts)
+		if writeErr != nil {
 			output <- wrapper.Result{
-				Error: errors.InternalServerError("Error mongodb session"),
+				Error: writeErr,
 			}
-		}
-		defer session.EndSession(context.Background())
-
-		_, err = session.WithTransaction(ctx, callback, txnOpts)
-		if err != nil {
+		} else if err != nil {
 			msg := fmt.Sprintf("Error Mongodb Transaction : %s", err.Error())
 			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
 			output <- wrapper.Result{
@@ -380,6 +455,24 @@ func (m MongoDBLogger) Close(ctx context.Context) error {
 	return m.mongoClient.Disconnect(ctx)
 }
 
+// writeError reports a write breaking a unique index as a conflict, so callers can tell it from a failure.
+func writeError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.Conflict("Duplicate data")
+	}
+	// a write in a transaction conflicts with a write committed after the transaction read its snapshot
+	if serverErr, ok := err.(mongo.ServerError); ok && serverErr.HasErrorLabel("TransientTransactionError") {
+		return errors.Conflict("Data changed meanwhile, please retry")
+	}
+	return errors.InternalServerError("Error mongodb connection")
+}
+
+// isMissingIndex reports whether a drop failed on an index or a collection that does not exist.
+func isMissingIndex(err error) bool {
+	commandErr, ok := err.(mongo.CommandError)
+	return ok && (commandErr.Code == codeNamespaceNotFound || commandErr.Code == codeIndexNotFound)
+}
+
 type InsertOne struct {
 	CollectionName string
 	Document       interface{}
@@ -399,8 +492,9 @@ func (m MongoDBLogger) InsertOne(payload InsertOne, ctx context.Context) <-chan
 			msg := fmt.Sprintf("Error Mongodb Connection : %s", err.Error())
 			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
 			output <- wrapper.Result{
-				Error: errors.InternalServerError("Error mongodb connection"),
+				Error: writeError(err),
 			}
+			return
 		}
 
 		finish := time.Now()
@@ -438,8 +532,9 @@ func (m MongoDBLogger) InsertMany(payload InsertMany, ctx context.Context) <-cha
 			msg := fmt.Sprintf("Error Mongodb Connection : %s", err.Error())
 			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
 			output <- wrapper.Result{
-				Error: errors.InternalServerError("Error mongodb connection"),
+				Error: writeError(err),
 			}
+			return
 		}
 
 		finish := time.Now()
@@ -487,14 +582,100 @@ func (m MongoDBLogger) UpdateOne(payload UpdateOne, ctx context.Context) <-chan
 		}
 
 		doc := bson.D{{Key: "$set", Value: update}}
-		_, err = collection.UpdateOne(ctx, payload.Filter, doc)
+		resp, err := collection.UpdateOne(ctx, payload.Filter, doc)
+
+		if err != nil {
+			msg := fmt.Sprintf("Error Mongodb Connection : %s", err.Error())
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+			output <- wrapper.Result{
+				Error: writeError(err),
+			}
+			return
+		}
+
+		finish := time.Now()
+
+		if finish.Sub(start).Seconds() > 10 {
+			j, _ := json.Marshal(payload.Filter)
+			msg := fmt.Sprintf("slow query: %v second, query: %s", finish.Sub(start).Seconds(), string(j))
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+		}
+
+		output <- wrapper.Result{
+			Data:  "Success update data",
+			Count: resp.MatchedCount,
+		}
+	}()
 
+	return output
+}
+
+type UpdateMany struct {
+	CollectionName string
+	Filter         interface{}
+	Document       interface{}
+}
+
+func (m MongoDBLogger) UpdateMany(payload UpdateMany, ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+		start := time.Now()
+
+		collection := m.mongoClient.Database(m.dbName).Collection(payload.CollectionName)
+
+		doc := bson.D{{Key: "$set", Value: payload.Document}}
+		resp, err := collection.UpdateMany(ctx, payload.Filter, doc)
+		if err != nil {
+			msg := fmt.Sprintf("Error Mongodb Connection : %s", err.Error())
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+			output <- wrapper.Result{
+				Error: writeError(err),
+			}
+			return
+		}
+
+		finish := time.Now()
+
+		if finish.Sub(start).Seconds() > 10 {
+			j, _ := json.Marshal(payload.Filter)
+			msg := fmt.Sprintf("slow query: %v second, query: %s", finish.Sub(start).Seconds(), string(j))
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+		}
+
+		output <- wrapper.Result{
+			Data:  "Success update data",
+			Count: resp.ModifiedCount,
+		}
+	}()
+
+	return output
+}
+
+type DeleteOne struct {
+	CollectionName string
+	Filter         interface{}
+}
+
+// DeleteOne removes at most one document matching the filter, Count holds the number of deleted documents.
+func (m MongoDBLogger) DeleteOne(payload DeleteOne, ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+		start := time.Now()
+
+		collection := m.mongoClient.Database(m.dbName).Collection(payload.CollectionName)
+
+		resp, err := collection.DeleteOne(ctx, payload.Filter)
 		if err != nil {
 			msg := fmt.Sprintf("Error Mongodb Connection : %s", err.Error())
 			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
 			output <- wrapper.Result{
 				Error: errors.InternalServerError("Error mongodb connection"),
 			}
+			return
 		}
 
 		finish := time.Now()
@@ -506,7 +687,73 @@ func (m MongoDBLogger) UpdateOne(payload UpdateOne, ctx context.Context) <-chan
 		}
 
 		output <- wrapper.Result{
-			Data: "Success update data",
+			Data:  "Success delete data",
+			Count: resp.DeletedCount,
+		}
+	}()
+
+	return output
+}
+
+type CreateIndexes struct {
+	CollectionName string
+	Indexes        []mongo.IndexModel
+}
+
+// CreateIndexes is idempotent for indexes that already exist with the same definition.
+func (m MongoDBLogger) CreateIndexes(payload CreateIndexes, ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		collection := m.mongoClient.Database(m.dbName).Collection(payload.CollectionName)
+
+		names, err := collection.Indexes().CreateMany(ctx, payload.Indexes)
+		if err != nil {
+			msg := fmt.Sprintf("Error create index : %s", err.Error())
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+			output <- wrapper.Result{
+				Error: errors.InternalServerError("Error create index"),
+			}
+			return
+		}
+
+		output <- wrapper.Result{
+			Data:  names,
+			Count: int64(len(names)),
+		}
+	}()
+
+	return output
+}
+
+type DropIndex struct {
+	CollectionName string
+	Name           string
+}
+
+// DropIndex is idempotent, dropping an index or a collection that does not exist succeeds.
+func (m MongoDBLogger) DropIndex(payload DropIndex, ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		collection := m.mongoClient.Database(m.dbName).Collection(payload.CollectionName)
+
+		_, err := collection.Indexes().DropOne(ctx, payload.Name)
+		if err != nil && !isMissingIndex(err) {
+			msg := fmt.Sprintf("Error drop index : %s", err.Error())
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+			output <- wrapper.Result{
+				Error: errors.InternalServerError("Error drop index"),
+			}
+			return
+		}
+
+		output <- wrapper.Result{
+			Data: payload.Name,
 		}
 	}()
 
@@ -535,6 +782,7 @@ func (m MongoDBLogger) Aggregate(payload Aggregate, ctx context.Context) <-chan
 			output <- wrapper.Result{
 				Error: errors.InternalServerError("Error mongodb connection"),
 			}
+			return
 		}
 		defer cursor.Close(ctx)
 
@@ -544,6 +792,7 @@ func (m MongoDBLogger) Aggregate(payload Aggregate, ctx context.Context) <-chan
 			output <- wrapper.Result{
 				Error: errors.InternalServerError(msg),
 			}
+			return
 		}
 		output <- wrapper.Result{
 			Data: payload.Result,
@@ -569,10 +818,13 @@ type FindOneAndUpdate struct {
 }
 
 // FindOneAndUpdate executes a findAndModify command to update at most one document in the collection and returns the document BEFORE or AFTER updating.
+// Update must use update operators ($set, $inc, ...). When no document matches the filter, Data is nil, so a filter can
+// carry the condition of a conditional update.
 func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.ReturnDocument, ctx context.Context) <-chan wrapper.Result {
 	output := make(chan wrapper.Result)
 
 	go func() {
+		defer close(output)
 		start := time.Now()
 
 		wc := writeconcern.Majority()
@@ -587,6 +839,7 @@ func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.Ret
 			output <- wrapper.Result{
 				Error: errors.InternalServerError("Error mongodb connection"),
 			}
+			return
 		}
 
 		var update bson.M
@@ -597,15 +850,20 @@ func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.Ret
 			output <- wrapper.Result{
 				Error: errors.InternalServerError("Error mongodb connection"),
 			}
+			return
 		}
 
 		callback := func(sessCtx mongo.SessionContext) (interface{}, error) {
 			// Important: You must pass sessCtx as the Context parameter to the operations for them to be executed in the
 			// transaction.
 			opts := options.FindOneAndUpdate().SetUpsert(payload.Upsert).SetReturnDocument(rd)
-			res := collection.FindOneAndUpdate(ctx, payload.Filter, update, opts)
+			res := collection.FindOneAndUpdate(sessCtx, payload.Filter, update, opts)
+			if res.Err() == mongo.ErrNoDocuments {
+				return nil, nil
+			}
+
 			if res.Err() != nil {
-				msg := fmt.Sprintf("Error Mongodb: %s", err.Error())
+				msg := fmt.Sprintf("Error Mongodb: %s", res.Err().Error())
 				m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
 				return nil, errors.InternalServerError("Error mongodb connection")
 			}
@@ -618,30 +876,18 @@ func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.Ret
 			return payload.Result, nil
 		}
 
-		session, err := m.mongoClient.StartSession()
-		if err != nil {
-			msg := fmt.Sprintf("Error Mongodb Session : %s", err.Error())
-			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
-			output <- wrapper.Result{
-				Error: errors.InternalServerError("Error mongodb session"),
-			}
-		}
-		defer session.EndSession(context.Background())
-
-		result, err := session.WithTransaction(ctx, callback, txnOpts)
+		result, err := m.runTransaction(ctx, callback, txnOpts)
 		if err != nil {
 			msg := fmt.Sprintf("Error Mongodb Transaction : %s", err.Error())
 			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
 			output <- wrapper.Result{
 				Error: errors.InternalServerError("Error mongodb transaction"),
 			}
+			return
 		}
-		rs, _ := json.Marshal(result)
-		fmt.Println("-----Result FindOneAndUpdate------")
-		fmt.Println(string(rs))
 
 		output <- wrapper.Result{
-			Data: payload.Result,
+			Data: result,
 		}
 
 		finish := time.Now()
@@ -656,6 +902,47 @@ func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.Ret
 	return output
 }
 
+// runTransaction runs callback in a new transaction, or inside the caller's transaction when ctx already carries a session.
+func (m MongoDBLogger) runTransaction(ctx context.Context, callback func(sessCtx mongo.SessionContext) (interface{}, error),
+	opts ...*options.TransactionOptions) (interface{}, error) {
+	if session := mongo.SessionFromContext(ctx); session != nil {
+		return callback(mongo.NewSessionContext(ctx, session))
+	}
+
+	session, err := m.mongoClient.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(context.Background())
+
+	return session.WithTransaction(ctx, callback, opts...)
+}
+
+// WithTransaction runs fn in a single transaction. Every operation that receives the context given to fn joins
+// the transaction, and the whole unit is rolled back when fn returns an error.
+func (m MongoDBLogger) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	wc := writeconcern.Majority()
+	rc := readconcern.Snapshot()
+	txnOpts := options.Transaction().SetWriteConcern(wc).SetReadConcern(rc)
+
+	var fnErr error
+	_, err := m.runTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		fnErr = fn(sessCtx)
+		return nil, fnErr
+	}, txnOpts)
+	if fnErr != nil {
+		return fnErr
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("Error Mongodb Transaction : %s", err.Error())
+		m.logger.Error(ctx, msg, "")
+		return errors.InternalServerError("Error mongodb transaction")
+	}
+
+	return nil
+}
+
 // Collections is mongodb's collection of function
 type Collections interface {
 	FindAllData(payload FindAllData, ctx context.Context) <-chan wrapper.Result
@@ -667,6 +954,11 @@ type Collections interface {
 	InsertOne(payload InsertOne, ctx context.Context) <-chan wrapper.Result
 	InsertMany(payload InsertMany, ctx context.Context) <-chan wrapper.Result
 	UpdateOne(payload UpdateOne, ctx context.Context) <-chan wrapper.Result
+	UpdateMany(payload UpdateMany, ctx context.Context) <-chan wrapper.Result
+	DeleteOne(payload DeleteOne, ctx context.Context) <-chan wrapper.Result
 	Aggregate(payload Aggregate, ctx context.Context) <-chan wrapper.Result
+	CreateIndexes(payload CreateIndexes, ctx context.Context) <-chan wrapper.Result
+	DropIndex(payload DropIndex, ctx context.Context) <-chan wrapper.Result
+	WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error
 	Close(ctx context.Context) error
 }
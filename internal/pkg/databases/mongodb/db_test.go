@@ -0,0 +1,90 @@
+package mongodb_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/errors"
+	mocklog "event-service/mocks/pkg/log"
+)
+
+func TestFindMany(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("success", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.event", mtest.FirstBatch,
+			bson.D{{Key: "name", Value: "first"}}, bson.D{{Key: "name", Value: "second"}}))
+		db := mongodb.NewMongoDBLogger(mt.Client, "db", &mocklog.Logger{})
+
+		var result []bson.M
+		resp := <-db.FindMany(mongodb.FindMany{Result: &result, CollectionName: "event", Filter: bson.M{}}, context.Background())
+
+		assert.NoError(mt, resp.Error)
+		assert.Len(mt, result, 2)
+	})
+
+	mt.Run("error find", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "find failed"}))
+		logger := &mocklog.Logger{}
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+		db := mongodb.NewMongoDBLogger(mt.Client, "db", logger)
+
+		var result []bson.M
+		var results []error
+		// a failed find sends a single error and closes the channel, it does not go on with a nil cursor
+		for resp := range db.FindMany(mongodb.FindMany{Result: &result, CollectionName: "event", Filter: bson.M{}}, context.Background()) {
+			results = append(results, resp.Error)
+		}
+
+		assert.Len(mt, results, 1)
+		errString, ok := results[0].(*errors.ErrorString)
+		assert.True(mt, ok)
+		assert.Equal(mt, http.StatusInternalServerError, errString.Code())
+	})
+
+	mt.Run("error decode", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.event", mtest.FirstBatch, bson.D{{Key: "name", Value: bson.A{}}}))
+		logger := &mocklog.Logger{}
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+		db := mongodb.NewMongoDBLogger(mt.Client, "db", logger)
+
+		var result []struct {
+			Name string `bson:"name"`
+		}
+		var results []error
+		for resp := range db.FindMany(mongodb.FindMany{Result: &result, CollectionName: "event", Filter: bson.M{}}, context.Background()) {
+			results = append(results, resp.Error)
+		}
+
+		assert.Len(mt, results, 1)
+		assert.Error(mt, results[0])
+	})
+}
+
+func TestFindOne(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("not found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.event", mtest.FirstBatch))
+		logger := &mocklog.Logger{}
+		logger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+		db := mongodb.NewMongoDBLogger(mt.Client, "db", logger)
+
+		var result bson.M
+		var results []interface{}
+		// a missing document sends a single nil result and closes the channel
+		for resp := range db.FindOne(mongodb.FindOne{Result: &result, CollectionName: "event", Filter: bson.M{}}, context.Background()) {
+			assert.NoError(mt, resp.Error)
+			results = append(results, resp.Data)
+		}
+
+		assert.Equal(mt, []interface{}{nil}, results)
+	})
+}
@@ -0,0 +1,36 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildSort(t *testing.T) {
+	sort := Sort{FieldName: "name", By: SortAscending}
+	assert.Equal(t, bson.D{{Key: "name", Value: 1}}, sort.buildSort())
+}
+
+func TestBuildSortCompound(t *testing.T) {
+	sort := Sort{
+		FieldName: "createdAt",
+		By:        SortDescending,
+		ThenBy: []Sort{
+			{FieldName: "name", By: SortAscending},
+			{FieldName: "eventId"},
+		},
+	}
+	assert.Equal(t, bson.D{{Key: "createdAt", Value: -1}, {Key: "name", Value: 1}, {Key: "eventId", Value: 1}}, sort.buildSort())
+}
+
+func TestBuildSortMeta(t *testing.T) {
+	sort := Sort{
+		FieldName: "score",
+		Meta:      "textScore",
+		ThenBy:    []Sort{{FieldName: "eventId", By: SortAscending}},
+	}
+	assert.Equal(t, bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "eventId", Value: 1}}, sort.buildSort())
+	assert.True(t, sort.hasMeta())
+	assert.False(t, Sort{FieldName: "name"}.hasMeta())
+}
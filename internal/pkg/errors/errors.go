@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"fmt"
 	"net/http"
 )
 
@@ -95,3 +96,23 @@ func UnprocessableEntity(msg string) error {
 		message: msg,
 	}
 }
+
+// InvalidStateTransition will throw if the resource cannot move from its current state to the requested one
+func InvalidStateTransition(from string, to string) error {
+	return &ErrorString{
+		code:    http.StatusConflict,
+		message: fmt.Sprintf("invalid state transition from '%s' to '%s'", from, to),
+	}
+}
+
+// IsConflict reports whether err was thrown by Conflict, e.g. a write breaking a unique index
+func IsConflict(err error) bool {
+	errString, ok := err.(*ErrorString)
+	return ok && errString.code == http.StatusConflict
+}
+
+// IsNotFound reports whether err was thrown by NotFound
+func IsNotFound(err error) bool {
+	errString, ok := err.(*ErrorString)
+	return ok && errString.code == http.StatusNotFound
+}
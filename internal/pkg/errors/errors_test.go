@@ -115,3 +115,26 @@ func TestTooManyRequestError(t *testing.T) {
 	assert.Equal(t, "Too many request error message", err.Error())
 	assert.Equal(t, "Too many request error message", errString.Message())
 }
+
+func TestInvalidStateTransition(t *testing.T) {
+	// Call the function under test
+	err := errors.InvalidStateTransition("draft", "finished")
+
+	errString, _ := err.(*errors.ErrorString)
+	// Assertions
+	assert.NotNil(t, err)
+	assert.Equal(t, "invalid state transition from 'draft' to 'finished'", err.Error())
+	assert.Equal(t, http.StatusConflict, errString.Code())
+}
+
+func TestIsConflict(t *testing.T) {
+	assert.True(t, errors.IsConflict(errors.Conflict("Duplicate data")))
+	assert.False(t, errors.IsConflict(errors.InternalServerError("Error mongodb connection")))
+	assert.False(t, errors.IsConflict(nil))
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, errors.IsNotFound(errors.NotFound("event not found")))
+	assert.False(t, errors.IsNotFound(errors.Conflict("Duplicate data")))
+	assert.False(t, errors.IsNotFound(nil))
+}
@@ -0,0 +1,247 @@
+package helpers
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"event-service/internal/pkg/log"
+)
+
+// minJwksRefresh bounds the refreshes asked by tokens of an unknown kid, a client sending random kids must not make
+// the service hammer the key server.
+const minJwksRefresh = 30 * time.Second
+
+// minRSAKeyBits is the smallest modulus accepted from the key set, shorter keys can be factored.
+const minRSAKeyBits = 2048
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwksKey struct {
+	alg string
+	key *rsa.PublicKey
+}
+
+// Jwks caches the rsa keys of a json web key set read from a file or an http(s) url. The keys are replaced on every
+// refresh, so a key published ahead of its first use is trusted as soon as it is read and a key removed from the set
+// stops being trusted.
+type Jwks struct {
+	source string
+	client *http.Client
+	logger log.Logger
+
+	mu          sync.RWMutex
+	keys        map[string]jwksKey
+	refreshedAt time.Time
+
+	// refreshMu lets a single caller refresh for an unknown kid, the others wait for it and read its keys
+	refreshMu   sync.Mutex
+	attemptedAt time.Time
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+func NewJwks(source string, log log.Logger) *Jwks {
+	return &Jwks{
+		source: source,
+		client: &http.Client{Timeout: 10 * time.Second},
+		logger: log,
+		keys:   make(map[string]jwksKey),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Refresh reads the key set again. On error the keys read before are kept, an unreachable key server must not reject
+// every token.
+func (k *Jwks) Refresh(ctx context.Context) error {
+	content, err := k.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseJwks(content)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+	k.refreshedAt = time.Now()
+	return nil
+}
+
+func (k *Jwks) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(k.source, "http://") && !strings.HasPrefix(k.source, "https://") {
+		return os.ReadFile(strings.TrimPrefix(k.source, "file://"))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected jwks status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func parseJwks(content []byte) (map[string]jwksKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("invalid jwks: %w", err)
+	}
+
+	keys := make(map[string]jwksKey)
+	for _, jwk := range set.Keys {
+		// keys of another type or use are left to the other consumers of the set
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		if jwk.Kid == "" {
+			return nil, fmt.Errorf("invalid jwks: rsa key without kid")
+		}
+		key, err := parseRSAKey(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwks key '%s': %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = jwksKey{alg: jwk.Alg, key: key}
+	}
+	return keys, nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 2 || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid rsa modulus or exponent")
+	}
+	modulus := new(big.Int).SetBytes(n)
+	if modulus.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("rsa modulus of %d bits, at least %d are required", modulus.BitLen(), minRSAKeyBits)
+	}
+	return &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}, nil
+}
+
+// Key returns the key of kid for a token signed with alg. An unknown kid may be a key published since the last
+// refresh, the set is read again at most once per minJwksRefresh whether the read succeeds or not.
+func (k *Jwks) Key(ctx context.Context, kid string, alg string) (*rsa.PublicKey, error) {
+	key, ok := k.key(kid)
+	if !ok {
+		key, ok = k.refreshFor(ctx, kid)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown jwks key '%s'", kid)
+	}
+	if key.alg != "" && key.alg != alg {
+		return nil, fmt.Errorf("jwks key '%s' is not for %s", kid, alg)
+	}
+	return key.key, nil
+}
+
+func (k *Jwks) key(kid string) (jwksKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// refreshFor reads the set again for an unknown kid. The attempt is recorded before the read, so a key server that
+// is down or slow is not asked again by every token meanwhile.
+func (k *Jwks) refreshFor(ctx context.Context, kid string) (jwksKey, bool) {
+	k.refreshMu.Lock()
+	defer k.refreshMu.Unlock()
+
+	// another caller may have read the set while this one waited
+	if key, ok := k.key(kid); ok {
+		return key, true
+	}
+
+	k.mu.RLock()
+	refreshedAt := k.refreshedAt
+	k.mu.RUnlock()
+	if time.Since(refreshedAt) < minJwksRefresh || time.Since(k.attemptedAt) < minJwksRefresh {
+		return jwksKey{}, false
+	}
+
+	k.attemptedAt = time.Now()
+	if err := k.Refresh(ctx); err != nil {
+		k.logger.Error(ctx, "Failed refresh jwks", err.Error())
+	}
+	return k.key(kid)
+}
+
+// Start refreshes the key set on a fixed interval until Close.
+func (k *Jwks) Start(interval time.Duration) {
+	k.wg.Add(1)
+	go func() {
+		defer k.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-k.stop:
+				return
+			case <-ticker.C:
+				ctx := context.Background()
+				if err := k.Refresh(ctx); err != nil {
+					k.logger.Error(ctx, "Failed refresh jwks", err.Error())
+				}
+			}
+		}
+	}()
+}
+
+func (k *Jwks) Close(ctx context.Context) error {
+	k.once.Do(func() {
+		close(k.stop)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		k.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,346 @@
+package helpers_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	mocklog "event-service/mocks/pkg/log"
+)
+
+type jwksServer struct {
+	mu       sync.Mutex
+	keys     map[string]*rsa.PrivateKey
+	requests int
+	status   int
+	delay    time.Duration
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	time.Sleep(s.delay)
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		return
+	}
+	_, _ = w.Write(jwksDocument(s.keys))
+}
+
+func (s *jwksServer) requestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *jwksServer) setKeys(keys map[string]*rsa.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func jwksDocument(keys map[string]*rsa.PrivateKey) []byte {
+	set := map[string][]map[string]string{"keys": {}}
+	for kid, key := range keys {
+		set["keys"] = append(set["keys"], map[string]string{
+			"kid": kid,
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	content, _ := json.Marshal(set)
+	return content
+}
+
+type JwksTestSuite struct {
+	suite.Suite
+	oldKey     *rsa.PrivateKey
+	newKey     *rsa.PrivateKey
+	server     *jwksServer
+	httpServer *httptest.Server
+	mockLogger *mocklog.Logger
+	jwt        *helpers.JwtImpl
+}
+
+func (suite *JwksTestSuite) SetupSuite() {
+	var err error
+	suite.oldKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.NoError(err)
+	suite.newKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.NoError(err)
+}
+
+func (suite *JwksTestSuite) SetupTest() {
+	suite.server = &jwksServer{keys: map[string]*rsa.PrivateKey{"old": suite.oldKey}}
+	suite.httpServer = httptest.NewServer(suite.server)
+	suite.mockLogger = new(mocklog.Logger)
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	suite.jwt = &helpers.JwtImpl{}
+}
+
+func (suite *JwksTestSuite) TearDownTest() {
+	suite.httpServer.Close()
+	suite.NoError(suite.jwt.InitVerification(helpers.JwtVerifyConfig{}, nil))
+}
+
+func TestJwksTestSuite(t *testing.T) {
+	suite.Run(t, new(JwksTestSuite))
+}
+
+func (suite *JwksTestSuite) initJwks(conf helpers.JwtVerifyConfig) *helpers.Jwks {
+	jwks := helpers.NewJwks(suite.httpServer.URL, suite.mockLogger)
+	suite.NoError(jwks.Refresh(context.Background()))
+	suite.NoError(suite.jwt.InitVerification(conf, jwks))
+	return jwks
+}
+
+func signToken(key *rsa.PrivateKey, method jwt.SigningMethod, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, _ := token.SignedString(key)
+	return signed
+}
+
+func userClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"userId": "userId",
+		"role":   "fan",
+		"iss":    "user-service",
+		"aud":    []string{"event-service", "order-service"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
+	}
+}
+
+func authorize(jwtImpl *helpers.JwtImpl, token string) (*helpers.PayloadJWT, error) {
+	request := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(request)
+	request.Header.Set("Authorization", "Bearer "+token)
+	return jwtImpl.JWTAuthorization(request)
+}
+
+func assertUnauthorized(t assert.TestingT, err error) {
+	errString, ok := err.(*errors.ErrorString)
+	assert.True(t, ok)
+	if ok {
+		assert.Equal(t, http.StatusUnauthorized, errString.Code())
+	}
+}
+
+func (suite *JwksTestSuite) TestJWTAuthorization() {
+	suite.initJwks(helpers.JwtVerifyConfig{Issuer: "user-service", Audience: "event-service"})
+
+	payload, err := authorize(suite.jwt, signToken(suite.oldKey, jwt.SigningMethodRS256, "old", userClaims()))
+
+	suite.NoError(err)
+	suite.Equal("userId", payload.UserId)
+	suite.Equal("fan", payload.Role)
+}
+
+func (suite *JwksTestSuite) TestJWTAuthorizationErrClaims() {
+	suite.initJwks(helpers.JwtVerifyConfig{Issuer: "user-service", Audience: "event-service"})
+
+	otherIssuer := userClaims()
+	otherIssuer["iss"] = "other-service"
+	otherAudience := userClaims()
+	otherAudience["aud"] = "order-service"
+	expired := userClaims()
+	expired["exp"] = time.Now().Add(time.Minute).Unix()
+	notBefore := userClaims()
+	notBefore["nbf"] = time.Now().Add(time.Hour).Unix()
+	noExpiry := userClaims()
+	delete(noExpiry, "exp")
+
+	for name, claims := range map[string]jwt.MapClaims{
+		"other issuer": otherIssuer, "other audience": otherAudience, "expired within the leeway": expired, "not before": notBefore,
+		"no expiry": noExpiry,
+	} {
+		_, err := authorize(suite.jwt, signToken(suite.oldKey, jwt.SigningMethodRS256, "old", claims))
+		suite.Error(err, name)
+		assertUnauthorized(suite.T(), err)
+	}
+}
+
+func (suite *JwksTestSuite) TestJWTAuthorizationErrKey() {
+	suite.initJwks(helpers.JwtVerifyConfig{Issuer: "user-service", Audience: "event-service"})
+
+	for name, token := range map[string]string{
+		"unknown kid":   signToken(suite.newKey, jwt.SigningMethodRS256, "new", userClaims()),
+		"wrong key":     signToken(suite.newKey, jwt.SigningMethodRS256, "old", userClaims()),
+		"no kid":        signToken(suite.oldKey, jwt.SigningMethodRS256, "", userClaims()),
+		"not allowed":   signToken(suite.oldKey, jwt.SigningMethodRS512, "old", userClaims()),
+		"hmac with key": signHmac(suite.oldKey, userClaims()),
+	} {
+		_, err := authorize(suite.jwt, token)
+		suite.Error(err, name)
+		assertUnauthorized(suite.T(), err)
+	}
+}
+
+// signHmac signs with the public key as an hmac secret, the token a verifier trusting the header alg would accept.
+func signHmac(key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = "old"
+	signed, _ := token.SignedString(key.PublicKey.N.Bytes())
+	return signed
+}
+
+func (suite *JwksTestSuite) TestJWTAuthorizationRotation() {
+	jwks := suite.initJwks(helpers.JwtVerifyConfig{Issuer: "user-service", Audience: "event-service"})
+
+	// the new key is published next to the old one, then the old one is removed
+	suite.server.setKeys(map[string]*rsa.PrivateKey{"old": suite.oldKey, "new": suite.newKey})
+	suite.NoError(jwks.Refresh(context.Background()))
+	_, err := authorize(suite.jwt, signToken(suite.newKey, jwt.SigningMethodRS256, "new", userClaims()))
+	suite.NoError(err)
+	_, err = authorize(suite.jwt, signToken(suite.oldKey, jwt.SigningMethodRS256, "old", userClaims()))
+	suite.NoError(err)
+
+	suite.server.setKeys(map[string]*rsa.PrivateKey{"new": suite.newKey})
+	suite.NoError(jwks.Refresh(context.Background()))
+	_, err = authorize(suite.jwt, signToken(suite.oldKey, jwt.SigningMethodRS256, "old", userClaims()))
+	suite.Error(err)
+}
+
+func (suite *JwksTestSuite) TestJwksKeyRefreshOnUnknownKid() {
+	jwks := helpers.NewJwks(suite.httpServer.URL, suite.mockLogger)
+
+	// never read yet, the unknown kid reads the set
+	key, err := jwks.Key(context.Background(), "old", "RS256")
+	suite.NoError(err)
+	suite.Equal(suite.oldKey.N, key.N)
+
+	// read just now, another unknown kid does not read it again
+	_, err = jwks.Key(context.Background(), "new", "RS256")
+	suite.EqualError(err, "unknown jwks key 'new'")
+	suite.Equal(1, suite.server.requests)
+
+	_, err = jwks.Key(context.Background(), "old", "RS384")
+	suite.EqualError(err, "jwks key 'old' is not for RS384")
+}
+
+func (suite *JwksTestSuite) TestJwksKeyErrRefreshRecorded() {
+	suite.server.status = http.StatusInternalServerError
+	jwks := helpers.NewJwks(suite.httpServer.URL, suite.mockLogger)
+
+	// the failed read counts as an attempt, the key server is not asked again by the next unknown kid
+	_, err := jwks.Key(context.Background(), "old", "RS256")
+	suite.Error(err)
+	_, err = jwks.Key(context.Background(), "new", "RS256")
+	suite.Error(err)
+	suite.Equal(1, suite.server.requestCount())
+}
+
+func (suite *JwksTestSuite) TestJwksKeyConcurrentRefresh() {
+	suite.server.delay = 50 * time.Millisecond
+	jwks := helpers.NewJwks(suite.httpServer.URL, suite.mockLogger)
+
+	// the tokens arriving together with an unknown kid wait for a single read of the set
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := jwks.Key(context.Background(), "old", "RS256")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		suite.NoError(err)
+	}
+	suite.Equal(1, suite.server.requestCount())
+}
+
+func (suite *JwksTestSuite) TestJwksStart() {
+	jwks := helpers.NewJwks(suite.httpServer.URL, suite.mockLogger)
+	jwks.Start(10 * time.Millisecond)
+
+	suite.Eventually(func() bool {
+		suite.server.mu.Lock()
+		defer suite.server.mu.Unlock()
+		return suite.server.requests >= 2
+	}, time.Second, 10*time.Millisecond)
+	suite.NoError(jwks.Close(context.Background()))
+}
+
+func (suite *JwksTestSuite) TestJwksRefreshKeepsKeysOnError() {
+	jwks := helpers.NewJwks(suite.httpServer.URL, suite.mockLogger)
+	suite.NoError(jwks.Refresh(context.Background()))
+	suite.httpServer.Close()
+
+	suite.Error(jwks.Refresh(context.Background()))
+	_, err := jwks.Key(context.Background(), "old", "RS256")
+	suite.NoError(err)
+}
+
+func (suite *JwksTestSuite) TestJwksFile() {
+	path := filepath.Join(suite.T().TempDir(), "jwks.json")
+	suite.NoError(os.WriteFile(path, jwksDocument(map[string]*rsa.PrivateKey{"new": suite.newKey}), 0o600))
+
+	jwks := helpers.NewJwks("file://"+path, suite.mockLogger)
+	suite.NoError(jwks.Refresh(context.Background()))
+	_, err := jwks.Key(context.Background(), "new", "RS256")
+	suite.NoError(err)
+
+	suite.Error(helpers.NewJwks(filepath.Join(suite.T().TempDir(), "missing.json"), suite.mockLogger).Refresh(context.Background()))
+}
+
+func (suite *JwksTestSuite) TestJwksErrInvalid() {
+	path := filepath.Join(suite.T().TempDir(), "jwks.json")
+	suite.NoError(os.WriteFile(path, []byte(`{"keys": [{"kty": "RSA", "n": "AQAB", "e": "AQAB"}]}`), 0o600))
+
+	err := helpers.NewJwks(path, suite.mockLogger).Refresh(context.Background())
+	suite.EqualError(err, "invalid jwks: rsa key without kid")
+}
+
+func (suite *JwksTestSuite) TestJwksErrWeakKey() {
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	suite.NoError(err)
+	path := filepath.Join(suite.T().TempDir(), "jwks.json")
+	suite.NoError(os.WriteFile(path, jwksDocument(map[string]*rsa.PrivateKey{"weak": weakKey}), 0o600))
+
+	err = helpers.NewJwks(path, suite.mockLogger).Refresh(context.Background())
+	suite.EqualError(err, "invalid jwks key 'weak': rsa modulus of 1024 bits, at least 2048 are required")
+}
+
+func (suite *JwksTestSuite) TestInitVerificationErrClaims() {
+	jwks := helpers.NewJwks(suite.httpServer.URL, suite.mockLogger)
+
+	for name, conf := range map[string]helpers.JwtVerifyConfig{
+		"no issuer and audience": {}, "no issuer": {Audience: "event-service"}, "no audience": {Issuer: "user-service"},
+	} {
+		err := suite.jwt.InitVerification(conf, jwks)
+		suite.EqualError(err, "jwt issuer and audience are required to verify with a jwks", name)
+	}
+}
+
+func (suite *JwksTestSuite) TestInitVerificationErrAlgorithm() {
+	err := suite.jwt.InitVerification(helpers.JwtVerifyConfig{Algorithms: []string{"HS256"}}, nil)
+	suite.EqualError(err, "jwt algorithm 'HS256' is not an rsa algorithm")
+}
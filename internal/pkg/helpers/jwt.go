@@ -1,9 +1,11 @@
 package helpers
 
 import (
+	"context"
 	"crypto/rsa"
 	"encoding/base64"
 	"event-service/internal/pkg/errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -17,10 +19,20 @@ var (
 	signKey      *rsa.PrivateKey
 	verifyKeyRef *rsa.PublicKey
 	signKeyRef   *rsa.PrivateKey
+	verifyJwks   *Jwks
+	verifyConf   = JwtVerifyConfig{Algorithms: []string{jwt.SigningMethodRS256.Alg()}}
 )
 
 type JwtImpl struct{}
 
+// JwtVerifyConfig are the checks on the access tokens on top of their signature. An empty issuer or audience is not
+// checked.
+type JwtVerifyConfig struct {
+	Issuer     string
+	Audience   string
+	Algorithms []string
+}
+
 type ConfigInitializer interface {
 	InitConfig(privateKeyConf string, publicKeyConf string, privateKeyRefConf string, publicKeyRefConf string)
 }
@@ -86,6 +98,30 @@ func (j *JwtImpl) InitConfig(privateKeyConf string, publicKeyConf string, privat
 	}
 }
 
+// InitVerification must run after InitConfig. A nil keySet keeps verifying the access tokens with the public key of
+// InitConfig, otherwise they are verified with the key of keySet named by their kid and must carry the issuer and
+// audience of conf, a shared key set also signs the tokens of other services. Only rsa algorithms are allowed,
+// RS256 when none is given.
+func (j *JwtImpl) InitVerification(conf JwtVerifyConfig, keySet *Jwks) error {
+	if keySet != nil && (conf.Issuer == "" || conf.Audience == "") {
+		return fmt.Errorf("jwt issuer and audience are required to verify with a jwks")
+	}
+	if len(conf.Algorithms) == 0 {
+		conf.Algorithms = []string{jwt.SigningMethodRS256.Alg()}
+	}
+	for _, alg := range conf.Algorithms {
+		switch jwt.GetSigningMethod(alg).(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		default:
+			return fmt.Errorf("jwt algorithm '%s' is not an rsa algorithm", alg)
+		}
+	}
+
+	verifyConf = conf
+	verifyJwks = keySet
+	return nil
+}
+
 type PayloadJWT struct {
 	UserId string `json:"userId"`
 	Token  string `json:"token"`
@@ -96,16 +132,24 @@ const leeway = -120
 
 type MyClaims struct {
 	PayloadJWT
-	*jwt.StandardClaims
+	jwt.RegisteredClaims
 }
 
-func (c *MyClaims) Validate() error {
-	c.StandardClaims.ExpiresAt += leeway
-	c.StandardClaims.IssuedAt += leeway
-	err := c.StandardClaims.Valid()
-	c.StandardClaims.ExpiresAt -= leeway
-	c.StandardClaims.IssuedAt -= leeway
-	return err
+// Validate checks the time claims with the leeway, a token without exp is rejected. Then it checks the issuer and the
+// audience when they are given.
+func (c *MyClaims) Validate(issuer string, audience string) error {
+	now := time.Now()
+	if !c.VerifyExpiresAt(now.Add(-leeway*time.Second), true) || !c.VerifyIssuedAt(now.Add(-leeway*time.Second), false) ||
+		!c.VerifyNotBefore(now, false) {
+		return errors.UnauthorizedError("Invalid token")
+	}
+	if issuer != "" && !c.VerifyIssuer(issuer, true) {
+		return errors.UnauthorizedError("Invalid token issuer")
+	}
+	if audience != "" && !c.VerifyAudience(audience, true) {
+		return errors.UnauthorizedError("Invalid token audience")
+	}
+	return nil
 }
 
 func (j *JwtImpl) JWTAuthorization(request *fasthttp.Request) (*PayloadJWT, error) {
@@ -120,8 +164,14 @@ func (j *JwtImpl) JWTAuthorization(request *fasthttp.Request) (*PayloadJWT, erro
 
 	var parsedTokenClaims = new(MyClaims)
 
-	parsedToken, err := jwt.ParseWithClaims(authToken, parsedTokenClaims, func(authToken *jwt.Token) (interface{}, error) {
-		return verifyKey, nil
+	// the claims are validated below, with the leeway
+	parser := jwt.NewParser(jwt.WithValidMethods(verifyConf.Algorithms), jwt.WithoutClaimsValidation())
+	parsedToken, err := parser.ParseWithClaims(authToken, parsedTokenClaims, func(authToken *jwt.Token) (interface{}, error) {
+		if verifyJwks == nil {
+			return verifyKey, nil
+		}
+		kid, _ := authToken.Header["kid"].(string)
+		return verifyJwks.Key(context.Background(), kid, authToken.Method.Alg())
 	})
 
 	if err != nil {
@@ -138,6 +188,10 @@ func (j *JwtImpl) JWTAuthorization(request *fasthttp.Request) (*PayloadJWT, erro
 		return nil, errors.UnauthorizedError("Invalid token")
 	}
 
+	if err := parsedTokenClaims.Validate(verifyConf.Issuer, verifyConf.Audience); err != nil {
+		return nil, err
+	}
+
 	claim := parsedTokenClaims.PayloadJWT
 
 	if claim.UserId == "" {
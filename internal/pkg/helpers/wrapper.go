@@ -14,10 +14,11 @@ import (
 
 // Result common output
 type Result struct {
-	Data     interface{}
-	MetaData interface{}
-	Error    error
-	Count    int64
+	Data       interface{}
+	MetaData   interface{}
+	Error      error
+	Count      int64
+	NextCursor string
 }
 
 type response struct {
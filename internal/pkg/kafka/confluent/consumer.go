@@ -3,63 +3,137 @@ package kafka
 import (
 	"context"
 	"fmt"
-	"strings"
+	"sync"
+	"time"
 
 	"event-service/internal/pkg/log"
 
 	"gopkg.in/confluentinc/confluent-kafka-go.v1/kafka"
 )
 
+const pollTimeout = 100 * time.Millisecond
+
 type consumer struct {
-	handler  ConsumerHandler
+	router   *Router
 	consumer *kafka.Consumer
 	logger   log.Logger
+
+	// ctx is handed to the handlers and cancelled by Close, so a handler in flight stops with the consumer
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
-// NewConsumer is a constructor of kafka consumer
-func NewConsumer(cfg *kafka.ConfigMap, log log.Logger) (Consumer, error) {
+// NewConsumer is a constructor of kafka consumer. The config must disable auto commit, offsets are
+// committed by the consumer once the router has handled a message.
+func NewConsumer(cfg *kafka.ConfigMap, router *Router, log log.Logger) (Consumer, error) {
 	c, err := kafka.NewConsumer(cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
 	return &consumer{
-		logger:   log,
+		router:   router,
 		consumer: c,
+		logger:   log,
+		ctx:      ctx,
+		cancel:   cancel,
 	}, nil
 }
 
-func (c *consumer) SetHandler(handler ConsumerHandler) {
-	c.handler = handler
-}
+func (c *consumer) Start() error {
+	topics := c.router.Topics()
+	if len(topics) == 0 {
+		return fmt.Errorf("kafka consumer has no handler registered")
+	}
 
-func (c *consumer) Subscribe(topics ...string) {
-	if c.handler == nil {
-		joinTopic := strings.Join(topics, ", ")
-		msg := fmt.Sprintf("Kafka Consumer Error: Topics: [%s] There is no consumer handlers to handle message from incoming event", joinTopic)
-		c.logger.Error(context.Background(), msg, fmt.Sprintf("%+v", topics))
-		return
+	if err := c.consumer.SubscribeTopics(topics, nil); err != nil {
+		return err
 	}
 
-	c.consumer.SubscribeTopics(topics, nil)
-	go func() {
-		for {
-			msg, err := c.consumer.ReadMessage(-1)
-			if err != nil {
-				msg := fmt.Sprintf("Kafka Consumer Error: %v (%v)\n", err, msg)
-				c.logger.Error(context.Background(), msg, fmt.Sprintf("%+v", topics))
+	c.wg.Add(1)
+	go c.run()
+
+	return nil
+}
+
+func (c *consumer) run() {
+	defer c.wg.Done()
+	ctx := c.ctx
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		msg, err := c.consumer.ReadMessage(pollTimeout)
+		if err != nil {
+			if kafkaErr, ok := err.(kafka.Error); ok && kafkaErr.Code() == kafka.ErrTimedOut {
 				continue
 			}
-			switch topics[0] {
-			default:
-				c.consumer.CommitMessage(msg)
+			c.logger.Error(ctx, fmt.Sprintf("Kafka Consumer Error: %v", err), "")
+			continue
+		}
+
+		if err := c.router.Dispatch(ctx, toConsumedMessage(msg)); err != nil {
+			// neither the handler nor the dead letter topic took the message, read it again
+			c.logger.Error(ctx, fmt.Sprintf("Kafka Consumer Error: message not handled, topic %s offset %v", *msg.TopicPartition.Topic,
+				msg.TopicPartition.Offset), err.Error())
+			if err := c.consumer.Seek(msg.TopicPartition, 0); err != nil {
+				c.logger.Error(ctx, "Kafka Consumer Error: cannot seek back", err.Error())
+			}
+			select {
+			case <-time.After(c.router.config.RetryBackoff):
+			case <-ctx.Done():
 			}
+			continue
 		}
-	}()
 
-	return
+		if _, err := c.consumer.CommitMessage(msg); err != nil {
+			c.logger.Error(ctx, "Kafka Consumer Error: cannot commit message", err.Error())
+		}
+	}
 }
 
 func (c *consumer) Close(ctx context.Context) error {
+	c.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
 	return c.consumer.Close()
 }
+
+func toConsumedMessage(msg *kafka.Message) ConsumedMessage {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+
+	topic := ""
+	if msg.TopicPartition.Topic != nil {
+		topic = *msg.TopicPartition.Topic
+	}
+
+	return ConsumedMessage{
+		Topic:     topic,
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   headers,
+		Timestamp: msg.Timestamp,
+	}
+}
@@ -9,24 +9,27 @@ import (
 // Producer is collection of function of kafka producer
 type Producer interface {
 	Publish(topic string, message []byte, kafkaPartition *int32)
+	PublishSync(ctx context.Context, topic string, key, value []byte, headers map[string]string) error
+	PublishBatchSync(ctx context.Context, messages []Message) []error
 
 	Close(ctx context.Context) error
 }
 
-// Consumer is collection of function of kafka consumer
+// Message is an outbound kafka message, messages sharing a Key land on the same partition
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// Consumer reads the topics registered on its Router until it is closed
 type Consumer interface {
-	SetHandler(handler ConsumerHandler)
-	Subscribe(topics ...string)
+	Start() error
 
 	Close(ctx context.Context) error
 }
 
-// ConsumerHandler is a collection of function for handling kafka message
-type ConsumerHandler interface {
-	SendEmailOtpRegister(message *k.Message, topic string)
-	SendEmailOtpLogin(message *k.Message, topic string)
-}
-
 ///
 
 type KafkaConfig struct {
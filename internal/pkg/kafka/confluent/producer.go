@@ -61,6 +61,77 @@ func (p *producer) Publish(topic string, message []byte, kafkaPartition *int32)
 	}
 }
 
+// PublishSync produces the message and blocks until the broker delivery report arrives or ctx is done.
+func (p *producer) PublishSync(ctx context.Context, topic string, key, value []byte, headers map[string]string) error {
+	return p.PublishBatchSync(ctx, []Message{{
+		Topic:   topic,
+		Key:     key,
+		Value:   value,
+		Headers: headers,
+	}})[0]
+}
+
+// PublishBatchSync produces all messages and waits for their delivery reports. The returned slice has one
+// entry per message, nil when that message was written.
+func (p *producer) PublishBatchSync(ctx context.Context, messages []Message) []error {
+	errs := make([]error, len(messages))
+	deliveryCh := make(chan kafka.Event, len(messages))
+
+	pending := 0
+	for i, message := range messages {
+		topic := message.Topic
+		err := p.producer.Produce(&kafka.Message{
+			TopicPartition: kafka.TopicPartition{
+				Topic:     &topic,
+				Partition: kafka.PartitionAny,
+			},
+			Key:     message.Key,
+			Value:   message.Value,
+			Headers: toKafkaHeaders(message.Headers),
+			Opaque:  i,
+		}, deliveryCh)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		pending++
+	}
+
+	delivered := make([]bool, len(messages))
+	for ; pending > 0; pending-- {
+		select {
+		case e := <-deliveryCh:
+			m, ok := e.(*kafka.Message)
+			if !ok {
+				continue
+			}
+			i, ok := m.Opaque.(int)
+			if !ok {
+				continue
+			}
+			delivered[i] = true
+			errs[i] = m.TopicPartition.Error
+		case <-ctx.Done():
+			for i := range messages {
+				if !delivered[i] && errs[i] == nil {
+					errs[i] = ctx.Err()
+				}
+			}
+			return errs
+		}
+	}
+
+	return errs
+}
+
+func toKafkaHeaders(headers map[string]string) []kafka.Header {
+	kafkaHeaders := make([]kafka.Header, 0, len(headers))
+	for k, v := range headers {
+		kafkaHeaders = append(kafkaHeaders, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return kafkaHeaders
+}
+
 func (p *producer) Close(ctx context.Context) error {
 	p.producer.Close()
 	return nil
@@ -0,0 +1,146 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"event-service/internal/pkg/log"
+)
+
+// ConsumedMessage is a kafka message handed to a topic handler
+type ConsumedMessage struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Timestamp time.Time
+}
+
+// HandlerFunc handles one message, returning an error makes the router retry it unless it is Permanent
+type HandlerFunc func(ctx context.Context, message ConsumedMessage) error
+
+type permanentError struct {
+	err error
+}
+
+func (p permanentError) Error() string {
+	return p.err.Error()
+}
+
+func (p permanentError) Unwrap() error {
+	return p.err
+}
+
+// Permanent marks an error that fails the same way on every attempt, like a message that cannot be parsed or
+// validated. The router moves the message to the dead letter topic without retrying it.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
+
+type RouterConfig struct {
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// DeadLetterSuffix is appended to the topic name to build its dead letter topic
+	DeadLetterSuffix string
+}
+
+// Router keeps the handler of every consumed topic. A message is retried MaxRetries times, then it is
+// moved to the dead letter topic so the partition is not blocked. A Permanent error moves it there at once.
+type Router struct {
+	config     RouterConfig
+	handlers   map[string]HandlerFunc
+	deadLetter Producer
+	logger     log.Logger
+}
+
+func NewRouter(cfg RouterConfig, deadLetter Producer, log log.Logger) *Router {
+	if cfg.DeadLetterSuffix == "" {
+		cfg.DeadLetterSuffix = ".dlq"
+	}
+
+	return &Router{
+		config:     cfg,
+		handlers:   make(map[string]HandlerFunc),
+		deadLetter: deadLetter,
+		logger:     log,
+	}
+}
+
+func (r *Router) Handle(topic string, handler HandlerFunc) {
+	r.handlers[topic] = handler
+}
+
+func (r *Router) Topics() []string {
+	topics := make([]string, 0, len(r.handlers))
+	for topic := range r.handlers {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Dispatch runs the topic handler. It returns an error only when the message could be neither handled
+// nor moved to the dead letter topic, in which case the offset must not be committed.
+func (r *Router) Dispatch(ctx context.Context, message ConsumedMessage) error {
+	handler, ok := r.handlers[message.Topic]
+	if !ok {
+		r.logger.Error(ctx, fmt.Sprintf("Kafka Consumer Error: no handler for topic %s", message.Topic), "")
+		return nil
+	}
+
+	var err error
+	for attempt := 0; attempt <= r.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(r.config.RetryBackoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err = handler(ctx, message); err == nil {
+			return nil
+		}
+		r.logger.Error(ctx, fmt.Sprintf("Kafka Consumer Error: handler failed, topic %s attempt %d", message.Topic, attempt+1), err.Error())
+		if IsPermanent(err) {
+			break
+		}
+	}
+
+	// a handler stopped by the shutdown did not fail, the message is read again after the restart
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return r.sendToDeadLetter(ctx, message, err)
+}
+
+func (r *Router) sendToDeadLetter(ctx context.Context, message ConsumedMessage, cause error) error {
+	headers := make(map[string]string, len(message.Headers)+4)
+	for k, v := range message.Headers {
+		headers[k] = v
+	}
+	headers["dlq-original-topic"] = message.Topic
+	headers["dlq-original-partition"] = strconv.Itoa(int(message.Partition))
+	headers["dlq-original-offset"] = strconv.FormatInt(message.Offset, 10)
+	headers["dlq-error"] = cause.Error()
+
+	topic := message.Topic + r.config.DeadLetterSuffix
+	if err := r.deadLetter.PublishSync(ctx, topic, message.Key, message.Value, headers); err != nil {
+		return err
+	}
+
+	r.logger.Error(ctx, fmt.Sprintf("Kafka Consumer: message moved to %s", topic), cause.Error())
+	return nil
+}
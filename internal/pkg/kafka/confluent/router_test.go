@@ -0,0 +1,155 @@
+package kafka_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kafkaConfluent "event-service/internal/pkg/kafka/confluent"
+	mockkafka "event-service/mocks/pkg/kafka"
+	mocklog "event-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RouterTestSuite struct {
+	suite.Suite
+	mockProducer *mockkafka.Producer
+	mockLogger   *mocklog.Logger
+	router       *kafkaConfluent.Router
+	ctx          context.Context
+}
+
+func (suite *RouterTestSuite) SetupTest() {
+	suite.mockProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	suite.router = kafkaConfluent.NewRouter(kafkaConfluent.RouterConfig{
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	}, suite.mockProducer, suite.mockLogger)
+	suite.ctx = context.Background()
+}
+
+func TestRouterTestSuite(t *testing.T) {
+	suite.Run(t, new(RouterTestSuite))
+}
+
+func (suite *RouterTestSuite) TestTopics() {
+	suite.router.Handle("b", func(ctx context.Context, message kafkaConfluent.ConsumedMessage) error { return nil })
+	suite.router.Handle("a", func(ctx context.Context, message kafkaConfluent.ConsumedMessage) error { return nil })
+
+	assert.Equal(suite.T(), []string{"a", "b"}, suite.router.Topics())
+}
+
+func (suite *RouterTestSuite) TestDispatch() {
+	var received kafkaConfluent.ConsumedMessage
+	suite.router.Handle("topic", func(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+		received = message
+		return nil
+	})
+
+	err := suite.router.Dispatch(suite.ctx, kafkaConfluent.ConsumedMessage{Topic: "topic", Value: []byte("value")})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []byte("value"), received.Value)
+}
+
+func (suite *RouterTestSuite) TestDispatchRetry() {
+	calls := 0
+	suite.router.Handle("topic", func(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+		calls++
+		if calls < 3 {
+			return errors.New("error")
+		}
+		return nil
+	})
+
+	err := suite.router.Dispatch(suite.ctx, kafkaConfluent.ConsumedMessage{Topic: "topic"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, calls)
+	suite.mockProducer.AssertNotCalled(suite.T(), "PublishSync", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RouterTestSuite) TestDispatchDeadLetter() {
+	calls := 0
+	suite.router.Handle("topic", func(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+		calls++
+		return errors.New("error")
+	})
+	suite.mockProducer.On("PublishSync", mock.Anything, "topic.dlq", []byte("key"), []byte("value"), mock.MatchedBy(func(headers map[string]string) bool {
+		return headers["dlq-original-topic"] == "topic" && headers["dlq-original-offset"] == "7" && headers["dlq-error"] == "error" &&
+			headers["trace"] == "id"
+	})).Return(nil)
+
+	err := suite.router.Dispatch(suite.ctx, kafkaConfluent.ConsumedMessage{
+		Topic:   "topic",
+		Offset:  7,
+		Key:     []byte("key"),
+		Value:   []byte("value"),
+		Headers: map[string]string{"trace": "id"},
+	})
+	assert.NoError(suite.T(), err)
+	// first attempt plus two retries
+	assert.Equal(suite.T(), 3, calls)
+	suite.mockProducer.AssertNumberOfCalls(suite.T(), "PublishSync", 1)
+}
+
+func (suite *RouterTestSuite) TestDispatchPermanentDeadLetter() {
+	calls := 0
+	suite.router.Handle("topic", func(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+		calls++
+		return kafkaConfluent.Permanent(errors.New("bad request"))
+	})
+	suite.mockProducer.On("PublishSync", mock.Anything, "topic.dlq", mock.Anything, mock.Anything, mock.MatchedBy(func(headers map[string]string) bool {
+		return headers["dlq-error"] == "bad request"
+	})).Return(nil)
+
+	err := suite.router.Dispatch(suite.ctx, kafkaConfluent.ConsumedMessage{Topic: "topic"})
+	assert.NoError(suite.T(), err)
+	// a message that cannot be parsed fails the same way on a retry
+	assert.Equal(suite.T(), 1, calls)
+	suite.mockProducer.AssertNumberOfCalls(suite.T(), "PublishSync", 1)
+}
+
+func (suite *RouterTestSuite) TestDispatchErrDeadLetter() {
+	suite.router.Handle("topic", func(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+		return errors.New("error")
+	})
+	suite.mockProducer.On("PublishSync", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("broker down"))
+
+	err := suite.router.Dispatch(suite.ctx, kafkaConfluent.ConsumedMessage{Topic: "topic"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RouterTestSuite) TestDispatchErrCancelled() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	suite.router.Handle("topic", func(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+		cancel()
+		return ctx.Err()
+	})
+
+	// a shutdown is not a failure of the message, it is not moved to the dead letter topic
+	err := suite.router.Dispatch(ctx, kafkaConfluent.ConsumedMessage{Topic: "topic"})
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	suite.mockProducer.AssertNotCalled(suite.T(), "PublishSync", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RouterTestSuite) TestDispatchUnknownTopic() {
+	err := suite.router.Dispatch(suite.ctx, kafkaConfluent.ConsumedMessage{Topic: "unknown"})
+	assert.NoError(suite.T(), err)
+	suite.mockLogger.AssertCalled(suite.T(), "Error", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RouterTestSuite) TestDispatchCustomDeadLetterSuffix() {
+	router := kafkaConfluent.NewRouter(kafkaConfluent.RouterConfig{DeadLetterSuffix: "-dead"}, suite.mockProducer, suite.mockLogger)
+	router.Handle("topic", func(ctx context.Context, message kafkaConfluent.ConsumedMessage) error {
+		return errors.New("error")
+	})
+	suite.mockProducer.On("PublishSync", mock.Anything, "topic-dead", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err := router.Dispatch(suite.ctx, kafkaConfluent.ConsumedMessage{Topic: "topic"})
+	assert.NoError(suite.T(), err)
+}
@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/log"
+)
+
+const collectionName = "schema_migrations"
+
+// Migration is one versioned change of the database. Up must be idempotent, replicas starting together may run it
+// more than once before the version is recorded.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db mongodb.Collections) error
+}
+
+// AppliedMigration is the record kept in schema_migrations for every migration that ran.
+type AppliedMigration struct {
+	Version     int       `json:"version" bson:"version"`
+	Description string    `json:"description" bson:"description"`
+	AppliedAt   time.Time `json:"appliedAt" bson:"appliedAt"`
+}
+
+type Runner struct {
+	db         mongodb.Collections
+	logger     log.Logger
+	migrations []Migration
+}
+
+// NewRunner runs migrations against db in the order of their version.
+func NewRunner(db mongodb.Collections, log log.Logger, migrations ...Migration) *Runner {
+	sorted := append([]Migration{}, migrations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	return &Runner{
+		db:         db,
+		logger:     log,
+		migrations: sorted,
+	}
+}
+
+// Pending returns the migrations that were not applied yet.
+func (r *Runner) Pending(ctx context.Context) ([]Migration, error) {
+	var applied []AppliedMigration
+	resp := <-r.db.FindMany(mongodb.FindMany{
+		Result:         &applied,
+		CollectionName: collectionName,
+		Filter:         bson.M{},
+	}, ctx)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	versions := make(map[int]bool, len(applied))
+	for _, migration := range applied {
+		versions[migration.Version] = true
+	}
+
+	pending := make([]Migration, 0, len(r.migrations))
+	for _, migration := range r.migrations {
+		if !versions[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Run applies the pending migrations and records each of them, it stops at the first failing one so the later
+// migrations never run on a partially migrated database.
+func (r *Runner) Run(ctx context.Context) error {
+	resp := <-r.db.CreateIndexes(mongodb.CreateIndexes{
+		CollectionName: collectionName,
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "version", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+	}, ctx)
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	pending, err := r.Pending(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range pending {
+		r.logger.Info(ctx, fmt.Sprintf("Running migration %d: %s", migration.Version, migration.Description), migration.Version)
+		if err := migration.Up(ctx, r.db); err != nil {
+			r.logger.Error(ctx, fmt.Sprintf("Error migration %d: %s", migration.Version, err.Error()), migration.Version)
+			return err
+		}
+
+		resp := <-r.db.InsertOne(mongodb.InsertOne{
+			CollectionName: collectionName,
+			Document: AppliedMigration{
+				Version:     migration.Version,
+				Description: migration.Description,
+				AppliedAt:   time.Now(),
+			},
+		}, ctx)
+		// another replica recorded it first
+		if resp.Error != nil && !errors.IsConflict(resp.Error) {
+			return resp.Error
+		}
+	}
+	return nil
+}
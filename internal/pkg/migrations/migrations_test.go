@@ -0,0 +1,311 @@
+package migrations_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"event-service/internal/pkg/databases/mongodb"
+	"event-service/internal/pkg/errors"
+	"event-service/internal/pkg/helpers"
+	"event-service/internal/pkg/migrations"
+	mocks "event-service/mocks/pkg/databases/mongodb"
+	mocklog "event-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type MigrationsTestSuite struct {
+	suite.Suite
+	mockMongodb *mocks.Collections
+	mockLogger  *mocklog.Logger
+	ctx         context.Context
+	ran         []int
+}
+
+func (suite *MigrationsTestSuite) SetupTest() {
+	suite.mockMongodb = new(mocks.Collections)
+	suite.mockLogger = new(mocklog.Logger)
+	suite.ctx = context.Background()
+	suite.ran = nil
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Maybe()
+}
+
+func TestMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result, 1)
+	responseChan <- result
+	close(responseChan)
+	return responseChan
+}
+
+func (suite *MigrationsTestSuite) migration(version int, err error) migrations.Migration {
+	return migrations.Migration{
+		Version:     version,
+		Description: "migration",
+		Up: func(ctx context.Context, db mongodb.Collections) error {
+			suite.ran = append(suite.ran, version)
+			return err
+		},
+	}
+}
+
+func (suite *MigrationsTestSuite) mockApplied(versions ...int) {
+	suite.mockMongodb.On("FindMany", mock.MatchedBy(func(payload mongodb.FindMany) bool {
+		return payload.CollectionName == "schema_migrations"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		result := args.Get(0).(mongodb.FindMany).Result.(*[]migrations.AppliedMigration)
+		for _, version := range versions {
+			*result = append(*result, migrations.AppliedMigration{Version: version})
+		}
+	}).Return(func(payload mongodb.FindMany, ctx context.Context) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: payload.Result})
+	})
+}
+
+func (suite *MigrationsTestSuite) TestRun() {
+	suite.mockMongodb.On("CreateIndexes", mock.MatchedBy(func(payload mongodb.CreateIndexes) bool {
+		return payload.CollectionName == "schema_migrations" && *payload.Indexes[0].Options.Unique
+	}), mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockApplied(1)
+	suite.mockMongodb.On("InsertOne", mock.MatchedBy(func(payload mongodb.InsertOne) bool {
+		return payload.CollectionName == "schema_migrations"
+	}), mock.Anything).Return(func(payload mongodb.InsertOne, ctx context.Context) <-chan helpers.Result {
+		return mockChannel(helpers.Result{})
+	})
+
+	runner := migrations.NewRunner(suite.mockMongodb, suite.mockLogger,
+		suite.migration(3, nil), suite.migration(1, nil), suite.migration(2, nil))
+	err := runner.Run(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{2, 3}, suite.ran)
+	suite.mockMongodb.AssertNumberOfCalls(suite.T(), "InsertOne", 2)
+}
+
+func (suite *MigrationsTestSuite) TestRunStopsOnError() {
+	suite.mockMongodb.On("CreateIndexes", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockApplied()
+	suite.mockMongodb.On("InsertOne", mock.Anything, mock.Anything).Return(func(payload mongodb.InsertOne, ctx context.Context) <-chan helpers.Result {
+		return mockChannel(helpers.Result{})
+	})
+
+	runner := migrations.NewRunner(suite.mockMongodb, suite.mockLogger,
+		suite.migration(1, nil), suite.migration(2, errors.InternalServerError("Error create index")), suite.migration(3, nil))
+	err := runner.Run(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), []int{1, 2}, suite.ran)
+	suite.mockMongodb.AssertNumberOfCalls(suite.T(), "InsertOne", 1)
+}
+
+func (suite *MigrationsTestSuite) TestRunAlreadyRecorded() {
+	suite.mockMongodb.On("CreateIndexes", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockApplied()
+	suite.mockMongodb.On("InsertOne", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.Conflict("Duplicate data")}))
+
+	runner := migrations.NewRunner(suite.mockMongodb, suite.mockLogger, suite.migration(1, nil))
+	err := runner.Run(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *MigrationsTestSuite) TestRunErrRecord() {
+	suite.mockMongodb.On("CreateIndexes", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+	suite.mockApplied()
+	suite.mockMongodb.On("InsertOne", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error mongodb connection")}))
+
+	runner := migrations.NewRunner(suite.mockMongodb, suite.mockLogger, suite.migration(1, nil), suite.migration(2, nil))
+	err := runner.Run(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), []int{1}, suite.ran)
+}
+
+func (suite *MigrationsTestSuite) TestRunErrIndex() {
+	suite.mockMongodb.On("CreateIndexes", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error create index")}))
+
+	runner := migrations.NewRunner(suite.mockMongodb, suite.mockLogger, suite.migration(1, nil))
+	err := runner.Run(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	assert.Empty(suite.T(), suite.ran)
+}
+
+func (suite *MigrationsTestSuite) TestPending() {
+	suite.mockApplied(1, 2)
+
+	runner := migrations.NewRunner(suite.mockMongodb, suite.mockLogger,
+		suite.migration(1, nil), suite.migration(2, nil), suite.migration(3, nil))
+	pending, err := runner.Pending(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), pending, 1)
+	assert.Equal(suite.T(), 3, pending[0].Version)
+}
+
+func (suite *MigrationsTestSuite) TestAllVersionsUnique() {
+	versions := map[int]bool{}
+	for _, migration := range migrations.All() {
+		assert.False(suite.T(), versions[migration.Version], "duplicate version %d", migration.Version)
+		assert.NotNil(suite.T(), migration.Up)
+		versions[migration.Version] = true
+	}
+}
+
+func (suite *MigrationsTestSuite) TestEventIndexes() {
+	suite.mockDuplicates("event")
+	suite.mockMongodb.On("CreateIndexes", mock.MatchedBy(func(payload mongodb.CreateIndexes) bool {
+		if payload.CollectionName != "event" {
+			return false
+		}
+		for _, index := range payload.Indexes {
+			keys := index.Keys.(bson.D)
+			if keys[0].Key == "name" && keys[0].Value == 1 {
+				return *index.Options.Unique
+			}
+		}
+		return false
+	}), mock.Anything).Return(mockChannel(helpers.Result{}))
+
+	err := migrations.All()[0].Up(suite.ctx, suite.mockMongodb)
+
+	assert.NoError(suite.T(), err)
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *MigrationsTestSuite) TestEventIndexesErrDuplicates() {
+	suite.mockDuplicates("event", bson.D{{Key: "name", Value: "Rock"}})
+
+	err := migrations.All()[0].Up(suite.ctx, suite.mockMongodb)
+
+	assert.EqualError(suite.T(), err, "event has duplicate name, resolve them before the unique index is built: "+
+		"name=Rock (2 documents)")
+	suite.mockMongodb.AssertNotCalled(suite.T(), "CreateIndexes", mock.Anything, mock.Anything)
+}
+
+func (suite *MigrationsTestSuite) mockPublishedEvents(cursor string, nextCursor string, eventIds ...string) {
+	suite.mockMongodb.On("FindAllData", mock.MatchedBy(func(payload mongodb.FindAllData) bool {
+		return payload.CollectionName == "event" && payload.Keyset && payload.Cursor == cursor
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		result := reflect.ValueOf(args.Get(0).(mongodb.FindAllData).Result).Elem()
+		for _, eventId := range eventIds {
+			item := reflect.New(result.Type().Elem()).Elem()
+			item.Field(0).SetString(eventId)
+			result.Set(reflect.Append(result, item))
+		}
+	}).Return(func(payload mongodb.FindAllData, ctx context.Context) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: payload.Result, NextCursor: nextCursor})
+	}).Once()
+}
+
+func (suite *MigrationsTestSuite) mockSellable(eventIds ...string) {
+	suite.mockMongodb.On("UpdateMany", mock.MatchedBy(func(payload mongodb.UpdateMany) bool {
+		filter := payload.Filter.(bson.M)
+		published, ok := filter["eventId"].(bson.M)
+		return ok && reflect.DeepEqual(published["$in"], eventIds) && payload.Document.(bson.M)["isSellable"] == true
+	}), mock.Anything).Return(mockChannel(helpers.Result{})).Once()
+}
+
+func (suite *MigrationsTestSuite) TestBackfillTicketSellable() {
+	// the published events are read a batch at a time, each batch opens the sales of its tickets
+	suite.mockPublishedEvents("", "next", "id1", "id2")
+	suite.mockPublishedEvents("next", "", "id3")
+	suite.mockSellable("id1", "id2")
+	suite.mockSellable("id3")
+	suite.mockMongodb.On("UpdateMany", mock.MatchedBy(func(payload mongodb.UpdateMany) bool {
+		filter := payload.Filter.(bson.M)
+		_, published := filter["eventId"]
+		return !published && payload.Document.(bson.M)["isSellable"] == false
+	}), mock.Anything).Return(mockChannel(helpers.Result{})).Once()
+
+	err := migrations.All()[4].Up(suite.ctx, suite.mockMongodb)
+
+	assert.NoError(suite.T(), err)
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *MigrationsTestSuite) TestBackfillTicketSellableNoPublished() {
+	suite.mockPublishedEvents("", "")
+	suite.mockMongodb.On("UpdateMany", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{})).Once()
+
+	err := migrations.All()[4].Up(suite.ctx, suite.mockMongodb)
+
+	assert.NoError(suite.T(), err)
+	suite.mockMongodb.AssertNumberOfCalls(suite.T(), "UpdateMany", 1)
+}
+
+func (suite *MigrationsTestSuite) TestBackfillTicketSellableErr() {
+	suite.mockMongodb.On("FindAllData", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error mongodb connection")}))
+
+	err := migrations.All()[4].Up(suite.ctx, suite.mockMongodb)
+
+	assert.Error(suite.T(), err)
+	suite.mockMongodb.AssertNotCalled(suite.T(), "UpdateMany", mock.Anything, mock.Anything)
+}
+
+func (suite *MigrationsTestSuite) TestOrganizerIndexes() {
+	suite.mockMongodb.On("CreateIndexes", mock.MatchedBy(func(payload mongodb.CreateIndexes) bool {
+		keys := payload.Indexes[0].Keys.(bson.D)
+		return payload.CollectionName == "organizer" && keys[0].Key == "organizerId" && *payload.Indexes[0].Options.Unique
+	}), mock.Anything).Return(mockChannel(helpers.Result{})).Once()
+	suite.mockMongodb.On("CreateIndexes", mock.MatchedBy(func(payload mongodb.CreateIndexes) bool {
+		return payload.CollectionName == "event" || payload.CollectionName == "online-ticket-config"
+	}), mock.Anything).Return(mockChannel(helpers.Result{})).Twice()
+
+	err := migrations.All()[5].Up(suite.ctx, suite.mockMongodb)
+
+	assert.NoError(suite.T(), err)
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *MigrationsTestSuite) mockDuplicates(collectionName string, duplicates ...bson.D) {
+	suite.mockMongodb.On("Aggregate", mock.MatchedBy(func(payload mongodb.Aggregate) bool {
+		return payload.CollectionName == collectionName
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		result := reflect.ValueOf(args.Get(0).(mongodb.Aggregate).Result).Elem()
+		for _, key := range duplicates {
+			item := reflect.New(result.Type().Elem()).Elem()
+			item.Field(0).Set(reflect.ValueOf(key))
+			item.Field(1).SetInt(2)
+			result.Set(reflect.Append(result, item))
+		}
+	}).Return(func(payload mongodb.Aggregate, ctx context.Context) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: payload.Result})
+	}).Once()
+}
+
+func (suite *MigrationsTestSuite) TestUniqueTicketType() {
+	suite.mockDuplicates("ticket-detail")
+	suite.mockMongodb.On("DropIndex", mongodb.DropIndex{CollectionName: "ticket-detail", Name: "eventId_1_ticketType_1"}, mock.Anything).
+		Return(mockChannel(helpers.Result{})).Once()
+	suite.mockMongodb.On("CreateIndexes", mock.MatchedBy(func(payload mongodb.CreateIndexes) bool {
+		keys := payload.Indexes[0].Keys.(bson.D)
+		return payload.CollectionName == "ticket-detail" && keys[0].Key == "eventId" && keys[1].Key == "ticketType" &&
+			*payload.Indexes[0].Options.Unique
+	}), mock.Anything).Return(mockChannel(helpers.Result{})).Once()
+
+	err := migrations.All()[6].Up(suite.ctx, suite.mockMongodb)
+
+	assert.NoError(suite.T(), err)
+	suite.mockMongodb.AssertExpectations(suite.T())
+}
+
+func (suite *MigrationsTestSuite) TestUniqueTicketTypeErrDuplicates() {
+	suite.mockDuplicates("ticket-detail", bson.D{{Key: "eventId", Value: "id"}, {Key: "ticketType", Value: "Gold"}})
+
+	err := migrations.All()[6].Up(suite.ctx, suite.mockMongodb)
+
+	assert.EqualError(suite.T(), err, "ticket-detail has duplicate eventId, ticketType, resolve them before the unique index "+
+		"is built: eventId=id ticketType=Gold (2 documents)")
+	suite.mockMongodb.AssertNotCalled(suite.T(), "DropIndex", mock.Anything, mock.Anything)
+	suite.mockMongodb.AssertNotCalled(suite.T(), "CreateIndexes", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,309 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"event-service/internal/pkg/constants"
+	"event-service/internal/pkg/databases/mongodb"
+)
+
+// All lists the migrations of the service. A released migration must never change, add a new version instead.
+func All() []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Description: "create event indexes",
+			Up:          createEventIndexes,
+		},
+		{
+			Version:     2,
+			Description: "create ticket-detail indexes",
+			Up:          createTicketIndexes,
+		},
+		{
+			Version:     3,
+			Description: "create online ticket config and outbox indexes",
+			Up:          createConfigAndOutboxIndexes,
+		},
+		{
+			Version:     4,
+			Description: "backfill event status",
+			Up:          backfillEventStatus,
+		},
+		{
+			Version:     5,
+			Description: "backfill ticket isSellable",
+			Up:          backfillTicketSellable,
+		},
+		{
+			Version:     6,
+			Description: "create organizer indexes",
+			Up:          createOrganizerIndexes,
+		},
+		{
+			Version:     7,
+			Description: "make ticket-detail eventId and ticketType unique",
+			Up:          uniqueTicketType,
+		},
+	}
+}
+
+const (
+	// maxReportedDuplicates bounds the duplicates listed by a failed check, the first ones are enough to start the
+	// cleanup.
+	maxReportedDuplicates = 20
+	// backfillBatchSize bounds the event ids of one backfill update.
+	backfillBatchSize = 500
+)
+
+type duplicate struct {
+	Key   bson.D `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+// checkDuplicates fails with the values of fields shared by several documents, a unique index cannot be built over
+// them. They are left to be resolved by hand, the migration cannot tell which document to keep.
+func checkDuplicates(ctx context.Context, db mongodb.Collections, collectionName string, fields ...string) error {
+	group := bson.D{}
+	for _, field := range fields {
+		group = append(group, bson.E{Key: field, Value: "$" + field})
+	}
+
+	var duplicates []duplicate
+	resp := <-db.Aggregate(mongodb.Aggregate{
+		Result:         &duplicates,
+		CollectionName: collectionName,
+		Filter: []bson.M{
+			{"$group": bson.M{"_id": group, "count": bson.M{"$sum": 1}}},
+			{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+			{"$sort": bson.M{"count": -1}},
+			{"$limit": maxReportedDuplicates},
+		},
+	}, ctx)
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	report := make([]string, 0, len(duplicates))
+	for _, value := range duplicates {
+		keys := make([]string, 0, len(value.Key))
+		for _, key := range value.Key {
+			keys = append(keys, fmt.Sprintf("%s=%v", key.Key, key.Value))
+		}
+		report = append(report, fmt.Sprintf("%s (%d documents)", strings.Join(keys, " "), value.Count))
+	}
+	return fmt.Errorf("%s has duplicate %s, resolve them before the unique index is built: %s", collectionName,
+		strings.Join(fields, ", "), strings.Join(report, "; "))
+}
+
+func createIndexes(ctx context.Context, db mongodb.Collections, collectionName string, indexes ...mongo.IndexModel) error {
+	resp := <-db.CreateIndexes(mongodb.CreateIndexes{
+		CollectionName: collectionName,
+		Indexes:        indexes,
+	}, ctx)
+	return resp.Error
+}
+
+// createEventIndexes makes the event name unique, two concurrent CreateEvent calls can both pass the name check.
+// The tag is shared by the events of one organizer so it is only indexed.
+func createEventIndexes(ctx context.Context, db mongodb.Collections) error {
+	if err := checkDuplicates(ctx, db, "event", "name"); err != nil {
+		return err
+	}
+
+	return createIndexes(ctx, db, "event",
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "eventId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "tag", Value: 1}},
+		},
+		mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "name", Value: "text"},
+				{Key: "description", Value: "text"},
+				{Key: "tag", Value: "text"},
+				{Key: "country.city", Value: "text"},
+				{Key: "country.place", Value: "text"},
+			},
+			// matches in the name weigh the most in the event list search
+			Options: options.Index().SetName(constants.EventTextIndexName).SetWeights(bson.M{
+				"name":          10,
+				"tag":           5,
+				"country.city":  3,
+				"country.place": 3,
+				"description":   1,
+			}),
+		},
+	)
+}
+
+func createTicketIndexes(ctx context.Context, db mongodb.Collections) error {
+	return createIndexes(ctx, db, "ticket-detail",
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "ticketId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "eventId", Value: 1}, {Key: "ticketType", Value: 1}},
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "tag", Value: 1}},
+		},
+	)
+}
+
+func createConfigAndOutboxIndexes(ctx context.Context, db mongodb.Collections) error {
+	if err := createIndexes(ctx, db, "online-ticket-config",
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "tag", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	); err != nil {
+		return err
+	}
+	if err := createIndexes(ctx, db, "online-ticket-config-history",
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "tag", Value: 1}, {Key: "version", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	); err != nil {
+		return err
+	}
+	return createIndexes(ctx, db, "outbox",
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}},
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}},
+		},
+	)
+}
+
+// createOrganizerIndexes looks up the teams of a member and the events and configs of a team.
+func createOrganizerIndexes(ctx context.Context, db mongodb.Collections) error {
+	if err := createIndexes(ctx, db, "organizer",
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "organizerId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "members.userId", Value: 1}},
+		},
+	); err != nil {
+		return err
+	}
+	if err := createIndexes(ctx, db, "event",
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "organizerId", Value: 1}},
+		},
+	); err != nil {
+		return err
+	}
+	return createIndexes(ctx, db, "online-ticket-config",
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "organizerId", Value: 1}, {Key: "tag", Value: 1}},
+		},
+	)
+}
+
+// uniqueTicketType replaces the ticket type index of migration 2 by a unique one, AddTicketType upserts on it and two
+// concurrent calls could otherwise both insert the same ticket type.
+func uniqueTicketType(ctx context.Context, db mongodb.Collections) error {
+	if err := checkDuplicates(ctx, db, "ticket-detail", "eventId", "ticketType"); err != nil {
+		return err
+	}
+
+	// an index cannot change its options in place
+	resp := <-db.DropIndex(mongodb.DropIndex{
+		CollectionName: "ticket-detail",
+		Name:           "eventId_1_ticketType_1",
+	}, ctx)
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	return createIndexes(ctx, db, "ticket-detail",
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "eventId", Value: 1}, {Key: "ticketType", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+}
+
+// backfillEventStatus stores the status of the events created before the lifecycle, they were all published.
+func backfillEventStatus(ctx context.Context, db mongodb.Collections) error {
+	resp := <-db.UpdateMany(mongodb.UpdateMany{
+		CollectionName: "event",
+		Filter:         bson.M{"status": bson.M{"$in": bson.A{nil, ""}}},
+		Document:       bson.M{"status": constants.EventStatusPublished},
+	}, ctx)
+	return resp.Error
+}
+
+// backfillTicketSellable opens the sales of the tickets created before isSellable existed when their event is
+// published, the tickets of the other events stay closed. The published events are read in batches, their number
+// is not bounded.
+func backfillTicketSellable(ctx context.Context, db mongodb.Collections) error {
+	cursor := ""
+	for {
+		var events []struct {
+			EventId string `bson:"eventId"`
+		}
+		resp := <-db.FindAllData(mongodb.FindAllData{
+			Result:         &events,
+			CollectionName: "event",
+			Filter:         bson.M{"status": constants.EventStatusPublished},
+			Projection:     bson.M{"eventId": 1},
+			Sort:           &mongodb.Sort{FieldName: "eventId", By: mongodb.SortAscending},
+			Size:           backfillBatchSize,
+			Keyset:         true,
+			Cursor:         cursor,
+		}, ctx)
+		if resp.Error != nil {
+			return resp.Error
+		}
+
+		if len(events) > 0 {
+			eventIds := make([]string, 0, len(events))
+			for _, event := range events {
+				eventIds = append(eventIds, event.EventId)
+			}
+
+			respUpdate := <-db.UpdateMany(mongodb.UpdateMany{
+				CollectionName: "ticket-detail",
+				Filter:         bson.M{"isSellable": bson.M{"$exists": false}, "eventId": bson.M{"$in": eventIds}},
+				Document:       bson.M{"isSellable": true},
+			}, ctx)
+			if respUpdate.Error != nil {
+				return respUpdate.Error
+			}
+		}
+
+		if resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+
+	resp := <-db.UpdateMany(mongodb.UpdateMany{
+		CollectionName: "ticket-detail",
+		Filter:         bson.M{"isSellable": bson.M{"$exists": false}},
+		Document:       bson.M{"isSellable": false},
+	}, ctx)
+	return resp.Error
+}
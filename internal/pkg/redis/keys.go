@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HashTagKey builds the key `prefix:{tag}`. Redis cluster only hashes the part between the braces, so keys built
+// with the same tag share a slot and can be used together in one Lua script.
+func HashTagKey(prefix string, tag string) string {
+	return fmt.Sprintf("%s:{%s}", prefix, tag)
+}
+
+// hashTag returns the part of key that redis cluster hashes: the text between the first '{' and the next '}', or
+// the whole key when there is no non-empty tag.
+func hashTag(key string) string {
+	start := strings.IndexByte(key, '{')
+	if start < 0 {
+		return key
+	}
+
+	end := strings.IndexByte(key[start+1:], '}')
+	if end <= 0 {
+		return key
+	}
+	return key[start+1 : start+1+end]
+}
+
+// sameHashTag reports whether all keys hash on the same tag, which keeps a multi-key command in one cluster slot.
+func sameHashTag(keys []string) bool {
+	for _, key := range keys {
+		if hashTag(key) != hashTag(keys[0]) {
+			return false
+		}
+	}
+	return true
+}
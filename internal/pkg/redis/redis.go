@@ -15,12 +15,28 @@ import (
 
 // var redisClient *redis.Client
 
+// Nil is the error of a read on a key that does not exist.
+const Nil = redis.Nil
+
+// RedisClient serves Collections from a single node or a cluster, both behind redis.UniversalClient. In cluster mode
+// every multi-key command and script must use keys sharing one hash tag, see HashTagKey.
 type RedisClient struct {
-	Client interface{}
+	Client redis.UniversalClient
 }
 
 func InitConnection(redisDB, redisHost, redisPort, redisPassword string, appConfig string) Collections {
-	var client interface{}
+	client, err := NewConnection(redisDB, redisHost, redisPort, redisPassword, appConfig)
+	if err != nil {
+		fmt.Println("REDIS ERROR:", err.Error())
+		panic("cannot connect redis")
+	}
+	return client
+}
+
+// NewConnection creates the client for appConfig ("cluster" or a single node), wraps it for Datadog when enabled
+// and pings every node before handing it out.
+func NewConnection(redisDB, redisHost, redisPort, redisPassword string, appConfig string) (*RedisClient, error) {
+	var client redis.UniversalClient
 
 	if appConfig != "cluster" {
 		// Create Redis Client
@@ -31,89 +47,98 @@ func InitConnection(redisDB, redisHost, redisPort, redisPassword string, appConf
 			db = int(parseRedisDb)
 		}
 
-		c := redis.NewClient(&redis.Options{
+		client = redis.NewClient(&redis.Options{
 			Addr:     fmt.Sprintf("%v:%v", redisHost, redisPort),
 			Password: redisPassword,
 			DB:       db,
 		})
-
-		if configs.GetConfig().Datadog.DatadogEnabled == "true" {
-			redistrace.WrapClient(c)
-		}
-
-		if c.Ping(context.Background()).Err() != nil {
-			panic("cannot connect redis")
-		}
-		client = c
 	} else {
-		// Create Redis Cluster Client
-		hostArray := strings.Split(redisHost, ",")
-		c := redis.NewClusterClient(&redis.ClusterOptions{
-			Addrs:    hostArray,
+		// Create Redis Cluster Client, REDIS_HOST holds the comma separated host:port of the seed nodes
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    strings.Split(redisHost, ","),
 			Password: redisPassword,
 		})
+	}
 
-		// Test Connection
-		for _, addr := range hostArray {
-			nodeClient := redis.NewClient(&redis.Options{
-				Addr:     addr,
-				Password: redisPassword,
-			})
-
-			_, err := nodeClient.Ping(context.Background()).Result()
-			if err != nil {
-				fmt.Println("REDIS CLUSTER ERROR:", err.Error())
-				panic("Cannot connect to Redis Cluster")
-			}
-			nodeClient.Close()
-		}
-		client = c
+	if configs.GetConfig().Datadog.DatadogEnabled == "true" {
+		redistrace.WrapClient(client)
 	}
-	return &RedisClient{Client: client}
+
+	// Test Connection
+	if err := ping(context.Background(), client); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &RedisClient{Client: client}, nil
+}
+
+func ping(ctx context.Context, client redis.UniversalClient) error {
+	cluster, ok := client.(*redis.ClusterClient)
+	if !ok {
+		return client.Ping(ctx).Err()
+	}
+
+	// a cluster ping only reaches one node, check every shard the cluster reports
+	return cluster.ForEachShard(ctx, func(ctx context.Context, shard *redis.Client) error {
+		return shard.Ping(ctx).Err()
+	})
 }
 
 type Collections interface {
 	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
 	EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd
+	ScriptLoad(ctx context.Context, script string) *redis.StringCmd
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
-	Conn(ctx context.Context) *redis.Conn
 	Get(ctx context.Context, key string) *redis.StringCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Incr(ctx context.Context, key string) *redis.IntCmd
+	SAdd(ctx context.Context, key string, members ...interface{}) *redis.IntCmd
+	SMembers(ctx context.Context, key string) *redis.StringSliceCmd
+	SRem(ctx context.Context, key string, members ...interface{}) *redis.IntCmd
 
 	Close() error
 }
 
 func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
-	return r.Client.(*redis.Client).SetNX(ctx, key, value, expiration)
+	return r.Client.SetNX(ctx, key, value, expiration)
 }
 
 func (r *RedisClient) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
-	return r.Client.(*redis.Client).EvalSha(ctx, sha1, keys, args...)
+	return r.Client.EvalSha(ctx, sha1, keys, args...)
 }
 
-func (r *RedisClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
-	return r.Client.(*redis.Client).Del(ctx, keys...)
+func (r *RedisClient) ScriptLoad(ctx context.Context, script string) *redis.StringCmd {
+	return r.Client.ScriptLoad(ctx, script)
 }
 
-func (r *RedisClient) Conn(ctx context.Context) *redis.Conn {
-	return r.Client.(*redis.Client).Conn(ctx)
+func (r *RedisClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	return r.Client.Del(ctx, keys...)
 }
 
 func (r *RedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
-	return r.Client.(*redis.Client).Get(ctx, key)
+	return r.Client.Get(ctx, key)
 }
 
 func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
-	return r.Client.(*redis.Client).Set(ctx, key, value, expiration)
+	return r.Client.Set(ctx, key, value, expiration)
+}
+
+func (r *RedisClient) Incr(ctx context.Context, key string) *redis.IntCmd {
+	return r.Client.Incr(ctx, key)
+}
+
+func (r *RedisClient) SAdd(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+	return r.Client.SAdd(ctx, key, members...)
+}
+
+func (r *RedisClient) SMembers(ctx context.Context, key string) *redis.StringSliceCmd {
+	return r.Client.SMembers(ctx, key)
+}
+
+func (r *RedisClient) SRem(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+	return r.Client.SRem(ctx, key, members...)
 }
 
 func (r *RedisClient) Close() error {
-	switch c := r.Client.(type) {
-	case *redis.Client:
-		return c.Close()
-	case *redis.ClusterClient:
-		return c.Close()
-	default:
-		return fmt.Errorf("unsupported Redis client type")
-	}
+	return r.Client.Close()
 }
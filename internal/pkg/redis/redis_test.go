@@ -0,0 +1,130 @@
+package redis_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pkgRedis "event-service/internal/pkg/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisTestSuite struct {
+	suite.Suite
+	miniRedis *miniredis.Miniredis
+	ctx       context.Context
+}
+
+func (suite *RedisTestSuite) SetupTest() {
+	suite.miniRedis = miniredis.RunT(suite.T())
+	suite.ctx = context.Background()
+}
+
+func TestRedisTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisTestSuite))
+}
+
+func (suite *RedisTestSuite) newConnection(appConfig string) *pkgRedis.RedisClient {
+	host, port, _ := strings.Cut(suite.miniRedis.Addr(), ":")
+	if appConfig == "cluster" {
+		host = suite.miniRedis.Addr()
+	}
+
+	client, err := pkgRedis.NewConnection("0", host, port, "", appConfig)
+	assert.NoError(suite.T(), err)
+	suite.T().Cleanup(func() { client.Close() })
+	return client
+}
+
+func (suite *RedisTestSuite) TestNewConnection() {
+	client := suite.newConnection("")
+	_, ok := client.Client.(*redis.Client)
+	assert.True(suite.T(), ok)
+}
+
+func (suite *RedisTestSuite) TestNewConnectionCluster() {
+	client := suite.newConnection("cluster")
+	_, ok := client.Client.(*redis.ClusterClient)
+	assert.True(suite.T(), ok)
+}
+
+func (suite *RedisTestSuite) TestNewConnectionErr() {
+	host, port, _ := strings.Cut(suite.miniRedis.Addr(), ":")
+	suite.miniRedis.Close()
+
+	client, err := pkgRedis.NewConnection("0", host, port, "", "")
+	assert.Nil(suite.T(), client)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RedisTestSuite) TestNewConnectionClusterErr() {
+	addr := suite.miniRedis.Addr()
+	suite.miniRedis.Close()
+
+	client, err := pkgRedis.NewConnection("", addr, "", "", "cluster")
+	assert.Nil(suite.T(), client)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RedisTestSuite) TestInitConnectionPanic() {
+	host, port, _ := strings.Cut(suite.miniRedis.Addr(), ":")
+	suite.miniRedis.Close()
+
+	assert.Panics(suite.T(), func() {
+		pkgRedis.InitConnection("0", host, port, "", "")
+	})
+}
+
+func (suite *RedisTestSuite) TestCommands() {
+	for _, appConfig := range []string{"", "cluster"} {
+		suite.miniRedis.FlushAll()
+		client := suite.newConnection(appConfig)
+
+		assert.NoError(suite.T(), client.Set(suite.ctx, "key", "value", time.Minute).Err(), appConfig)
+		value, err := client.Get(suite.ctx, "key").Result()
+		assert.NoError(suite.T(), err, appConfig)
+		assert.Equal(suite.T(), "value", value, appConfig)
+
+		created, err := client.SetNX(suite.ctx, "key", "other", time.Minute).Result()
+		assert.NoError(suite.T(), err, appConfig)
+		assert.False(suite.T(), created, appConfig)
+
+		assert.NoError(suite.T(), client.SAdd(suite.ctx, "set", "a", "b").Err(), appConfig)
+		assert.NoError(suite.T(), client.SRem(suite.ctx, "set", "a").Err(), appConfig)
+		members, err := client.SMembers(suite.ctx, "set").Result()
+		assert.NoError(suite.T(), err, appConfig)
+		assert.Equal(suite.T(), []string{"b"}, members, appConfig)
+
+		deleted, err := client.Del(suite.ctx, "key", "set").Result()
+		assert.NoError(suite.T(), err, appConfig)
+		assert.Equal(suite.T(), int64(2), deleted, appConfig)
+	}
+}
+
+func (suite *RedisTestSuite) TestScript() {
+	script := pkgRedis.NewScript("redis.call('SET', KEYS[1], ARGV[1]) return redis.call('INCRBY', KEYS[2], ARGV[1])")
+	keys := []string{pkgRedis.HashTagKey("A", "id"), pkgRedis.HashTagKey("B", "id")}
+
+	for _, appConfig := range []string{"", "cluster"} {
+		suite.miniRedis.FlushAll()
+		client := suite.newConnection(appConfig)
+
+		// the first run loads the script after NOSCRIPT, the second hits the cached sha
+		for want := int64(2); want <= 4; want += 2 {
+			res, err := script.Run(suite.ctx, client, keys, 2).Int64()
+			assert.NoError(suite.T(), err, appConfig)
+			assert.Equal(suite.T(), want, res, appConfig)
+		}
+		value, _ := suite.miniRedis.Get(keys[0])
+		assert.Equal(suite.T(), "2", value, appConfig)
+	}
+}
+
+func (suite *RedisTestSuite) TestHashTagKey() {
+	assert.Equal(suite.T(), "TICKET-STOCK:{id}", pkgRedis.HashTagKey("TICKET-STOCK", "id"))
+}
@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Script is a Lua script run with EVALSHA. Its sha is computed once from the source, the script is only sent to
+// redis (SCRIPT LOAD) when the server answers NOSCRIPT, e.g. after a restart or on a new cluster node.
+type Script struct {
+	src string
+	sha string
+}
+
+func NewScript(src string) *Script {
+	h := sha1.Sum([]byte(src))
+	return &Script{
+		src: src,
+		sha: hex.EncodeToString(h[:]),
+	}
+}
+
+func (s *Script) Sha() string {
+	return s.sha
+}
+
+// Load sends the script to redis with SCRIPT LOAD so the next EvalSha finds it.
+func (s *Script) Load(ctx context.Context, c Collections) error {
+	return c.ScriptLoad(ctx, s.src).Err()
+}
+
+// Run executes the script with EvalSha, loading it and retrying once when redis does not know the sha yet. Keys
+// must share a hash tag, so the script runs the same on a single node and on a cluster.
+func (s *Script) Run(ctx context.Context, c Collections, keys []string, args ...interface{}) *redis.Cmd {
+	if !sameHashTag(keys) {
+		return redis.NewCmdResult(nil, fmt.Errorf("CROSSSLOT script keys %v do not share a hash tag", keys))
+	}
+
+	cmd := c.EvalSha(ctx, s.sha, keys, args...)
+	if cmd.Err() == nil || !strings.HasPrefix(cmd.Err().Error(), "NOSCRIPT") {
+		return cmd
+	}
+
+	if err := s.Load(ctx, c); err != nil {
+		cmd.SetErr(err)
+		return cmd
+	}
+	return c.EvalSha(ctx, s.sha, keys, args...)
+}
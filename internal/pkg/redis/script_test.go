@@ -0,0 +1,94 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pkgRedis "event-service/internal/pkg/redis"
+	mockredis "event-service/mocks/pkg/redis"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScriptTestSuite struct {
+	suite.Suite
+	mockRedis *mockredis.Collections
+	script    *pkgRedis.Script
+	ctx       context.Context
+}
+
+func (suite *ScriptTestSuite) SetupTest() {
+	suite.mockRedis = new(mockredis.Collections)
+	suite.script = pkgRedis.NewScript("return 1")
+	suite.ctx = context.Background()
+}
+
+func TestScriptTestSuite(t *testing.T) {
+	suite.Run(t, new(ScriptTestSuite))
+}
+
+func (suite *ScriptTestSuite) TestSha() {
+	// sha1 of "return 1", as returned by SCRIPT LOAD
+	assert.Equal(suite.T(), "e0e1f9fabfc9d4800c877a703b823ac0578ff8db", suite.script.Sha())
+}
+
+func (suite *ScriptTestSuite) TestRun() {
+	suite.mockRedis.On("EvalSha", mock.Anything, suite.script.Sha(), []string{"key"}, "arg").
+		Return(redis.NewCmdResult(int64(1), nil)).Once()
+
+	res, err := suite.script.Run(suite.ctx, suite.mockRedis, []string{"key"}, "arg").Int64()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), res)
+	suite.mockRedis.AssertNotCalled(suite.T(), "ScriptLoad", mock.Anything, mock.Anything)
+}
+
+func (suite *ScriptTestSuite) TestRunNoScript() {
+	suite.mockRedis.On("EvalSha", mock.Anything, suite.script.Sha(), []string{"key"}).
+		Return(redis.NewCmdResult(nil, errors.New("NOSCRIPT No matching script. Please use EVAL."))).Once()
+	suite.mockRedis.On("ScriptLoad", mock.Anything, "return 1").Return(redis.NewStringResult(suite.script.Sha(), nil))
+	suite.mockRedis.On("EvalSha", mock.Anything, suite.script.Sha(), []string{"key"}).
+		Return(redis.NewCmdResult(int64(1), nil)).Once()
+
+	res, err := suite.script.Run(suite.ctx, suite.mockRedis, []string{"key"}).Int64()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), res)
+	suite.mockRedis.AssertNumberOfCalls(suite.T(), "EvalSha", 2)
+}
+
+func (suite *ScriptTestSuite) TestRunErrLoad() {
+	suite.mockRedis.On("EvalSha", mock.Anything, mock.Anything, mock.Anything).
+		Return(redis.NewCmdResult(nil, errors.New("NOSCRIPT No matching script. Please use EVAL.")))
+	suite.mockRedis.On("ScriptLoad", mock.Anything, mock.Anything).Return(redis.NewStringResult("", errors.New("connection refused")))
+
+	err := suite.script.Run(suite.ctx, suite.mockRedis, []string{"key"}).Err()
+	assert.EqualError(suite.T(), err, "connection refused")
+	suite.mockRedis.AssertNumberOfCalls(suite.T(), "EvalSha", 1)
+}
+
+func (suite *ScriptTestSuite) TestRunErr() {
+	suite.mockRedis.On("EvalSha", mock.Anything, mock.Anything, mock.Anything).
+		Return(redis.NewCmdResult(nil, errors.New("ERR wrong number of arguments")))
+
+	err := suite.script.Run(suite.ctx, suite.mockRedis, []string{"key"}).Err()
+	assert.Error(suite.T(), err)
+	suite.mockRedis.AssertNotCalled(suite.T(), "ScriptLoad", mock.Anything, mock.Anything)
+}
+
+func (suite *ScriptTestSuite) TestRunCrossSlot() {
+	res := suite.script.Run(suite.ctx, suite.mockRedis, []string{"A:{1}", "B:{2}"})
+	assert.ErrorContains(suite.T(), res.Err(), "CROSSSLOT")
+	suite.mockRedis.AssertNotCalled(suite.T(), "EvalSha", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ScriptTestSuite) TestRunSameHashTag() {
+	keys := []string{"A:{1}", "B:{1}:x", "{1}"}
+	suite.mockRedis.On("EvalSha", mock.Anything, suite.script.Sha(), keys).Return(redis.NewCmdResult(int64(1), nil))
+
+	res, err := suite.script.Run(suite.ctx, suite.mockRedis, keys).Int64()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), res)
+}
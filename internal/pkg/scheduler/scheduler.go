@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"event-service/internal/pkg/log"
+	"event-service/internal/pkg/redis"
+)
+
+// jobDeadlineMargin keeps 1/jobDeadlineMargin of the interval between the job deadline and the lock expiry.
+const jobDeadlineMargin = 5
+
+type Job func(ctx context.Context) error
+
+// Scheduler runs a job on a fixed interval. Every tick first takes a Redis lock so that
+// only one replica executes the job per interval.
+type Scheduler struct {
+	redis    redis.Collections
+	logger   log.Logger
+	lockKey  string
+	interval time.Duration
+	job      Job
+
+	instanceId string
+	stop       chan struct{}
+	wg         sync.WaitGroup
+	once       sync.Once
+}
+
+func NewScheduler(rc redis.Collections, log log.Logger, lockKey string, interval time.Duration, job Job) *Scheduler {
+	hostname, _ := os.Hostname()
+	return &Scheduler{
+		redis:      rc,
+		logger:     log,
+		lockKey:    lockKey,
+		interval:   interval,
+		job:        job,
+		instanceId: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		stop:       make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.RunOnce(context.Background())
+			}
+		}
+	}()
+}
+
+// RunOnce executes the job when the lock is acquired. The lock is left to expire with the
+// interval instead of being released, so a fast replica cannot run the job twice in one tick.
+// The job gets a deadline before the lock expires, a slow run never overlaps the next one.
+func (s *Scheduler) RunOnce(ctx context.Context) bool {
+	acquired, err := s.redis.SetNX(ctx, s.lockKey, s.instanceId, s.interval).Result()
+	if err != nil {
+		s.logger.Error(ctx, fmt.Sprintf("Failed acquire scheduler lock %s", s.lockKey), err.Error())
+		return false
+	}
+
+	if !acquired {
+		return false
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, s.interval-s.interval/jobDeadlineMargin)
+	defer cancel()
+
+	if err := s.job(jobCtx); err != nil {
+		s.logger.Error(ctx, fmt.Sprintf("Failed run scheduler job %s", s.lockKey), err.Error())
+	}
+	return true
+}
+
+func (s *Scheduler) Close(ctx context.Context) error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
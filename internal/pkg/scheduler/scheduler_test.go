@@ -0,0 +1,108 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"event-service/internal/pkg/scheduler"
+	mocklog "event-service/mocks/pkg/log"
+	mockredis "event-service/mocks/pkg/redis"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SchedulerTestSuite struct {
+	suite.Suite
+	mockRedis  *mockredis.Collections
+	mockLogger *mocklog.Logger
+	ctx        context.Context
+	calls      int
+	scheduler  *scheduler.Scheduler
+}
+
+func (suite *SchedulerTestSuite) SetupTest() {
+	suite.mockRedis = new(mockredis.Collections)
+	suite.mockLogger = new(mocklog.Logger)
+	suite.ctx = context.Background()
+	suite.calls = 0
+	suite.scheduler = scheduler.NewScheduler(suite.mockRedis, suite.mockLogger, "LOCK", time.Minute, func(ctx context.Context) error {
+		suite.calls++
+		return nil
+	})
+}
+
+func TestSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
+
+func (suite *SchedulerTestSuite) TestRunOnceLockAcquired() {
+	suite.mockRedis.On("SetNX", mock.Anything, "LOCK", mock.Anything, time.Minute).Return(redis.NewBoolResult(true, nil))
+
+	ran := suite.scheduler.RunOnce(suite.ctx)
+
+	assert.True(suite.T(), ran)
+	assert.Equal(suite.T(), 1, suite.calls)
+}
+
+func (suite *SchedulerTestSuite) TestRunOnceDeadlineBeforeLockExpiry() {
+	suite.mockRedis.On("SetNX", mock.Anything, "LOCK", mock.Anything, time.Minute).Return(redis.NewBoolResult(true, nil))
+	var deadline time.Time
+	var hasDeadline bool
+	s := scheduler.NewScheduler(suite.mockRedis, suite.mockLogger, "LOCK", time.Minute, func(ctx context.Context) error {
+		deadline, hasDeadline = ctx.Deadline()
+		return nil
+	})
+
+	lockExpiry := time.Now().Add(time.Minute)
+	ran := s.RunOnce(suite.ctx)
+
+	assert.True(suite.T(), ran)
+	assert.True(suite.T(), hasDeadline)
+	assert.True(suite.T(), deadline.Before(lockExpiry.Add(-10*time.Second)))
+}
+
+func (suite *SchedulerTestSuite) TestRunOnceLockHeld() {
+	suite.mockRedis.On("SetNX", mock.Anything, "LOCK", mock.Anything, time.Minute).Return(redis.NewBoolResult(false, nil))
+
+	ran := suite.scheduler.RunOnce(suite.ctx)
+
+	assert.False(suite.T(), ran)
+	assert.Equal(suite.T(), 0, suite.calls)
+}
+
+func (suite *SchedulerTestSuite) TestRunOnceErrLock() {
+	suite.mockRedis.On("SetNX", mock.Anything, "LOCK", mock.Anything, time.Minute).Return(redis.NewBoolResult(false, errors.New("error")))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	ran := suite.scheduler.RunOnce(suite.ctx)
+
+	assert.False(suite.T(), ran)
+	assert.Equal(suite.T(), 0, suite.calls)
+}
+
+func (suite *SchedulerTestSuite) TestRunOnceErrJob() {
+	suite.mockRedis.On("SetNX", mock.Anything, "LOCK", mock.Anything, time.Minute).Return(redis.NewBoolResult(true, nil))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	s := scheduler.NewScheduler(suite.mockRedis, suite.mockLogger, "LOCK", time.Minute, func(ctx context.Context) error {
+		return errors.New("error")
+	})
+
+	ran := s.RunOnce(suite.ctx)
+
+	assert.True(suite.T(), ran)
+	suite.mockLogger.AssertCalled(suite.T(), "Error", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *SchedulerTestSuite) TestStartAndClose() {
+	suite.scheduler.Start()
+
+	err := suite.scheduler.Close(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.scheduler.Close(suite.ctx))
+}
@@ -54,6 +54,26 @@ func (_m *MongodbRepositoryQuery) FindOneCountry(ctx context.Context, id int) <-
 	return r0
 }
 
+// FindOneCountryByNumber provides a mock function with given fields: ctx, number
+func (_m *MongodbRepositoryQuery) FindOneCountryByNumber(ctx context.Context, number int) <-chan helpers.Result {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOneCountryByNumber")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMongodbRepositoryQuery(t interface {
@@ -16,6 +16,26 @@ type MongodbRepositoryCommand struct {
 	mock.Mock
 }
 
+// AddEventTicketId provides a mock function with given fields: ctx, eventId, ticketId, updatedBy
+func (_m *MongodbRepositoryCommand) AddEventTicketId(ctx context.Context, eventId string, ticketId string, updatedBy string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId, ticketId, updatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEventTicketId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId, ticketId, updatedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // InsertOneEventCollection provides a mock function with given fields: ctx, _a1
 func (_m *MongodbRepositoryCommand) InsertOneEventCollection(ctx context.Context, _a1 entity.Event) <-chan helpers.Result {
 	ret := _m.Called(ctx, _a1)
@@ -36,6 +56,64 @@ func (_m *MongodbRepositoryCommand) InsertOneEventCollection(ctx context.Context
 	return r0
 }
 
+// UpdateOneEventDetail provides a mock function with given fields: ctx, _a1, expected
+func (_m *MongodbRepositoryCommand) UpdateOneEventDetail(ctx context.Context, _a1 entity.Event, expected entity.EventVersion) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1, expected)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOneEventDetail")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Event, entity.EventVersion) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1, expected)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateOneEventStatus provides a mock function with given fields: ctx, _a1, expected
+func (_m *MongodbRepositoryCommand) UpdateOneEventStatus(ctx context.Context, _a1 entity.Event, expected entity.EventVersion) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1, expected)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOneEventStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Event, entity.EventVersion) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1, expected)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// WithTransaction provides a mock function with given fields: ctx, fn
+func (_m *MongodbRepositoryCommand) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMongodbRepositoryCommand(t interface {
@@ -4,12 +4,13 @@ package mocks
 
 import (
 	context "context"
+	entity "event-service/internal/modules/event/models/entity"
 
 	helpers "event-service/internal/pkg/helpers"
 
 	mock "github.com/stretchr/testify/mock"
 
-	request "event-service/internal/modules/event/models/request"
+	time "time"
 )
 
 // MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
@@ -17,17 +18,37 @@ type MongodbRepositoryQuery struct {
 	mock.Mock
 }
 
-// FindAllEvent provides a mock function with given fields: ctx, payload
-func (_m *MongodbRepositoryQuery) FindAllEvent(ctx context.Context, payload request.AllEventReq) <-chan helpers.Result {
-	ret := _m.Called(ctx, payload)
+// FindAllEvent provides a mock function with given fields: ctx, filter
+func (_m *MongodbRepositoryQuery) FindAllEvent(ctx context.Context, filter entity.EventFilter) <-chan helpers.Result {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindAllEvent")
 	}
 
 	var r0 <-chan helpers.Result
-	if rf, ok := ret.Get(0).(func(context.Context, request.AllEventReq) <-chan helpers.Result); ok {
-		r0 = rf(ctx, payload)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.EventFilter) <-chan helpers.Result); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEventById provides a mock function with given fields: ctx, eventId
+func (_m *MongodbRepositoryQuery) FindEventById(ctx context.Context, eventId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEventById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(<-chan helpers.Result)
@@ -77,6 +98,26 @@ func (_m *MongodbRepositoryQuery) FindEventByTag(ctx context.Context, tag string
 	return r0
 }
 
+// FindEventsByStatusBefore provides a mock function with given fields: ctx, status, field, before
+func (_m *MongodbRepositoryQuery) FindEventsByStatusBefore(ctx context.Context, status string, field string, before time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, status, field, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEventsByStatusBefore")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, status, field, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMongodbRepositoryQuery(t interface {
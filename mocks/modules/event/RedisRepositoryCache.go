@@ -0,0 +1,153 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "event-service/internal/modules/event/models/entity"
+
+	helpers "event-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RedisRepositoryCache is an autogenerated mock type for the RedisRepositoryCache type
+type RedisRepositoryCache struct {
+	mock.Mock
+}
+
+// FindAllEvent provides a mock function with given fields: ctx, filter
+func (_m *RedisRepositoryCache) FindAllEvent(ctx context.Context, filter entity.EventFilter) <-chan helpers.Result {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllEvent")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.EventFilter) <-chan helpers.Result); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEventById provides a mock function with given fields: ctx, eventId
+func (_m *RedisRepositoryCache) FindEventById(ctx context.Context, eventId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEventById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEventByName provides a mock function with given fields: ctx, name
+func (_m *RedisRepositoryCache) FindEventByName(ctx context.Context, name string) <-chan helpers.Result {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEventByName")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEventByTag provides a mock function with given fields: ctx, tag
+func (_m *RedisRepositoryCache) FindEventByTag(ctx context.Context, tag string) <-chan helpers.Result {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEventByTag")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEventsByStatusBefore provides a mock function with given fields: ctx, status, field, before
+func (_m *RedisRepositoryCache) FindEventsByStatusBefore(ctx context.Context, status string, field string, before time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, status, field, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEventsByStatusBefore")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, status, field, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// RefreshEvent provides a mock function with given fields: ctx, _a1
+func (_m *RedisRepositoryCache) RefreshEvent(ctx context.Context, _a1 entity.Event) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshEvent")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Event) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewRedisRepositoryCache creates a new instance of RedisRepositoryCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRedisRepositoryCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RedisRepositoryCache {
+	mock := &RedisRepositoryCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
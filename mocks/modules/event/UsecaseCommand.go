@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	request "event-service/internal/modules/event/models/request"
+
+	response "event-service/internal/modules/event/models/response"
 )
 
 // UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
@@ -15,6 +17,132 @@ type UsecaseCommand struct {
 	mock.Mock
 }
 
+// AcknowledgeBankTicket provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) AcknowledgeBankTicket(origCtx context.Context, payload request.BankTicketCreatedReq) error {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcknowledgeBankTicket")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.BankTicketCreatedReq) error); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AcknowledgeOrder provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) AcknowledgeOrder(origCtx context.Context, payload request.OrderAckReq) error {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcknowledgeOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.OrderAckReq) error); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddTicketType provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) AddTicketType(origCtx context.Context, payload request.AddTicketReq) (*string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTicketType")
+	}
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.AddTicketReq) (*string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.AddTicketReq) *string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.AddTicketReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelEvent provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) CancelEvent(origCtx context.Context, payload request.CancelEventReq) (*string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelEvent")
+	}
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.CancelEventReq) (*string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.CancelEventReq) *string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.CancelEventReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmTicketHold provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ConfirmTicketHold(origCtx context.Context, payload request.TicketHoldReq) (*string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmTicketHold")
+	}
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.TicketHoldReq) (*string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.TicketHoldReq) *string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.TicketHoldReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateEvent provides a mock function with given fields: origCtx, payload
 func (_m *UsecaseCommand) CreateEvent(origCtx context.Context, payload request.EventReq) (*string, error) {
 	ret := _m.Called(origCtx, payload)
@@ -75,6 +203,252 @@ func (_m *UsecaseCommand) CreateOnlineTicketConfig(origCtx context.Context, payl
 	return r0, r1
 }
 
+// DeleteOnlineTicketConfig provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) DeleteOnlineTicketConfig(origCtx context.Context, payload request.OnlineTicketConfigReq) (*string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOnlineTicketConfig")
+	}
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.OnlineTicketConfigReq) (*string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.OnlineTicketConfigReq) *string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.OnlineTicketConfigReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HoldTicket provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) HoldTicket(origCtx context.Context, payload request.HoldTicketReq) (*response.TicketHold, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HoldTicket")
+	}
+
+	var r0 *response.TicketHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.HoldTicketReq) (*response.TicketHold, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.HoldTicketReq) *response.TicketHold); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.TicketHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.HoldTicketReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessScheduledEvents provides a mock function with given fields: origCtx
+func (_m *UsecaseCommand) ProcessScheduledEvents(origCtx context.Context) error {
+	ret := _m.Called(origCtx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessScheduledEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(origCtx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublishEvent provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) PublishEvent(origCtx context.Context, payload request.PublishEventReq) (*string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishEvent")
+	}
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.PublishEventReq) (*string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.PublishEventReq) *string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.PublishEventReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileTicketStock provides a mock function with given fields: origCtx
+func (_m *UsecaseCommand) ReconcileTicketStock(origCtx context.Context) error {
+	ret := _m.Called(origCtx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileTicketStock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(origCtx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseTicketHold provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ReleaseTicketHold(origCtx context.Context, payload request.TicketHoldReq) (*string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseTicketHold")
+	}
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.TicketHoldReq) (*string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.TicketHoldReq) *string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.TicketHoldReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReprovisionTickets provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ReprovisionTickets(origCtx context.Context, payload request.ReprovisionTicketReq) (*string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReprovisionTickets")
+	}
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ReprovisionTicketReq) (*string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ReprovisionTicketReq) *string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ReprovisionTicketReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateEvent provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpdateEvent(origCtx context.Context, payload request.UpdateEventReq) (*string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEvent")
+	}
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.UpdateEventReq) (*string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.UpdateEventReq) *string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.UpdateEventReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTicket provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpdateTicket(origCtx context.Context, payload request.UpdateTicketReq) (*string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTicket")
+	}
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.UpdateTicketReq) (*string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.UpdateTicketReq) *string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.UpdateTicketReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUsecaseCommand(t interface {
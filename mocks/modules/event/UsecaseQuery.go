@@ -17,6 +17,36 @@ type UsecaseQuery struct {
 	mock.Mock
 }
 
+// FindEventDetail provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindEventDetail(origCtx context.Context, payload request.EventDetailReq) (*response.EventDetail, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEventDetail")
+	}
+
+	var r0 *response.EventDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.EventDetailReq) (*response.EventDetail, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.EventDetailReq) *response.EventDetail); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.EventDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.EventDetailReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindEvents provides a mock function with given fields: origCtx, payload
 func (_m *UsecaseQuery) FindEvents(origCtx context.Context, payload request.AllEventReq) (*response.EventResp, error) {
 	ret := _m.Called(origCtx, payload)
@@ -47,6 +77,156 @@ func (_m *UsecaseQuery) FindEvents(origCtx context.Context, payload request.AllE
 	return r0, r1
 }
 
+// FindOnlineTicketAllocation provides a mock function with given fields: origCtx, tag
+func (_m *UsecaseQuery) FindOnlineTicketAllocation(origCtx context.Context, tag string) (*response.OnlineTicketAllocation, error) {
+	ret := _m.Called(origCtx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOnlineTicketAllocation")
+	}
+
+	var r0 *response.OnlineTicketAllocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.OnlineTicketAllocation, error)); ok {
+		return rf(origCtx, tag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.OnlineTicketAllocation); ok {
+		r0 = rf(origCtx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.OnlineTicketAllocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOnlineTicketConfig provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindOnlineTicketConfig(origCtx context.Context, payload request.OnlineTicketConfigReq) (*response.OnlineTicketConfig, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOnlineTicketConfig")
+	}
+
+	var r0 *response.OnlineTicketConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.OnlineTicketConfigReq) (*response.OnlineTicketConfig, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.OnlineTicketConfigReq) *response.OnlineTicketConfig); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.OnlineTicketConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.OnlineTicketConfigReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOnlineTicketConfigHistory provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindOnlineTicketConfigHistory(origCtx context.Context, payload request.OnlineTicketConfigReq) ([]response.OnlineTicketConfigHistory, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOnlineTicketConfigHistory")
+	}
+
+	var r0 []response.OnlineTicketConfigHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.OnlineTicketConfigReq) ([]response.OnlineTicketConfigHistory, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.OnlineTicketConfigReq) []response.OnlineTicketConfigHistory); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.OnlineTicketConfigHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.OnlineTicketConfigReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOnlineTicketConfigs provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindOnlineTicketConfigs(origCtx context.Context, payload request.AllOnlineTicketConfigReq) (*response.OnlineTicketConfigResp, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOnlineTicketConfigs")
+	}
+
+	var r0 *response.OnlineTicketConfigResp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.AllOnlineTicketConfigReq) (*response.OnlineTicketConfigResp, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.AllOnlineTicketConfigReq) *response.OnlineTicketConfigResp); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.OnlineTicketConfigResp)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.AllOnlineTicketConfigReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTicketAvailability provides a mock function with given fields: origCtx, ticketId
+func (_m *UsecaseQuery) FindTicketAvailability(origCtx context.Context, ticketId string) (*response.TicketAvailability, error) {
+	ret := _m.Called(origCtx, ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTicketAvailability")
+	}
+
+	var r0 *response.TicketAvailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.TicketAvailability, error)); ok {
+		return rf(origCtx, ticketId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.TicketAvailability); ok {
+		r0 = rf(origCtx, ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.TicketAvailability)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, ticketId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUsecaseQuery(t interface {
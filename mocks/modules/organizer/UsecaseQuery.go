@@ -0,0 +1,62 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "event-service/internal/modules/organizer/models/request"
+
+	response "event-service/internal/modules/organizer/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindOrganizer provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindOrganizer(origCtx context.Context, payload request.OrganizerReq) (*response.Organizer, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrganizer")
+	}
+
+	var r0 *response.Organizer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.OrganizerReq) (*response.Organizer, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.OrganizerReq) *response.Organizer); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Organizer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.OrganizerReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
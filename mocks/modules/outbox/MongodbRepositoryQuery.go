@@ -0,0 +1,73 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	helpers "event-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "event-service/internal/modules/outbox/models/request"
+
+	time "time"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// FindDueMessages provides a mock function with given fields: ctx, now, limit
+func (_m *MongodbRepositoryQuery) FindDueMessages(ctx context.Context, now time.Time, limit int64) <-chan helpers.Result {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDueMessages")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int64) <-chan helpers.Result); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindStuckMessages provides a mock function with given fields: ctx, createdBefore, payload
+func (_m *MongodbRepositoryQuery) FindStuckMessages(ctx context.Context, createdBefore time.Time, payload request.StuckMessageReq) <-chan helpers.Result {
+	ret := _m.Called(ctx, createdBefore, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStuckMessages")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, request.StuckMessageReq) <-chan helpers.Result); ok {
+		r0 = rf(ctx, createdBefore, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -15,6 +15,66 @@ type MongodbRepositoryCommand struct {
 	mock.Mock
 }
 
+// DeleteOneOnlineTicketConfig provides a mock function with given fields: ctx, tag
+func (_m *MongodbRepositoryCommand) DeleteOneOnlineTicketConfig(ctx context.Context, tag string) <-chan helpers.Result {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOneOnlineTicketConfig")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindOnlineTicketConfigByTag provides a mock function with given fields: ctx, tag
+func (_m *MongodbRepositoryCommand) FindOnlineTicketConfigByTag(ctx context.Context, tag string) <-chan helpers.Result {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOnlineTicketConfigByTag")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// IncOnlineTicketSold provides a mock function with given fields: ctx, tag, countryCode, quantity
+func (_m *MongodbRepositoryCommand) IncOnlineTicketSold(ctx context.Context, tag string, countryCode string, quantity int) <-chan helpers.Result {
+	ret := _m.Called(ctx, tag, countryCode, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncOnlineTicketSold")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, tag, countryCode, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // InsertManyTicketCollection provides a mock function with given fields: ctx, _a1
 func (_m *MongodbRepositoryCommand) InsertManyTicketCollection(ctx context.Context, _a1 []entity.Ticket) <-chan helpers.Result {
 	ret := _m.Called(ctx, _a1)
@@ -35,6 +95,166 @@ func (_m *MongodbRepositoryCommand) InsertManyTicketCollection(ctx context.Conte
 	return r0
 }
 
+// InsertOneOnlineTicketConfigHistory provides a mock function with given fields: ctx, history
+func (_m *MongodbRepositoryCommand) InsertOneOnlineTicketConfigHistory(ctx context.Context, history entity.OnlineTicketConfigHistory) <-chan helpers.Result {
+	ret := _m.Called(ctx, history)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneOnlineTicketConfigHistory")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.OnlineTicketConfigHistory) <-chan helpers.Result); ok {
+		r0 = rf(ctx, history)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOneTicketIfAbsent provides a mock function with given fields: ctx, _a1
+func (_m *MongodbRepositoryCommand) InsertOneTicketIfAbsent(ctx context.Context, _a1 entity.Ticket) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneTicketIfAbsent")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Ticket) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateManyTicketEventInfo provides a mock function with given fields: ctx, eventId, tag, continentName, continentCode, country
+func (_m *MongodbRepositoryCommand) UpdateManyTicketEventInfo(ctx context.Context, eventId string, tag string, continentName string, continentCode string, country entity.Country) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId, tag, continentName, continentCode, country)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateManyTicketEventInfo")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, entity.Country) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId, tag, continentName, continentCode, country)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateManyTicketSellable provides a mock function with given fields: ctx, eventId, isSellable
+func (_m *MongodbRepositoryCommand) UpdateManyTicketSellable(ctx context.Context, eventId string, isSellable bool) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId, isSellable)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateManyTicketSellable")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId, isSellable)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateOneTicketPrice provides a mock function with given fields: ctx, ticketId, ticketPrice
+func (_m *MongodbRepositoryCommand) UpdateOneTicketPrice(ctx context.Context, ticketId string, ticketPrice int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, ticketPrice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOneTicketPrice")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, ticketPrice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateOneTicketProvisioning provides a mock function with given fields: ctx, ticketId, status, reason
+func (_m *MongodbRepositoryCommand) UpdateOneTicketProvisioning(ctx context.Context, ticketId string, status string, reason string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, status, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOneTicketProvisioning")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, status, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateOneTicketQuota provides a mock function with given fields: ctx, ticketId, currentQuota, delta
+func (_m *MongodbRepositoryCommand) UpdateOneTicketQuota(ctx context.Context, ticketId string, currentQuota int, delta int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, currentQuota, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOneTicketQuota")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, currentQuota, delta)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateOneTicketRemaining provides a mock function with given fields: ctx, ticketId, currentRemaining, totalRemaining
+func (_m *MongodbRepositoryCommand) UpdateOneTicketRemaining(ctx context.Context, ticketId string, currentRemaining int, totalRemaining int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, currentRemaining, totalRemaining)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOneTicketRemaining")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, currentRemaining, totalRemaining)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // UpsertOneOnlineTicketConfig provides a mock function with given fields: ctx, payload
 func (_m *MongodbRepositoryCommand) UpsertOneOnlineTicketConfig(ctx context.Context, payload entity.OnlineTicketConfig) <-chan helpers.Result {
 	ret := _m.Called(ctx, payload)
@@ -0,0 +1,190 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "event-service/internal/modules/ticket/models/entity"
+	helpers "event-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// AggregateOnlineTicketAllocation provides a mock function with given fields: ctx, tag
+func (_m *MongodbRepositoryQuery) AggregateOnlineTicketAllocation(ctx context.Context, tag string) <-chan helpers.Result {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateOnlineTicketAllocation")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindAllOnlineTicketConfig provides a mock function with given fields: ctx, createdBy, page, size
+func (_m *MongodbRepositoryQuery) FindAllOnlineTicketConfig(ctx context.Context, createdBy string, page int64, size int64) <-chan helpers.Result {
+	ret := _m.Called(ctx, createdBy, page, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllOnlineTicketConfig")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) <-chan helpers.Result); ok {
+		r0 = rf(ctx, createdBy, page, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindAllOnlineTicketConfigByOrganizer provides a mock function with given fields: ctx, organizerId, page, size
+func (_m *MongodbRepositoryQuery) FindAllOnlineTicketConfigByOrganizer(ctx context.Context, organizerId string, page int64, size int64) <-chan helpers.Result {
+	ret := _m.Called(ctx, organizerId, page, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllOnlineTicketConfigByOrganizer")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) <-chan helpers.Result); ok {
+		r0 = rf(ctx, organizerId, page, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEventIdsByTicket provides a mock function with given fields: ctx, filter
+func (_m *MongodbRepositoryQuery) FindEventIdsByTicket(ctx context.Context, filter entity.TicketFilter) <-chan helpers.Result {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEventIdsByTicket")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TicketFilter) <-chan helpers.Result); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindOnlineTicketConfigByTag provides a mock function with given fields: ctx, tag
+func (_m *MongodbRepositoryQuery) FindOnlineTicketConfigByTag(ctx context.Context, tag string) <-chan helpers.Result {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOnlineTicketConfigByTag")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindOnlineTicketConfigHistory provides a mock function with given fields: ctx, tag
+func (_m *MongodbRepositoryQuery) FindOnlineTicketConfigHistory(ctx context.Context, tag string) <-chan helpers.Result {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOnlineTicketConfigHistory")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindTicketById provides a mock function with given fields: ctx, ticketId
+func (_m *MongodbRepositoryQuery) FindTicketById(ctx context.Context, ticketId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTicketById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindTicketsByEventId provides a mock function with given fields: ctx, eventId
+func (_m *MongodbRepositoryQuery) FindTicketsByEventId(ctx context.Context, eventId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTicketsByEventId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
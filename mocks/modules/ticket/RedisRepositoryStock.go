@@ -0,0 +1,192 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "event-service/internal/modules/ticket/models/entity"
+	helpers "event-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RedisRepositoryStock is an autogenerated mock type for the RedisRepositoryStock type
+type RedisRepositoryStock struct {
+	mock.Mock
+}
+
+// AdjustStock provides a mock function with given fields: ctx, ticketId, delta
+func (_m *RedisRepositoryStock) AdjustStock(ctx context.Context, ticketId string, delta int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustStock")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, delta)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// ConfirmHold provides a mock function with given fields: ctx, ticketId, holdId, userId, now
+func (_m *RedisRepositoryStock) ConfirmHold(ctx context.Context, ticketId string, holdId string, userId string, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, holdId, userId, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmHold")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, holdId, userId, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindStockTicketIds provides a mock function with given fields: ctx
+func (_m *RedisRepositoryStock) FindStockTicketIds(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStockTicketIds")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// HoldStock provides a mock function with given fields: ctx, hold, countryLeft, now
+func (_m *RedisRepositoryStock) HoldStock(ctx context.Context, hold entity.TicketHold, countryLeft int, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, hold, countryLeft, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HoldStock")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TicketHold, int, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, hold, countryLeft, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InitStock provides a mock function with given fields: ctx, ticketId, totalRemaining
+func (_m *RedisRepositoryStock) InitStock(ctx context.Context, ticketId string, totalRemaining int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, totalRemaining)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitStock")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, totalRemaining)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// ReleaseHold provides a mock function with given fields: ctx, ticketId, holdId, userId, now
+func (_m *RedisRepositoryStock) ReleaseHold(ctx context.Context, ticketId string, holdId string, userId string, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, holdId, userId, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseHold")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, holdId, userId, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// RemoveStockTicketId provides a mock function with given fields: ctx, ticketId
+func (_m *RedisRepositoryStock) RemoveStockTicketId(ctx context.Context, ticketId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveStockTicketId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// SyncStock provides a mock function with given fields: ctx, ticketId, now
+func (_m *RedisRepositoryStock) SyncStock(ctx context.Context, ticketId string, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncStock")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewRedisRepositoryStock creates a new instance of RedisRepositoryStock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRedisRepositoryStock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RedisRepositoryStock {
+	mock := &RedisRepositoryStock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,9 +1,10 @@
-// Code generated by mockery v2.39.1. DO NOT EDIT.
+// Code generated by mockery v2.40.1. DO NOT EDIT.
 
 package mocks
 
 import (
 	context "context"
+	kafka "event-service/internal/pkg/kafka/confluent"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -36,6 +37,44 @@ func (_m *Producer) Publish(topic string, message []byte, kafkaPartition *int32)
 	_m.Called(topic, message, kafkaPartition)
 }
 
+// PublishBatchSync provides a mock function with given fields: ctx, messages
+func (_m *Producer) PublishBatchSync(ctx context.Context, messages []kafka.Message) []error {
+	ret := _m.Called(ctx, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishBatchSync")
+	}
+
+	var r0 []error
+	if rf, ok := ret.Get(0).(func(context.Context, []kafka.Message) []error); ok {
+		r0 = rf(ctx, messages)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	return r0
+}
+
+// PublishSync provides a mock function with given fields: ctx, topic, key, value, headers
+func (_m *Producer) PublishSync(ctx context.Context, topic string, key []byte, value []byte, headers map[string]string) error {
+	ret := _m.Called(ctx, topic, key, value, headers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishSync")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, []byte, map[string]string) error); ok {
+		r0 = rf(ctx, topic, key, value, headers)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewProducer creates a new instance of Producer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProducer(t interface {
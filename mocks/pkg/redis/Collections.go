@@ -1,4 +1,4 @@
-// Code generated by mockery v2.39.1. DO NOT EDIT.
+// Code generated by mockery v2.40.1. DO NOT EDIT.
 
 package mocks
 
@@ -35,26 +35,6 @@ func (_m *Collections) Close() error {
 	return r0
 }
 
-// Conn provides a mock function with given fields: ctx
-func (_m *Collections) Conn(ctx context.Context) *v8.Conn {
-	ret := _m.Called(ctx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for Conn")
-	}
-
-	var r0 *v8.Conn
-	if rf, ok := ret.Get(0).(func(context.Context) *v8.Conn); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v8.Conn)
-		}
-	}
-
-	return r0
-}
-
 // Del provides a mock function with given fields: ctx, keys
 func (_m *Collections) Del(ctx context.Context, keys ...string) *v8.IntCmd {
 	_va := make([]interface{}, len(keys))
@@ -125,6 +105,112 @@ func (_m *Collections) Get(ctx context.Context, key string) *v8.StringCmd {
 	return r0
 }
 
+// Incr provides a mock function with given fields: ctx, key
+func (_m *Collections) Incr(ctx context.Context, key string) *v8.IntCmd {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Incr")
+	}
+
+	var r0 *v8.IntCmd
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v8.IntCmd); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v8.IntCmd)
+		}
+	}
+
+	return r0
+}
+
+// SAdd provides a mock function with given fields: ctx, key, members
+func (_m *Collections) SAdd(ctx context.Context, key string, members ...interface{}) *v8.IntCmd {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key)
+	_ca = append(_ca, members...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SAdd")
+	}
+
+	var r0 *v8.IntCmd
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) *v8.IntCmd); ok {
+		r0 = rf(ctx, key, members...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v8.IntCmd)
+		}
+	}
+
+	return r0
+}
+
+// SMembers provides a mock function with given fields: ctx, key
+func (_m *Collections) SMembers(ctx context.Context, key string) *v8.StringSliceCmd {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SMembers")
+	}
+
+	var r0 *v8.StringSliceCmd
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v8.StringSliceCmd); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v8.StringSliceCmd)
+		}
+	}
+
+	return r0
+}
+
+// SRem provides a mock function with given fields: ctx, key, members
+func (_m *Collections) SRem(ctx context.Context, key string, members ...interface{}) *v8.IntCmd {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key)
+	_ca = append(_ca, members...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SRem")
+	}
+
+	var r0 *v8.IntCmd
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) *v8.IntCmd); ok {
+		r0 = rf(ctx, key, members...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v8.IntCmd)
+		}
+	}
+
+	return r0
+}
+
+// ScriptLoad provides a mock function with given fields: ctx, script
+func (_m *Collections) ScriptLoad(ctx context.Context, script string) *v8.StringCmd {
+	ret := _m.Called(ctx, script)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScriptLoad")
+	}
+
+	var r0 *v8.StringCmd
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v8.StringCmd); ok {
+		r0 = rf(ctx, script)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v8.StringCmd)
+		}
+	}
+
+	return r0
+}
+
 // Set provides a mock function with given fields: ctx, key, value, expiration
 func (_m *Collections) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *v8.StatusCmd {
 	ret := _m.Called(ctx, key, value, expiration)